@@ -19,6 +19,14 @@ chart: {{ template "runai-common.chart" . }}
 release: {{ .Release.Name }}
 heritage: {{ .Release.Service }}
 createdBy: "RunaiJob"
+{{- include "runai-common.user-label" . }}
+{{- end }}
+
+{{- /* the user is kept in an annotation as well, as a label value can't have every character of a user name */ -}}
+{{- define "runai-common.user-label" }}
+{{- if and .Values.user (regexMatch "^[A-Za-z0-9]([-A-Za-z0-9_.]{0,61}[A-Za-z0-9])?$" .Values.user) }}
+user: {{ .Values.user | quote }}
+{{- end }}
 {{- end }}
 
 {{- define "host.path.volume.name" -}}
@@ -2,19 +2,41 @@ package cluster
 
 import (
 	"fmt"
-	"strings"
 	"github.com/run-ai/runai-cli/cmd/completion"
 	"os"
+	"strings"
 	"text/tabwriter"
 
-	commandUtil "github.com/run-ai/runai-cli/pkg/util/command"
 	"github.com/run-ai/runai-cli/cmd/constants"
+	"github.com/run-ai/runai-cli/cmd/flags"
+	"github.com/run-ai/runai-cli/pkg/types"
+	"github.com/run-ai/runai-cli/pkg/ui"
+	commandUtil "github.com/run-ai/runai-cli/pkg/util/command"
 
 	"github.com/spf13/cobra"
 	"k8s.io/client-go/tools/clientcmd"
 )
 
-func runListCommand(cmd *cobra.Command, args []string) error {
+var (
+	clusterListHiddenFields = ui.EnsureStringPaths(types.ClusterView{}, []string{
+		"Current",
+	})
+
+	clusterListFormatters = map[string]ui.FormatFunction{
+		"clustername": func(value, model interface{}) (string, error) {
+			clusterView := model.(types.ClusterView)
+			if clusterView.Current {
+				return fmt.Sprintf("%s (current)", clusterView.Name), nil
+			}
+			return clusterView.Name, nil
+		},
+	}
+)
+
+func runListCommand(cmd *cobra.Command, args []string, output ui.OutputOpt) error {
+	if err := output.Validate(); err != nil {
+		return err
+	}
 
 	configAccess := clientcmd.DefaultClientConfig.ConfigAccess()
 	config, err := configAccess.GetStartingConfig()
@@ -25,11 +47,7 @@ func runListCommand(cmd *cobra.Command, args []string) error {
 
 	currentContext := config.CurrentContext
 
-	fmt.Printf("Configured clusters on this computer are:\n")
-
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	fmt.Fprintf(w, "CLUSTER\tCURRENT PROJECT\n")
-
+	clusterViews := []types.ClusterView{}
 	for name, context := range config.Contexts {
 		project := ""
 		if strings.HasPrefix(context.Namespace, constants.RunaiNsProjectPrefix) {
@@ -37,23 +55,39 @@ func runListCommand(cmd *cobra.Command, args []string) error {
 			project = context.Namespace[lenNsPrefix:len(context.Namespace)]
 		}
 
-		if name == currentContext {
-			fmt.Fprintf(w, "%s (current)\t%s\n", name, project)
-		} else {
-			fmt.Fprintf(w, "%s\t%s\n", name, project)
-		}
+		clusterViews = append(clusterViews, types.ClusterView{
+			Name:    name,
+			Current: name == currentContext,
+			Project: project,
+		})
+	}
+
+	if !output.IsTable() {
+		return ui.PrintList(os.Stdout, clusterViews, ui.ListOpt{Kind: "cluster", NamePath: "{.name}"}, output)
 	}
-	_ = w.Flush()
 
-	return nil
+	fmt.Printf("Configured clusters on this computer are:\n")
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	err = ui.CreateTable(types.ClusterView{}, ui.TableOpt{
+		DisplayOpt: ui.DisplayOpt{Hide: clusterListHiddenFields},
+		Formatts:   clusterListFormatters,
+		NoHeaders:  output.NoHeaders,
+	}).Render(w, clusterViews).Error()
+	if err != nil {
+		return err
+	}
+	return w.Flush()
 }
 
 func listCommandDEPRECATED() *cobra.Command {
 
 	var command = &cobra.Command{
-		Use:        "list",
-		Short:      fmt.Sprint("List all avaliable clusters."),
-		Run:        commandUtil.WrapRunCommand(runListCommand),
+		Use:   "list",
+		Short: fmt.Sprint("List all avaliable clusters."),
+		Run: commandUtil.WrapRunCommand(func(cmd *cobra.Command, args []string) error {
+			return runListCommand(cmd, args, ui.OutputOpt{})
+		}),
 		Deprecated: "Please use: 'runai list cluster' instead",
 	}
 
@@ -61,14 +95,19 @@ func listCommandDEPRECATED() *cobra.Command {
 }
 
 func ListCommand() *cobra.Command {
+	var output ui.OutputOpt
 
 	var command = &cobra.Command{
-		Use:     "clusters",
-		Aliases: []string{"cluster"},
-		Short:   "List all available clusters",
+		Use:               "clusters",
+		Aliases:           []string{"cluster"},
+		Short:             "List all available clusters",
 		ValidArgsFunction: completion.NoArgs,
-		Run:     commandUtil.WrapRunCommand(runListCommand),
+		Run: commandUtil.WrapRunCommand(func(cmd *cobra.Command, args []string) error {
+			return runListCommand(cmd, args, output)
+		}),
 	}
 
+	flags.AddOutputFlags(command.Flags(), &output)
+
 	return command
 }
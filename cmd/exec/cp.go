@@ -0,0 +1,257 @@
+package exec
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/run-ai/runai-cli/cmd/job"
+	raUtil "github.com/run-ai/runai-cli/cmd/util"
+	"github.com/run-ai/runai-cli/pkg/authentication/assertion"
+	"github.com/run-ai/runai-cli/pkg/client"
+	commandUtil "github.com/run-ai/runai-cli/pkg/util/command"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/rest"
+	kubeExec "k8s.io/kubectl/pkg/cmd/exec"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/scheme"
+)
+
+const cpExamples = `
+# Copy the checkpoints directory of job train1 to the local directory ./checkpoints
+runai cp train1:/workspace/checkpoints ./checkpoints
+
+# Copy a local file into the /etc/train directory of a specific pod of job hpo1
+runai cp ./config.yaml hpo1:/etc/train/ --pod hpo1-0-x7k2p
+`
+
+// copyLocation is a source or destination of runai cp, which is either a local path or a path in a job
+type copyLocation struct {
+	Job  string
+	Path string
+}
+
+func (location copyLocation) isRemote() bool {
+	return location.Job != ""
+}
+
+func (location copyLocation) String() string {
+	if location.isRemote() {
+		return fmt.Sprintf("%s:%s", location.Job, location.Path)
+	}
+	return location.Path
+}
+
+func NewCopyCommand() *cobra.Command {
+	var podName string
+	var quiet bool
+
+	var command = &cobra.Command{
+		Use:   "cp SOURCE DESTINATION",
+		Short: "Copy files and directories between the local machine and a running job.",
+		Long: `Copy files and directories between the local machine and a running job. Either the source or the destination
+is a path in a job, in the format of JOB_NAME:PATH. Directories are copied recursively. A path in a job which ends
+with / is a directory to copy into, and so is an existing local directory. The container of the job must have tar.`,
+		Example:           cpExamples,
+		ValidArgsFunction: job.GenJobNames,
+		Args:              cobra.ExactArgs(2),
+		PreRun:            commandUtil.NamespacedRoleAssertion(assertion.AssertExecutorRole),
+		Run: func(cmd *cobra.Command, args []string) {
+			source, destination, err := parseCopyLocations(args[0], args[1])
+			if err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+
+			var progress io.Writer = os.Stderr
+			if quiet {
+				progress = nil
+			}
+			if err = Copy(cmd, source, destination, podName, progress); err != nil {
+				log.Error(err)
+				os.Exit(1)
+			}
+		},
+	}
+
+	job.AddPodNameFlag(command, &podName)
+	command.Flags().BoolVarP(&quiet, "quiet", "q", false, "Do not print the progress of the copy.")
+
+	return command
+}
+
+// parseCopyLocation parses a path in a job, in the format of JOB_NAME:PATH, or a local path
+func parseCopyLocation(value string) copyLocation {
+	index := strings.Index(value, ":")
+	// a job name has no path separators, and a single letter before the colon is a windows drive
+	if index < 2 || strings.ContainsAny(value[:index], `/\`) {
+		return copyLocation{Path: value}
+	}
+	return copyLocation{Job: value[:index], Path: value[index+1:]}
+}
+
+func parseCopyLocations(sourceValue, destinationValue string) (copyLocation, copyLocation, error) {
+	source := parseCopyLocation(sourceValue)
+	destination := parseCopyLocation(destinationValue)
+	if source.isRemote() == destination.isRemote() {
+		return source, destination, fmt.Errorf("either the source or the destination must be a path in a job, in the format of JOB_NAME:PATH")
+	}
+	for _, location := range []copyLocation{source, destination} {
+		if location.Path == "" {
+			return source, destination, fmt.Errorf("the path of %s must be set", location)
+		}
+	}
+	return source, destination, nil
+}
+
+// Copy copies files between the local machine and a pod of a running job, by streaming a tarball through the
+// tar command of the container. The progress is written to progress, unless it is nil.
+func Copy(cmd *cobra.Command, source, destination copyLocation, podName string, progress io.Writer) error {
+	remote := source
+	if destination.isRemote() {
+		remote = destination
+	}
+
+	kubeClient, err := client.GetClient()
+	if err != nil {
+		return err
+	}
+	pod, err := GetPodFromCmd(cmd, kubeClient, remote.Job, podName, DefaultExecTimeout)
+	if err != nil {
+		return err
+	}
+	isRunning, err := raUtil.PodRunning(pod)
+	if err != nil {
+		return err
+	} else if !isRunning {
+		return fmt.Errorf("Unable to copy files of a pod that is not running")
+	}
+
+	copyProgress := newCopyProgress(progress)
+	if source.isRemote() {
+		err = copyFromPod(pod, source.Path, destination.Path, copyProgress)
+	} else {
+		err = copyToPod(pod, source.Path, destination.Path, copyProgress)
+	}
+	if err != nil {
+		return err
+	}
+	copyProgress.finish(source, destination)
+	return nil
+}
+
+// copyFromPod copies a file or directory of the pod to a local path. When the local path is an existing directory,
+// the copy is placed in it.
+func copyFromPod(pod *v1.Pod, remotePath, localPath string, progress *copyProgress) error {
+	remotePath = path.Clean(remotePath)
+	if remotePath == "/" || remotePath == "." {
+		return fmt.Errorf("can not copy %s, copy a directory under it instead", remotePath)
+	}
+	if info, err := os.Stat(localPath); err == nil && info.IsDir() {
+		localPath = filepath.Join(localPath, path.Base(remotePath))
+	}
+
+	reader, writer := io.Pipe()
+	var stderr bytes.Buffer
+	execErrors := make(chan error, 1)
+	go func() {
+		command := []string{"tar", "cf", "-", "-C", path.Dir(remotePath), path.Base(remotePath)}
+		err := execWithStreams(pod, command, nil, writer, &stderr)
+		writer.CloseWithError(err)
+		execErrors <- err
+	}()
+
+	err := readTar(reader, localPath, progress)
+	if err == nil {
+		// read the end of the output, so the command can finish
+		_, err = io.Copy(ioutil.Discard, reader)
+	} else {
+		// stop the command, which is blocked on writing the rest of the tarball
+		reader.CloseWithError(err)
+	}
+	execErr := <-execErrors
+	if execErr != nil && (err == nil || err == execErr) {
+		return remoteCommandError(execErr, &stderr)
+	}
+	return err
+}
+
+// copyToPod copies a local file or directory to a path of the pod. When the path ends with /, the copy is placed in
+// it.
+func copyToPod(pod *v1.Pod, localPath, remotePath string, progress *copyProgress) error {
+	if _, err := os.Lstat(localPath); err != nil {
+		return err
+	}
+
+	name := path.Base(remotePath)
+	remoteDir := path.Dir(remotePath)
+	if strings.HasSuffix(remotePath, "/") {
+		name = filepath.Base(filepath.Clean(localPath))
+		remoteDir = path.Clean(remotePath)
+	}
+
+	reader, writer := io.Pipe()
+	writeErrors := make(chan error, 1)
+	go func() {
+		err := writeTar(writer, localPath, name, progress)
+		writer.CloseWithError(err)
+		writeErrors <- err
+	}()
+
+	var stderr bytes.Buffer
+	command := []string{"tar", "xf", "-", "-C", remoteDir}
+	err := execWithStreams(pod, command, reader, ioutil.Discard, &stderr)
+	// stop writing the tarball when the command has failed before reading all of it
+	reader.CloseWithError(io.ErrClosedPipe)
+	if writeErr := <-writeErrors; writeErr != nil && writeErr != io.ErrClosedPipe {
+		return writeErr
+	}
+	if err != nil {
+		return remoteCommandError(err, &stderr)
+	}
+	return nil
+}
+
+func remoteCommandError(err error, stderr *bytes.Buffer) error {
+	if message := strings.TrimSpace(stderr.String()); message != "" {
+		return fmt.Errorf("%v: %s", err, message)
+	}
+	return err
+}
+
+// execWithStreams runs a command in the first container of the pod, without a tty
+func execWithStreams(pod *v1.Pod, command []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	kubeConfigFlags := genericclioptions.NewConfigFlags(true).WithDeprecatedPasswordFlag()
+	restConfig, err := cmdutil.NewMatchVersionFlags(kubeConfigFlags).ToRESTConfig()
+	if err != nil {
+		return err
+	}
+	restClient, err := rest.RESTClientFor(restConfig)
+	if err != nil {
+		return err
+	}
+
+	req := restClient.Post().
+		Resource("pods").
+		Name(pod.Name).
+		Namespace(pod.Namespace).
+		SubResource("exec")
+	req.VersionedParams(&v1.PodExecOptions{
+		Container: pod.Spec.Containers[0].Name,
+		Command:   command,
+		Stdin:     stdin != nil,
+		Stdout:    stdout != nil,
+		Stderr:    stderr != nil,
+	}, scheme.ParameterCodec)
+
+	executor := &kubeExec.DefaultRemoteExecutor{}
+	return executor.Execute("POST", req.URL(), restConfig, stdin, stdout, stderr, false, nil)
+}
@@ -0,0 +1,163 @@
+package exec
+
+import (
+	"archive/tar"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/run-ai/runai-cli/pkg/ui"
+	log "github.com/sirupsen/logrus"
+)
+
+const copyProgressInterval = 200 * time.Millisecond
+
+// copyProgress counts the files and bytes which have been copied, and prints them periodically
+type copyProgress struct {
+	out       io.Writer
+	files     int
+	bytes     int64
+	lastPrint time.Time
+}
+
+func newCopyProgress(out io.Writer) *copyProgress {
+	return &copyProgress{out: out}
+}
+
+// Write counts the bytes of the copied files
+func (progress *copyProgress) Write(p []byte) (int, error) {
+	progress.bytes += int64(len(p))
+	if progress.out != nil && time.Since(progress.lastPrint) >= copyProgressInterval {
+		fmt.Fprintf(progress.out, "\rCopied %d files, %s", progress.files, ui.ByteCountIEC(progress.bytes))
+		progress.lastPrint = time.Now()
+	}
+	return len(p), nil
+}
+
+func (progress *copyProgress) finish(source, destination copyLocation) {
+	if progress.out == nil {
+		return
+	}
+	if !progress.lastPrint.IsZero() {
+		fmt.Fprint(progress.out, "\r")
+	}
+	fmt.Fprintf(progress.out, "Copied %d files, %s from %s to %s\n", progress.files, ui.ByteCountIEC(progress.bytes), source, destination)
+}
+
+// writeTar writes a local file or directory to a tarball, renamed to name
+func writeTar(writer io.Writer, localPath, name string, progress *copyProgress) error {
+	tarWriter := tar.NewWriter(writer)
+	err := filepath.Walk(localPath, func(filePath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		relativePath, err := filepath.Rel(localPath, filePath)
+		if err != nil {
+			return err
+		}
+
+		link := ""
+		if info.Mode()&os.ModeSymlink != 0 {
+			if link, err = os.Readlink(filePath); err != nil {
+				return err
+			}
+		}
+		header, err := tar.FileInfoHeader(info, link)
+		if err != nil {
+			return err
+		}
+		header.Name = path.Join(name, filepath.ToSlash(relativePath))
+		if info.IsDir() {
+			header.Name += "/"
+		}
+		if err = tarWriter.WriteHeader(header); err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		file, err := os.Open(filePath)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		if _, err = io.CopyN(tarWriter, io.TeeReader(file, progress), info.Size()); err != nil {
+			return err
+		}
+		progress.files++
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return tarWriter.Close()
+}
+
+// readTar extracts a tarball of a single file or directory to a local path, which replaces the name of the file or
+// directory
+func readTar(reader io.Reader, localPath string, progress *copyProgress) error {
+	tarReader := tar.NewReader(reader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		targetPath, err := getExtractPath(localPath, header.Name)
+		if err != nil {
+			return err
+		}
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err = os.MkdirAll(targetPath, os.FileMode(header.Mode).Perm()|0700); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err = extractFile(tarReader, targetPath, header, progress); err != nil {
+				return err
+			}
+		default:
+			log.Warnf("Skipping %s, which is not a regular file or a directory", header.Name)
+		}
+	}
+}
+
+// getExtractPath returns the local path of a tarball entry. The first element of the entry is replaced by the local
+// path, and no entry is extracted outside of it.
+func getExtractPath(localPath, name string) (string, error) {
+	cleanName := strings.TrimPrefix(path.Clean("/"+name), "/")
+	if cleanName == "" {
+		return "", fmt.Errorf("invalid file name in the copied files: %s", name)
+	}
+	relativePath := ""
+	if index := strings.Index(cleanName, "/"); index != -1 {
+		relativePath = cleanName[index+1:]
+	}
+	return filepath.Join(localPath, filepath.FromSlash(relativePath)), nil
+}
+
+func extractFile(tarReader *tar.Reader, targetPath string, header *tar.Header, progress *copyProgress) error {
+	if err := os.MkdirAll(filepath.Dir(targetPath), 0755); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(targetPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, os.FileMode(header.Mode).Perm())
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if _, err = io.Copy(file, io.TeeReader(tarReader, progress)); err != nil {
+		return err
+	}
+	progress.files++
+	return nil
+}
@@ -0,0 +1,107 @@
+package exec
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseCopyLocations(t *testing.T) {
+	tests := []struct {
+		source      string
+		destination string
+		job         string
+		expectError bool
+	}{
+		{source: "train1:/workspace/checkpoints", destination: "./checkpoints", job: "train1"},
+		{source: "./config.yaml", destination: "train1:/etc/train/", job: "train1"},
+		{source: `C:\code\config.yaml`, destination: "train1:/etc/train/", job: "train1"},
+		{source: "./a:b", destination: "train1:/tmp", job: "train1"},
+		{source: "./config.yaml", destination: "./copy.yaml", expectError: true},
+		{source: "train1:/a", destination: "train2:/b", expectError: true},
+		{source: "train1:", destination: "./b", expectError: true},
+	}
+
+	for _, test := range tests {
+		source, destination, err := parseCopyLocations(test.source, test.destination)
+		if test.expectError {
+			if err == nil {
+				t.Errorf("expected an error for %s %s", test.source, test.destination)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("unexpected error for %s %s: %v", test.source, test.destination, err)
+			continue
+		}
+		if job := source.Job + destination.Job; job != test.job {
+			t.Errorf("expected the job of %s %s to be %s, got %s", test.source, test.destination, test.job, job)
+		}
+	}
+}
+
+func TestCopyTar(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	source := filepath.Join(dir, "checkpoints")
+	files := map[string]string{"epoch-1.pt": "1", "logs/train.log": "loss 0.1"}
+	for name, content := range files {
+		filePath := filepath.Join(source, filepath.FromSlash(name))
+		if err = os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err = ioutil.WriteFile(filePath, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var tarball bytes.Buffer
+	if err = writeTar(&tarball, source, "checkpoints", newCopyProgress(nil)); err != nil {
+		t.Fatal(err)
+	}
+
+	// the copied directory is renamed to the destination
+	destination := filepath.Join(dir, "copy")
+	progress := newCopyProgress(nil)
+	if err = readTar(&tarball, destination, progress); err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range files {
+		copied, err := ioutil.ReadFile(filepath.Join(destination, filepath.FromSlash(name)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(copied) != content {
+			t.Errorf("expected the content of %s to be %s, got %s", name, content, copied)
+		}
+	}
+	if progress.files != 2 || progress.bytes != 9 {
+		t.Errorf("expected 2 files of 9 bytes to be copied, got %d files of %d bytes", progress.files, progress.bytes)
+	}
+}
+
+func TestGetExtractPath(t *testing.T) {
+	tests := map[string]string{
+		"checkpoints":                  "/tmp/copy",
+		"checkpoints/":                 "/tmp/copy",
+		"checkpoints/logs/train.log":   "/tmp/copy/logs/train.log",
+		"checkpoints/../../etc/passwd": "/tmp/copy/passwd",
+		"/checkpoints/epoch-1.pt":      "/tmp/copy/epoch-1.pt",
+	}
+
+	for name, expected := range tests {
+		extractPath, err := getExtractPath("/tmp/copy", name)
+		if err != nil {
+			t.Errorf("unexpected error for %s: %v", name, err)
+		}
+		if extractPath != filepath.FromSlash(expected) {
+			t.Errorf("expected %s to be extracted to %s, got %s", name, expected, extractPath)
+		}
+	}
+}
@@ -0,0 +1,14 @@
+package flags
+
+import (
+	"fmt"
+
+	"github.com/run-ai/runai-cli/pkg/ui"
+	flag "github.com/spf13/pflag"
+)
+
+// AddOutputFlags adds the --output and --no-headers flags of list-like commands
+func AddOutputFlags(f *flag.FlagSet, opt *ui.OutputOpt) {
+	f.StringVarP(&opt.Format, "output", "o", "", fmt.Sprintf("Output format. One of: json|yaml|wide|name|%sTEMPLATE|%sHEADER:FIELD_PATH,...", ui.JsonPathOutputPrefix, ui.CustomColumnsOutputPrefix))
+	f.BoolVar(&opt.NoHeaders, "no-headers", false, "Don't print headers in the table, wide and custom-columns outputs.")
+}
@@ -0,0 +1,170 @@
+package idle
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"text/tabwriter"
+	"time"
+
+	"github.com/run-ai/runai-cli/cmd/completion"
+	"github.com/run-ai/runai-cli/cmd/flags"
+	suspendJob "github.com/run-ai/runai-cli/cmd/job/suspend"
+	"github.com/run-ai/runai-cli/cmd/trainer"
+	"github.com/run-ai/runai-cli/pkg/authentication/assertion"
+	"github.com/run-ai/runai-cli/pkg/client"
+	"github.com/run-ai/runai-cli/pkg/jobs"
+	prom "github.com/run-ai/runai-cli/pkg/prometheus"
+	"github.com/run-ai/runai-cli/pkg/types"
+	"github.com/run-ai/runai-cli/pkg/ui"
+	"github.com/run-ai/runai-cli/pkg/util"
+	commandUtil "github.com/run-ai/runai-cli/pkg/util/command"
+	"github.com/spf13/cobra"
+	v1 "k8s.io/api/core/v1"
+)
+
+const idleExamples = `
+# Display the interactive jobs of the default project whose gpus have been idle for at least an hour
+runai idle
+
+# Display the idle interactive jobs of all the projects, and suspend them
+runai idle -A --idle-for 2h --suspend
+`
+
+var idleFormatters = map[string]ui.FormatFunction{
+	"duration": func(value, model interface{}) (string, error) {
+		duration, ok := value.(time.Duration)
+		if !ok {
+			return "", fmt.Errorf("[DURATION Format]:: expecting time.Duration, got: %s", reflect.ValueOf(value).Type().Name())
+		}
+		return util.ShortHumanDuration(duration), nil
+	},
+	"lastactive": func(value, model interface{}) (string, error) {
+		lastActive, ok := value.(time.Time)
+		if !ok {
+			return "", fmt.Errorf("[LASTACTIVE Format]:: expecting time.Time, got: %s", reflect.ValueOf(value).Type().Name())
+		}
+		return fmt.Sprintf("%s ago", util.ShortHumanDuration(time.Since(lastActive))), nil
+	},
+}
+
+func NewIdleCommand() *cobra.Command {
+	var allNamespaces bool
+	var idleFor time.Duration
+	var suspend bool
+	var output ui.OutputOpt
+	var command = &cobra.Command{
+		Use:   "idle",
+		Short: "Display the interactive jobs whose gpus are idle.",
+		Long: `Display the running interactive jobs whose allocated gpus have been utilized below 1% for at least the --idle-for
+duration. The gpu utilization history of each job is joined with its running pods, so the gpus are idle at most since
+the pods started, or since the first sample of the history. With --suspend, the idle jobs are suspended to release their gpus.`,
+		Example:           idleExamples,
+		ValidArgsFunction: completion.NoArgs,
+		PreRun: func(cmd *cobra.Command, args []string) {
+			// suspending the jobs requires the same role as 'runai suspend'
+			if suspend {
+				commandUtil.NamespacedRoleAssertion(assertion.AssertExecutorRole)(cmd, args)
+			} else {
+				commandUtil.RoleAssertion(assertion.AssertViewerRole)(cmd, args)
+			}
+		},
+		Run: commandUtil.WrapRunCommand(func(cmd *cobra.Command, args []string) error {
+			if err := output.Validate(); err != nil {
+				return err
+			}
+			if idleFor <= 0 {
+				return fmt.Errorf("--idle-for must be a positive duration")
+			}
+
+			kubeClient, err := client.GetClient()
+			if err != nil {
+				return err
+			}
+
+			namespaceInfo, err := flags.GetNamespaceToUseFromProjectFlagIncludingAll(cmd, kubeClient, allNamespaces)
+			if err != nil {
+				return err
+			}
+
+			promClient, err := prom.BuildMetricsClient(kubeClient)
+			if err != nil {
+				return fmt.Errorf("error while creating prometheus client: %v", err)
+			}
+			if promClient == nil {
+				return fmt.Errorf("prometheus was not found in the cluster, detecting idle jobs requires prometheus")
+			}
+
+			interactiveJobs, err := trainer.GetJobs(kubeClient, namespaceInfo, trainer.JobListOptions{JobType: trainer.RunaiInteractiveType}, []v1.PodPhase{v1.PodRunning})
+			if err != nil {
+				return err
+			}
+			interactiveJobs = trainer.MakeTrainingJobOrderdByProject(trainer.MakeTrainingJobOrderdByName(interactiveJobs))
+
+			idleJobs, err := jobs.GetIdleJobs(promClient, interactiveJobs, idleFor, time.Now())
+			if err != nil {
+				return err
+			}
+			if err = printIdleJobs(os.Stdout, idleJobs, output); err != nil {
+				return err
+			}
+
+			if !suspend || len(idleJobs) == 0 {
+				return nil
+			}
+			return suspendIdleJobs(kubeClient, idleJobs)
+		}),
+	}
+
+	command.Flags().BoolVarP(&allNamespaces, "all-projects", "A", false, "show all projects.")
+	command.Flags().DurationVar(&idleFor, "idle-for", time.Hour, "display the jobs whose gpus have been idle for at least the duration, e.g. 2h.")
+	command.Flags().BoolVar(&suspend, "suspend", false, "suspend the idle jobs.")
+	flags.AddOutputFlags(command.Flags(), &output)
+
+	return command
+}
+
+func printIdleJobs(out io.Writer, idleJobs []types.IdleJobView, output ui.OutputOpt) error {
+	if !output.IsTable() {
+		return ui.PrintList(out, idleJobs, ui.ListOpt{Kind: "job", NamePath: "{.name}"}, output)
+	}
+	if len(idleJobs) == 0 {
+		fmt.Fprintln(out, "No idle jobs found.")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	err := ui.CreateTable(types.IdleJobView{}, ui.TableOpt{
+		Formatts:  idleFormatters,
+		NoHeaders: output.NoHeaders,
+	}).Render(w, idleJobs).Error()
+	if err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
+// suspendIdleJobs suspends the idle jobs, project by project
+func suspendIdleJobs(kubeClient *client.Client, idleJobs []types.IdleJobView) error {
+	projects, jobNamesByProject := groupJobNamesByProject(idleJobs)
+	for _, project := range projects {
+		if err := suspendJob.SuspendJobs(kubeClient, project, jobNamesByProject[project]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// groupJobNamesByProject returns the projects of the jobs in the order of the jobs, and the names of the jobs of each project
+func groupJobNamesByProject(idleJobs []types.IdleJobView) ([]string, map[string][]string) {
+	projects := []string{}
+	jobNamesByProject := map[string][]string{}
+	for _, job := range idleJobs {
+		if _, found := jobNamesByProject[job.Project]; !found {
+			projects = append(projects, job.Project)
+		}
+		jobNamesByProject[job.Project] = append(jobNamesByProject[job.Project], job.Name)
+	}
+	return projects, jobNamesByProject
+}
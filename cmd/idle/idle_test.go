@@ -0,0 +1,52 @@
+package idle
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/run-ai/runai-cli/pkg/types"
+	"github.com/run-ai/runai-cli/pkg/ui"
+)
+
+func TestGroupJobNamesByProject(t *testing.T) {
+	idleJobs := []types.IdleJobView{
+		{Name: "jupyter1", Project: "team-b"},
+		{Name: "jupyter2", Project: "team-a"},
+		{Name: "jupyter3", Project: "team-b"},
+	}
+
+	projects, jobNamesByProject := groupJobNamesByProject(idleJobs)
+	if !reflect.DeepEqual(projects, []string{"team-b", "team-a"}) {
+		t.Errorf("expected the projects in the order of the jobs, got %v", projects)
+	}
+	expected := map[string][]string{"team-a": {"jupyter2"}, "team-b": {"jupyter1", "jupyter3"}}
+	if !reflect.DeepEqual(jobNamesByProject, expected) {
+		t.Errorf("expected %v, got %v", expected, jobNamesByProject)
+	}
+}
+
+func TestPrintIdleJobs(t *testing.T) {
+	lastActive := time.Now().Add(-3 * time.Hour)
+	idleJobs := []types.IdleJobView{
+		{Name: "jupyter1", Project: "team-a", User: "alice", Type: "Interactive", Node: "node1", AllocatedGPUs: 1, IdleFor: 3 * time.Hour, LastActive: &lastActive},
+		{Name: "jupyter2", Project: "team-a", User: "bob", Type: "Interactive", Node: "node2", AllocatedGPUs: 0.5, IdleFor: 45 * time.Minute},
+	}
+
+	var out bytes.Buffer
+	if err := printIdleJobs(&out, idleJobs, ui.OutputOpt{NoHeaders: true}); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected a line of each job, got:\n%s", out.String())
+	}
+	if fields := strings.Fields(lines[0]); fields[len(fields)-3] != "3h" || fields[len(fields)-1] != "ago" {
+		t.Errorf("expected the idle time and the last activity of the job, got %s", lines[0])
+	}
+	if fields := strings.Fields(lines[1]); fields[len(fields)-2] != "45m" || fields[len(fields)-1] != "-" {
+		t.Errorf("expected no last activity of the job, got %s", lines[1])
+	}
+}
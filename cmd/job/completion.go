@@ -13,6 +13,7 @@ import (
 
 const CompletionJobsFileSuffix = "jobs"
 const CompletionPodsFileSuffix = "pods_"
+const CompletionContainersFileSuffix = "containers_"
 
 //
 //   generate job names for commands which require job name as parameter
@@ -88,6 +89,43 @@ func GenPodNames(cmd *cobra.Command, args []string, _ string) ([]string, cobra.S
 	return result, cobra.ShellCompDirectiveNoFileComp
 }
 
+//
+//   generate completion list of the container names of the pods of a given job, including the init containers.
+//   Assumption: in all the commands that has --container parameter, the first argument is the job name
+//
+func GenContainerNames(cmd *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+
+	if len(args) == 0 {
+		return nil, cobra.ShellCompDirectiveError
+	}
+
+	cachePath := CompletionContainersFileSuffix + args[0]
+	result := completion.ReadFromCache(cachePath)
+	if result != nil {
+		return result, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	jobInfo, _, err := PrepareJobInfo(cmd, args[0])
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+
+	result = []string{}
+	found := map[string]bool{}
+	for _, curPod := range jobInfo.AllPods() {
+		for _, container := range append(curPod.Spec.InitContainers, curPod.Spec.Containers...) {
+			if !found[container.Name] {
+				found[container.Name] = true
+				result = append(result, container.Name)
+			}
+		}
+	}
+
+	completion.WriteToCache(cachePath, result)
+
+	return result, cobra.ShellCompDirectiveNoFileComp
+}
+
 //
 //   add pod flag to the command, and register compleiton function for it
 //
@@ -106,20 +144,30 @@ func AddSubmitFlagsCompletion(command *cobra.Command) {
 	completion.AddFlagDescrpition(command, "cpu", "Specify number of CPU units to allocate (e.g. 0.5, 1)")
 	completion.AddFlagDescrpition(command, "cpu-limit", "Specify CPU limit for the job (e.g. 0.5, 1)")
 	completion.AddFlagDescrpition(command, "create-home-dir", "Specify a temporary home directory to be created")
+	completion.AddFlagDescrpition(command, "env", "Specify an environment variable set from a key of a secret, formatted as 'SECRET:KEY=VAR'")
+	completion.AddFlagDescrpition(command, "env-from-secret", "Specify a secret to set environment variables from")
 	completion.AddFlagDescrpition(command, "environment", "Specify values for environment variable, formatted as 'variable=value'")
 	completion.AddFlagDescrpition(command, "git-sync", "Specify sync string var1=value1;var2=value2;...")
+	completion.AddFlagDescrpition(command, "git-sync-secret", "Specify an existing secret holding the git sync credentials")
 	completion.AddFlagDescrpition(command, "gpu", "Specify GPU units to allocate (e.g. 0.5, 1)")
 	completion.AddFlagDescrpition(command, "gpu-memory", "Specify GPU memory to allocate (e.g. 1G, 500M)")
 	completion.AddFlagDescrpition(command, "image", "Specify image to use when creating the job")
 	completion.AddFlagDescrpition(command, "job-name-prefix", "Specify prefix for the job name")
 	completion.AddFlagDescrpition(command, "memory", "Specify CPU memory to allocate (e.g. 1G, 20M)")
 	completion.AddFlagDescrpition(command, "memory-limit", "Specify memory limit (e.g. 1G, 20M)")
+	completion.AddFlagDescrpition(command, "mount-configmap", "Specify a config map to mount, formatted as 'NAME:/path'")
+	completion.AddFlagDescrpition(command, "mount-secret", "Specify a secret to mount, formatted as 'NAME:/path'")
 	completion.AddFlagDescrpition(command, "name", "Specify a name for the job")
+	completion.AddFlagDescrpition(command, "node-affinity", "Specify node label expressions the nodes must match (e.g. 'gpu-type in (a100,v100)')")
+	completion.AddFlagDescrpition(command, "node-selector", "Specify a node label the nodes must have, formatted as 'key=value'")
 	completion.AddFlagDescrpition(command, "node-type", "Specify node-type label for enforcing node type affinity")
 	completion.AddFlagDescrpition(command, "parallelism", "Specify number of pods to run in parallel at any given time")
 	completion.AddFlagDescrpition(command, "port", "Specify ports to expose from the job container")
+	completion.AddFlagDescrpition(command, "preferred-node-affinity", "Specify node label expressions to prefer, formatted as '[weight:]expressions'")
 	completion.AddFlagDescrpition(command, "processes", "Specify number of distributed training processes")
 	completion.AddFlagDescrpition(command, "pvc", "Specify mount parameters of a persistent volume")
+	completion.AddFlagDescrpition(command, "sync-local", "Specify a local directory to upload into the working directory of the job")
+	completion.AddFlagDescrpition(command, "toleration", "Specify a node taint to tolerate, formatted as 'key[=value][:effect]'")
 	completion.AddFlagDescrpition(command, "ttl-after-finish", "Specify the auto-deletion duration (e.g. 2s, 5m, 3h)")
 	completion.AddFlagDescrpition(command, "volume", "Specify volumes to mount, formatted as '<host_path>:<container_path>:<access_mode>'")
 	completion.AddFlagDescrpition(command, "working-dir", "Specify the working directory of the container")
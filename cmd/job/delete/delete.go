@@ -24,7 +24,9 @@ import (
 	rsrch_cs "github.com/run-ai/researcher-service/server/pkg/runai/client"
 	"github.com/run-ai/runai-cli/cmd/flags"
 	"github.com/run-ai/runai-cli/cmd/job"
+	"github.com/run-ai/runai-cli/cmd/trainer"
 	"github.com/run-ai/runai-cli/cmd/util"
+	"github.com/run-ai/runai-cli/pkg/authentication"
 	"github.com/run-ai/runai-cli/pkg/authentication/assertion"
 	"github.com/run-ai/runai-cli/pkg/client"
 	"github.com/run-ai/runai-cli/pkg/rsrch_client"
@@ -33,20 +35,43 @@ import (
 	"github.com/spf13/cobra"
 )
 
+const deleteExamples = `
+# Delete job train1
+runai delete train1
+
+# Delete all the jobs submitted by a sweep
+runai delete -l runai/sweep=lr-sweep-20210101120000
+`
+
 // NewDeleteCommand
 func NewDeleteCommand() *cobra.Command {
 	var isAll bool
+	var mine bool
+	var selector string
 
 	var command = &cobra.Command{
 		Use:               "delete JOB_NAME",
+		Example:           deleteExamples,
 		Short:             "Delete a job and its associated pods.",
 		ValidArgsFunction: job.GenJobNames,
 		PreRun:            commandUtil.NamespacedRoleAssertion(assertion.AssertExecutorRole),
 		Run: func(cmd *cobra.Command, args []string) {
-			if !isAll && len(args) == 0 {
+			if !isAll && selector == "" && len(args) == 0 {
 				cmd.HelpFunc()(cmd, args)
 				os.Exit(1)
 			}
+			if mine && selector != "" {
+				fmt.Println("--mine can't be used together with --selector")
+				os.Exit(1)
+			}
+			if mine && !isAll {
+				fmt.Println("--mine can be used only with --all")
+				os.Exit(1)
+			}
+			if selector != "" && (isAll || len(args) > 0) {
+				fmt.Println("--selector can't be used together with --all or job names")
+				os.Exit(1)
+			}
 
 			kubeClient, err := client.GetClient()
 			if err != nil {
@@ -68,7 +93,32 @@ func NewDeleteCommand() *cobra.Command {
 			//
 			jobNamesToDelete := args
 
-			if isAll {
+			if isAll && mine {
+				currentUser, err := authentication.GetCurrentUser()
+				if err != nil {
+					fmt.Println(err)
+					os.Exit(1)
+				}
+				jobNamesToDelete, err = job.ListJobNames(kubeClient, namespaceInfo, trainer.JobListOptions{User: currentUser})
+				if err != nil {
+					log.Error(err)
+					os.Exit(1)
+				}
+				if len(jobNamesToDelete) == 0 {
+					fmt.Printf("No jobs of user %s in project %s.\n", currentUser, projectName)
+					return
+				}
+			} else if selector != "" {
+				jobNamesToDelete, err = job.ListJobNames(kubeClient, namespaceInfo, trainer.JobListOptions{LabelSelector: selector})
+				if err != nil {
+					log.Error(err)
+					os.Exit(1)
+				}
+				if len(jobNamesToDelete) == 0 {
+					fmt.Printf("No jobs match the selector %s in project %s.\n", selector, projectName)
+					return
+				}
+			} else if isAll {
 				jobNamesToDelete, err = job.ListJobNamesByNamespace(kubeClient, namespaceInfo)
 				if err != nil {
 					log.Error(err)
@@ -137,6 +187,8 @@ func NewDeleteCommand() *cobra.Command {
 	}
 
 	command.Flags().BoolVarP(&isAll, "all", "A", false, "Delete all jobs")
+	command.Flags().BoolVar(&mine, "mine", false, "With --all, delete only the jobs submitted by the current user")
+	command.Flags().StringVarP(&selector, "selector", "l", "", "Delete the jobs which match a label selector, e.g. runai/sweep=lr-sweep-20210101120000")
 
 	return command
 }
@@ -39,6 +39,7 @@ type eventAndName struct {
 type PrintArgs struct {
 	ShowEvents bool
 	Output     string
+	Explain    bool
 }
 
 func RunDescribeJobDEPRECATED(cmd *cobra.Command, printArgs PrintArgs, name string) {
@@ -80,6 +81,10 @@ func DescribeCommand() *cobra.Command {
 				os.Exit(1)
 			}
 			name := args[0]
+			if printArgs.Explain && printArgs.Output != "" && printArgs.Output != "wide" {
+				fmt.Printf("--explain can't be used with output format %s\n", printArgs.Output)
+				os.Exit(1)
+			}
 			job, clientSet, err := PrepareJobInfo(cmd, name)
 			if err != nil {
 				fmt.Println(err)
@@ -87,6 +92,14 @@ func DescribeCommand() *cobra.Command {
 			}
 
 			printTrainingJob(clientSet, job, printArgs)
+			if printArgs.Explain {
+				kubeClient, err := client.GetClient()
+				if err != nil {
+					fmt.Println(err)
+					os.Exit(1)
+				}
+				ExplainJob(os.Stdout, kubeClient, job)
+			}
 		},
 	}
 
@@ -95,6 +108,8 @@ func DescribeCommand() *cobra.Command {
 	command.Flags().StringVarP(&printArgs.Output, "output", "o", "", "Output format. One of: json|yaml|wide")
 	command.RegisterFlagCompletionFunc("output", completion.OutputFormatValues)
 
+	command.Flags().BoolVar(&printArgs.Explain, "explain", false, "Explain why the job is pending or failing, based on the events of the scheduler, the quota of the project, the free GPUs of the nodes and the states of the containers.")
+
 	command.Flags().MarkDeprecated("events", "default is true")
 	return command
 }
@@ -137,19 +152,24 @@ func printTrainingJob(client kubernetes.Interface, job trainer.TrainingJob, prin
 			fmt.Println(string(outBytes))
 		}
 	case "wide", "":
-		printSingleJobHelper(client, job, printArgs)
+		printSingleJobHelper(os.Stdout, client, job, printArgs)
 	default:
 		log.Fatalf("Unknown output format: %s", printArgs.Output)
 	}
 }
 
-func printSingleJobHelper(client kubernetes.Interface, job trainer.TrainingJob, printArgs PrintArgs) {
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+func printSingleJobHelper(out io.Writer, client kubernetes.Interface, job trainer.TrainingJob, printArgs PrintArgs) {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
 	printJobSummary(w, job)
 
 	// apply a dummy FgDefault format to align tabwriter with the rest of the columns
 	fmt.Fprintf(w, "Pods:\n")
-	fmt.Fprintf(w, "POD\tSTATUS\tTYPE\tAGE\tNODE\n")
+	mpiJob := isMPIJob(job)
+	if mpiJob {
+		fmt.Fprintf(w, "POD\tSTATUS\tTYPE\tROLE\tAGE\tNODE\tREADY\tRESTARTS\tLAST TERMINATION\tREQUESTS (CPU/MEM/GPU)\tLIMITS (CPU/MEM/GPU)\tGPU ALLOCATION\n")
+	} else {
+		fmt.Fprintf(w, "POD\tSTATUS\tTYPE\tAGE\tNODE\tREADY\tRESTARTS\tLAST TERMINATION\tREQUESTS (CPU/MEM/GPU)\tLIMITS (CPU/MEM/GPU)\tGPU ALLOCATION\n")
+	}
 	pods := job.AllPods()
 
 	for _, pod := range pods {
@@ -178,15 +198,30 @@ func printSingleJobHelper(client kubernetes.Interface, job trainer.TrainingJob,
 		}
 
 		podCreationTime = metav1.Now().Sub(pod.CreationTimestamp.Time)
-		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", pod.Name,
+		podType := strings.ToUpper(job.Trainer())
+		instance := getPodInstance(job, pod)
+		if mpiJob {
+			podType += "\t" + strings.ToUpper(instance.Role)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%d\t%s\t%s\t%s\t%s\n", pod.Name,
 			strings.ToUpper(podStatus),
-			strings.ToUpper(job.Trainer()),
+			podType,
 			util.ShortHumanDuration(podCreationTime),
-			hostIP)
+			hostIP,
+			instance.Ready,
+			instance.Restarts,
+			formatLastTermination(instance),
+			formatInstanceResources(instance.Requests),
+			formatInstanceResources(instance.Limits),
+			formatGPUAllocation(instance))
 	}
 
+	events, eventsErr := getResourcesEvents(client, job.Namespace(), job)
+	fmt.Fprintf(w, "\nTimeline:\n")
+	printTimelineEntries(w, getJobTimeline(job, events))
+
 	if printArgs.ShowEvents {
-		printEvents(client, w, job.Namespace(), job)
+		printEventsList(w, events, eventsErr)
 	}
 
 	_ = w.Flush()
@@ -217,9 +252,25 @@ func printJobSummary(w io.Writer, job trainer.TrainingJob) {
 
 }
 
+// PrintJobDescription prints the details of the job and its pods, as printed by runai describe job without the events
+func PrintJobDescription(out io.Writer, clientset kubernetes.Interface, job trainer.TrainingJob) {
+	printSingleJobHelper(out, clientset, job, PrintArgs{})
+}
+
+// PrintJobEvents prints the events of the job and its pods, as printed by runai describe job
+func PrintJobEvents(out io.Writer, clientset kubernetes.Interface, job trainer.TrainingJob) {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	printEvents(clientset, w, job.Namespace(), job)
+	_ = w.Flush()
+}
+
 func printEvents(clientset kubernetes.Interface, w io.Writer, namespace string, job trainer.TrainingJob) {
-	fmt.Fprintf(w, "\nEvents: \n")
 	eventsMap, err := getResourcesEvents(clientset, namespace, job)
+	printEventsList(w, eventsMap, err)
+}
+
+func printEventsList(w io.Writer, eventsMap []eventAndName, err error) {
+	fmt.Fprintf(w, "\nEvents: \n")
 	if err != nil {
 		fmt.Fprintf(w, "Get job events failed, due to: %v", err)
 		return
@@ -317,18 +368,9 @@ func getSortedEvents(items []v1.Event, resources []types.Resource, podGroupName
 func BuildJobInfo(job trainer.TrainingJob, clientset kubernetes.Interface) *types.JobInfo {
 	instances := []types.Instance{}
 	for _, pod := range job.AllPods() {
-		isChief := false
-		if pod.Name == job.ChiefPod().Name {
-			isChief = true
-		}
-
-		instances = append(instances, types.Instance{
-			Name:    pod.Name,
-			Status:  strings.ToUpper(string(pod.Status.Phase)),
-			Age:     util.ShortHumanDuration(job.Age()),
-			Node:    pod.Status.HostIP,
-			IsChief: isChief,
-		})
+		instance := getPodInstance(job, pod)
+		instance.Age = util.ShortHumanDuration(job.Age())
+		instances = append(instances, instance)
 	}
 
 	return &types.JobInfo{
@@ -0,0 +1,130 @@
+package job
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/run-ai/runai-cli/cmd/trainer"
+	"github.com/run-ai/runai-cli/cmd/util"
+	"github.com/run-ai/runai-cli/pkg/types"
+	v1 "k8s.io/api/core/v1"
+)
+
+const (
+	mpiRoleLabel = "mpi_role_type"
+	mpiLauncher  = "launcher"
+	mpiWorker    = "worker"
+)
+
+// isMPIJob returns whether the pods of the job are launchers and workers
+func isMPIJob(job trainer.TrainingJob) bool {
+	return job.WorkloadType() == string(types.MpiWorkloadType) || job.Trainer() == trainer.MPIJobType
+}
+
+// getPodInstance returns the details of a pod of the job, as printed by describe job
+func getPodInstance(job trainer.TrainingJob, pod v1.Pod) types.Instance {
+	instance := types.Instance{
+		Name:     pod.Name,
+		Status:   strings.ToUpper(string(pod.Status.Phase)),
+		Node:     pod.Status.HostIP,
+		IsChief:  job.ChiefPod() != nil && pod.Name == job.ChiefPod().Name,
+		Requests: getPodResources(pod, func(container v1.Container) v1.ResourceList { return container.Resources.Requests }),
+		Limits:   getPodResources(pod, func(container v1.Container) v1.ResourceList { return container.Resources.Limits }),
+	}
+	if isMPIJob(job) {
+		instance.Role = getMPIRole(pod)
+	}
+
+	ready := 0
+	var lastTermination *v1.ContainerStateTerminated
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Ready {
+			ready++
+		}
+		instance.Restarts += status.RestartCount
+		for _, terminated := range []*v1.ContainerStateTerminated{status.LastTerminationState.Terminated, status.State.Terminated} {
+			if terminated != nil && (lastTermination == nil || terminated.FinishedAt.After(lastTermination.FinishedAt.Time)) {
+				lastTermination = terminated
+			}
+		}
+	}
+	instance.Ready = fmt.Sprintf("%d/%d", ready, len(pod.Spec.Containers))
+	if lastTermination != nil {
+		instance.LastTerminationReason = lastTermination.Reason
+		if instance.LastTerminationReason == "" {
+			instance.LastTerminationReason = "Terminated"
+		}
+		exitCode := lastTermination.ExitCode
+		instance.LastTerminationExitCode = &exitCode
+	}
+
+	instance.GPUFraction = pod.Annotations[util.RunaiGPUFraction]
+	instance.GPUIndex = pod.Annotations[util.RunaiGPUIndex]
+	return instance
+}
+
+func getMPIRole(pod v1.Pod) string {
+	if role, found := pod.Labels[mpiRoleLabel]; found {
+		return role
+	}
+	if strings.Contains(pod.Name, "-"+mpiLauncher) {
+		return mpiLauncher
+	}
+	return mpiWorker
+}
+
+// getPodResources sums the resources of the containers of the pod
+func getPodResources(pod v1.Pod, getResources func(container v1.Container) v1.ResourceList) types.InstanceResources {
+	total := v1.ResourceList{}
+	for _, container := range pod.Spec.Containers {
+		for name, quantity := range getResources(container) {
+			sum := total[name]
+			sum.Add(quantity)
+			total[name] = sum
+		}
+	}
+
+	formatQuantity := func(name v1.ResourceName) string {
+		quantity, found := total[name]
+		if !found {
+			return ""
+		}
+		return quantity.String()
+	}
+	return types.InstanceResources{
+		CPU:    formatQuantity(v1.ResourceCPU),
+		Memory: formatQuantity(v1.ResourceMemory),
+		GPU:    formatQuantity(util.NVIDIAGPUResourceName),
+	}
+}
+
+// formatInstanceResources formats the resources as CPU/MEMORY/GPU, with - for resources which are not set
+func formatInstanceResources(resources types.InstanceResources) string {
+	values := []string{resources.CPU, resources.Memory, resources.GPU}
+	for i, value := range values {
+		if value == "" {
+			values[i] = "-"
+		}
+	}
+	return strings.Join(values, "/")
+}
+
+func formatLastTermination(instance types.Instance) string {
+	if instance.LastTerminationExitCode == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%s (%d)", instance.LastTerminationReason, *instance.LastTerminationExitCode)
+}
+
+// formatGPUAllocation formats the fraction and the index of the gpu which are allocated to the pod
+func formatGPUAllocation(instance types.Instance) string {
+	switch {
+	case instance.GPUFraction != "" && instance.GPUIndex != "":
+		return fmt.Sprintf("%s of gpu %s", instance.GPUFraction, instance.GPUIndex)
+	case instance.GPUFraction != "":
+		return instance.GPUFraction
+	case instance.GPUIndex != "":
+		return fmt.Sprintf("gpu %s", instance.GPUIndex)
+	}
+	return "-"
+}
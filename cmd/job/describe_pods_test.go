@@ -0,0 +1,90 @@
+package job
+
+import (
+	"testing"
+	"time"
+
+	"github.com/run-ai/runai-cli/cmd/trainer"
+	"github.com/run-ai/runai-cli/cmd/util"
+	"github.com/run-ai/runai-cli/pkg/types"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGetPodResources(t *testing.T) {
+	pod := v1.Pod{Spec: v1.PodSpec{Containers: []v1.Container{
+		{Resources: v1.ResourceRequirements{Requests: v1.ResourceList{
+			v1.ResourceCPU:             resource.MustParse("500m"),
+			v1.ResourceMemory:          resource.MustParse("1Gi"),
+			util.NVIDIAGPUResourceName: resource.MustParse("1"),
+		}}},
+		{Resources: v1.ResourceRequirements{Requests: v1.ResourceList{
+			v1.ResourceCPU: resource.MustParse("1"),
+		}}},
+	}}}
+
+	resources := getPodResources(pod, func(container v1.Container) v1.ResourceList { return container.Resources.Requests })
+	expected := types.InstanceResources{CPU: "1500m", Memory: "1Gi", GPU: "1"}
+	if resources != expected {
+		t.Errorf("expected %+v, got %+v", expected, resources)
+	}
+	if formatted := formatInstanceResources(types.InstanceResources{CPU: "2"}); formatted != "2/-/-" {
+		t.Errorf("expected the missing resources to be formatted as -, got %s", formatted)
+	}
+}
+
+func TestGetPodInstanceTerminations(t *testing.T) {
+	finished := time.Date(2021, 3, 1, 10, 0, 0, 0, time.UTC)
+	pod := v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "train1-0", Annotations: map[string]string{util.RunaiGPUFraction: "0.5", util.RunaiGPUIndex: "3"}},
+		Spec:       v1.PodSpec{Containers: []v1.Container{{Name: "train1"}, {Name: "sidecar"}}},
+		Status: v1.PodStatus{
+			ContainerStatuses: []v1.ContainerStatus{
+				{
+					Name:                 "train1",
+					Ready:                true,
+					RestartCount:         2,
+					LastTerminationState: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{Reason: "OOMKilled", ExitCode: 137, FinishedAt: metav1.NewTime(finished)}},
+				},
+				{
+					Name:                 "sidecar",
+					RestartCount:         1,
+					LastTerminationState: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{Reason: "Error", ExitCode: 1, FinishedAt: metav1.NewTime(finished.Add(-time.Hour))}},
+				},
+			},
+		},
+	}
+
+	instance := getPodInstance(&trainer.RunaiWorkload{}, pod)
+	if instance.Ready != "1/2" || instance.Restarts != 3 {
+		t.Errorf("expected 1/2 ready containers and 3 restarts, got %s and %d", instance.Ready, instance.Restarts)
+	}
+	if formatted := formatLastTermination(instance); formatted != "OOMKilled (137)" {
+		t.Errorf("expected the latest termination, got %s", formatted)
+	}
+	if formatted := formatGPUAllocation(instance); formatted != "0.5 of gpu 3" {
+		t.Errorf("expected the gpu fraction and index, got %s", formatted)
+	}
+	if instance.Role != "" {
+		t.Errorf("expected no role for a job which is not distributed, got %s", instance.Role)
+	}
+}
+
+func TestGetMPIRole(t *testing.T) {
+	tests := []struct {
+		pod      v1.Pod
+		expected string
+	}{
+		{pod: v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "mpi1-launcher-x7k2p", Labels: map[string]string{mpiRoleLabel: mpiLauncher}}}, expected: mpiLauncher},
+		{pod: v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "mpi1-worker-0", Labels: map[string]string{mpiRoleLabel: mpiWorker}}}, expected: mpiWorker},
+		{pod: v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "mpi1-launcher-x7k2p"}}, expected: mpiLauncher},
+		{pod: v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "mpi1-worker-1"}}, expected: mpiWorker},
+	}
+
+	for _, test := range tests {
+		if role := getMPIRole(test.pod); role != test.expected {
+			t.Errorf("expected pod %s to be a %s, got %s", test.pod.Name, test.expected, role)
+		}
+	}
+}
@@ -0,0 +1,230 @@
+package job
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strings"
+
+	"github.com/run-ai/runai-cli/cmd/constants"
+	"github.com/run-ai/runai-cli/cmd/project"
+	"github.com/run-ai/runai-cli/cmd/trainer"
+	"github.com/run-ai/runai-cli/cmd/util"
+	"github.com/run-ai/runai-cli/pkg/client"
+	"github.com/run-ai/runai-cli/pkg/helpers"
+	"github.com/run-ai/runai-cli/pkg/nodes"
+	"github.com/run-ai/runai-cli/pkg/ui"
+	log "github.com/sirupsen/logrus"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+var (
+	imagePullFailureReasons = []string{"ErrImagePull", "ImagePullBackOff", "InvalidImageName", "ErrImageNeverPull"}
+	containerFailureReasons = []string{"CrashLoopBackOff", "CreateContainerConfigError", "CreateContainerError", "RunContainerError"}
+)
+
+// jobExplanationInfo is the state of the job, its project and the cluster, which explains why the job is pending
+type jobExplanationInfo struct {
+	jobName string
+	pods    []v1.Pod
+	pending bool
+	// podGroupEvents are the events of the pod group of the job, which are created by the runai scheduler
+	podGroupEvents []v1.Event
+	gpusPerPod     float64
+	// pendingGPUs are the GPUs which the job requests and are not allocated yet
+	pendingGPUs float64
+
+	project      string
+	quotaKnown   bool
+	deservedGPUs float64
+	// projectAllocatedGPUs are the GPUs which are allocated to all the jobs of the project
+	projectAllocatedGPUs float64
+
+	nodes []nodes.NodeInfo
+}
+
+// getJobExplanationInfo collects the state which explains the job. Information which can't be collected, e.g. the
+// quota of the project when the user can't list the projects, is not used in the explanation.
+func getJobExplanationInfo(kubeClient *client.Client, job trainer.TrainingJob) jobExplanationInfo {
+	info := jobExplanationInfo{
+		jobName:     job.Name(),
+		pods:        job.AllPods(),
+		pending:     strings.EqualFold(GetJobRealStatus(job), constants.Status.Pending) || job.PendingPods() > 0,
+		gpusPerPod:  job.RequestedGPU(),
+		pendingGPUs: math.Max(job.CurrentRequestedGPUs()-job.CurrentAllocatedGPUs(), 0),
+		project:     job.Project(),
+	}
+
+	events, err := getResourcesEvents(kubeClient.GetClientset(), job.Namespace(), job)
+	if err != nil {
+		log.Debugf("Failed to get the events of job %s: %v", job.Name(), err)
+	}
+	for _, event := range events {
+		if event.name == job.GetPodGroupName() {
+			info.podGroupEvents = append(info.podGroupEvents, event.event)
+		}
+	}
+
+	if !info.pending {
+		return info
+	}
+
+	projects, err := project.PrepareListOfProjects(kubeClient.GetRestConfig())
+	if err != nil {
+		log.Debugf("Failed to get the quota of project %s: %v", info.project, err)
+	} else if jobProject, found := projects[info.project]; found {
+		info.quotaKnown = true
+		info.deservedGPUs = float64(jobProject.DeservedGpus)
+	}
+
+	nodeInfos, _, err := nodes.GetAllNodeInfos(kubeClient, false)
+	if err != nil {
+		log.Debugf("Failed to get the nodes: %v", err)
+	}
+	info.nodes = nodeInfos
+	for _, nodeInfo := range nodeInfos {
+		for _, pod := range nodeInfo.Pods {
+			if pod.Namespace == job.Namespace() && pod.Status.Phase == v1.PodRunning {
+				info.projectAllocatedGPUs += util.GpuInActivePod(pod)
+			}
+		}
+	}
+	return info
+}
+
+// explainJob returns human readable reasons for the job to be pending or to fail
+func explainJob(info jobExplanationInfo) []string {
+	reasons := getContainerFailures(info.pods)
+	if !info.pending {
+		return reasons
+	}
+
+	if reason := getSchedulerReason(info.podGroupEvents); reason != "" {
+		reasons = append(reasons, reason)
+	}
+	reasons = append(reasons, getUnschedulablePods(info.pods)...)
+	if reason := getQuotaReason(info); reason != "" {
+		reasons = append(reasons, reason)
+	}
+	if reason := getNodesReason(info); reason != "" {
+		reasons = append(reasons, reason)
+	}
+	return reasons
+}
+
+// getContainerFailures returns the containers which can't pull their images or keep failing
+func getContainerFailures(pods []v1.Pod) []string {
+	reasons := []string{}
+	for _, pod := range pods {
+		statuses := append(append([]v1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+		for _, status := range statuses {
+			waiting := status.State.Waiting
+			if waiting == nil {
+				continue
+			}
+			switch {
+			case ui.Contains(imagePullFailureReasons, waiting.Reason):
+				reasons = append(reasons, fmt.Sprintf("image pull failing for container %s of pod %s (%s): %s", status.Name, pod.Name, waiting.Reason, waiting.Message))
+			case ui.Contains(containerFailureReasons, waiting.Reason):
+				reasons = append(reasons, fmt.Sprintf("container %s of pod %s is failing (%s): %s", status.Name, pod.Name, waiting.Reason, waiting.Message))
+			}
+		}
+	}
+	return reasons
+}
+
+// getSchedulerReason returns the latest warning of the scheduler about the pod group of the job
+func getSchedulerReason(podGroupEvents []v1.Event) string {
+	for i := len(podGroupEvents) - 1; i >= 0; i-- {
+		event := podGroupEvents[i]
+		if event.Type == v1.EventTypeWarning {
+			return fmt.Sprintf("the scheduler reports: %s", event.Message)
+		}
+	}
+	return ""
+}
+
+// getUnschedulablePods returns the pods which the scheduler has failed to schedule, with the reasons
+func getUnschedulablePods(pods []v1.Pod) []string {
+	reasons := []string{}
+	for _, pod := range pods {
+		for _, condition := range pod.Status.Conditions {
+			if condition.Type == v1.PodScheduled && condition.Status == v1.ConditionFalse && condition.Reason == v1.PodReasonUnschedulable {
+				reasons = append(reasons, fmt.Sprintf("pod %s is unschedulable: %s", pod.Name, condition.Message))
+			}
+		}
+	}
+	return reasons
+}
+
+// getQuotaReason returns whether the job requests more GPUs than the quota of its project allows
+func getQuotaReason(info jobExplanationInfo) string {
+	if !info.quotaKnown || info.pendingGPUs == 0 {
+		return ""
+	}
+	overQuota := info.projectAllocatedGPUs + info.pendingGPUs - info.deservedGPUs
+	if overQuota <= 0 {
+		return ""
+	}
+	return fmt.Sprintf("over quota by %v GPUs: project %s deserves %v GPUs, %v GPUs are allocated to its jobs and the job requests %v more",
+		overQuota, info.project, info.deservedGPUs, info.projectAllocatedGPUs, info.pendingGPUs)
+}
+
+// getNodesReason returns whether there is no node with enough free GPUs to run a pod of the job. Only whole GPUs
+// are checked, as fractions of GPUs may be allocated on GPUs which are shared with other jobs.
+func getNodesReason(info jobExplanationInfo) string {
+	if info.gpusPerPod < 1 || len(info.nodes) == 0 || len(info.pods) == 0 {
+		return ""
+	}
+	nodeSelector := labels.SelectorFromSet(info.pods[0].Spec.NodeSelector)
+
+	gpuTypes := map[string]bool{}
+	maxFree, maxFreeNode := 0, ""
+	for _, nodeInfo := range info.nodes {
+		if !util.IsNodeReady(nodeInfo.Node) || !nodeSelector.Matches(labels.Set(nodeInfo.Node.Labels)) {
+			continue
+		}
+		resourcesStatus := nodeInfo.GetResourcesStatus()
+		gpus := (*helpers.NodeResourcesStatusConvertor)(&resourcesStatus).ToGpus()
+		if gpus == nil {
+			continue
+		}
+		if float64(gpus.Free) >= info.gpusPerPod {
+			return ""
+		}
+		gpuTypes[gpus.GpuType] = true
+		if maxFreeNode == "" || gpus.Free > maxFree {
+			maxFree, maxFreeNode = gpus.Free, nodeInfo.Node.Name
+		}
+	}
+
+	if maxFreeNode == "" {
+		return fmt.Sprintf("no ready node with GPUs matches the node selector of job %s", info.jobName)
+	}
+	ofType := ""
+	if len(gpuTypes) == 1 {
+		for gpuType := range gpuTypes {
+			if gpuType != "" {
+				ofType = " of type " + gpuType
+			}
+		}
+	}
+	return fmt.Sprintf("no node with %v free GPUs%s, at most %d GPUs are free on node %s", info.gpusPerPod, ofType, maxFree, maxFreeNode)
+}
+
+func printJobExplanation(w io.Writer, reasons []string) {
+	fmt.Fprintf(w, "\nExplanation:\n")
+	if len(reasons) == 0 {
+		fmt.Fprintln(w, "No reason for the job to be pending or failing was found")
+		return
+	}
+	for _, reason := range reasons {
+		fmt.Fprintf(w, "- %s\n", reason)
+	}
+}
+
+// ExplainJob prints why the job is pending, or why its containers are failing
+func ExplainJob(w io.Writer, kubeClient *client.Client, job trainer.TrainingJob) {
+	printJobExplanation(w, explainJob(getJobExplanationInfo(kubeClient, job)))
+}
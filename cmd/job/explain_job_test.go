@@ -0,0 +1,107 @@
+package job
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/run-ai/runai-cli/cmd/util"
+	"github.com/run-ai/runai-cli/pkg/nodes"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newExplainedNode(name, gpuType string, gpus int64, allocatedGPUs int64) nodes.NodeInfo {
+	node := v1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{"nvidia.com/gpu.product": gpuType}},
+		Status: v1.NodeStatus{
+			Capacity:    v1.ResourceList{util.NVIDIAGPUResourceName: *resource.NewQuantity(gpus, resource.DecimalSI)},
+			Allocatable: v1.ResourceList{util.NVIDIAGPUResourceName: *resource.NewQuantity(gpus, resource.DecimalSI)},
+			Conditions:  []v1.NodeCondition{{Type: v1.NodeReady, Status: v1.ConditionTrue}},
+		},
+	}
+	pod := v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name + "-pod", Namespace: "runai-team-b"},
+		Spec: v1.PodSpec{
+			NodeName: name,
+			Containers: []v1.Container{{Resources: v1.ResourceRequirements{
+				Limits: v1.ResourceList{util.NVIDIAGPUResourceName: *resource.NewQuantity(allocatedGPUs, resource.DecimalSI)},
+			}}},
+		},
+		Status: v1.PodStatus{Phase: v1.PodRunning},
+	}
+	return nodes.NodeInfo{Node: node, Pods: []v1.Pod{pod}}
+}
+
+func TestExplainPendingJob(t *testing.T) {
+	info := jobExplanationInfo{
+		jobName: "train1",
+		pods: []v1.Pod{{
+			ObjectMeta: metav1.ObjectMeta{Name: "train1-0"},
+			Status: v1.PodStatus{
+				Phase:      v1.PodPending,
+				Conditions: []v1.PodCondition{{Type: v1.PodScheduled, Status: v1.ConditionFalse, Reason: v1.PodReasonUnschedulable, Message: "0/2 nodes are available"}},
+			},
+		}},
+		pending: true,
+		podGroupEvents: []v1.Event{
+			{Type: v1.EventTypeWarning, Message: "Job is pending for an old reason"},
+			{Type: v1.EventTypeWarning, Message: "Not enough resources for job"},
+			{Type: v1.EventTypeNormal, Message: "Job is waiting in queue"},
+		},
+		gpusPerPod:           4,
+		pendingGPUs:          4,
+		project:              "team-a",
+		quotaKnown:           true,
+		deservedGPUs:         4,
+		projectAllocatedGPUs: 2,
+		nodes: []nodes.NodeInfo{
+			newExplainedNode("dgx-1", "A100", 8, 6),
+			newExplainedNode("dgx-2", "A100", 8, 7),
+		},
+	}
+
+	expected := []string{
+		"the scheduler reports: Not enough resources for job",
+		"pod train1-0 is unschedulable: 0/2 nodes are available",
+		"over quota by 2 GPUs: project team-a deserves 4 GPUs, 2 GPUs are allocated to its jobs and the job requests 4 more",
+		"no node with 4 free GPUs of type A100, at most 2 GPUs are free on node dgx-1",
+	}
+	if reasons := explainJob(info); !reflect.DeepEqual(reasons, expected) {
+		t.Errorf("expected %q, got %q", expected, reasons)
+	}
+
+	info.nodes = append(info.nodes, newExplainedNode("dgx-3", "A100", 8, 0))
+	info.deservedGPUs = 8
+	expected = expected[:2]
+	if reasons := explainJob(info); !reflect.DeepEqual(reasons, expected) {
+		t.Errorf("expected %q, got %q", expected, reasons)
+	}
+}
+
+func TestExplainFailingContainers(t *testing.T) {
+	info := jobExplanationInfo{
+		jobName: "train1",
+		pods: []v1.Pod{{
+			ObjectMeta: metav1.ObjectMeta{Name: "train1-0"},
+			Status: v1.PodStatus{
+				InitContainerStatuses: []v1.ContainerStatus{{Name: "git-sync", State: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{}}}},
+				ContainerStatuses: []v1.ContainerStatus{
+					{Name: "train1", State: v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: "ImagePullBackOff", Message: "Back-off pulling image \"pytorch:nope\""}}},
+					{Name: "sidecar", State: v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: "CrashLoopBackOff", Message: "back-off 5m0s restarting failed container"}}},
+				},
+			},
+		}},
+		// the quota and the nodes are ignored when the job is not pending
+		quotaKnown:  true,
+		pendingGPUs: 1,
+	}
+
+	expected := []string{
+		"image pull failing for container train1 of pod train1-0 (ImagePullBackOff): Back-off pulling image \"pytorch:nope\"",
+		"container sidecar of pod train1-0 is failing (CrashLoopBackOff): back-off 5m0s restarting failed container",
+	}
+	if reasons := explainJob(info); !reflect.DeepEqual(reasons, expected) {
+		t.Errorf("expected %q, got %q", expected, reasons)
+	}
+}
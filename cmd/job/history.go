@@ -0,0 +1,115 @@
+package job
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/run-ai/runai-cli/cmd/trainer"
+	"github.com/run-ai/runai-cli/pkg/authentication/assertion"
+	"github.com/run-ai/runai-cli/pkg/util"
+	commandUtil "github.com/run-ai/runai-cli/pkg/util/command"
+	"github.com/spf13/cobra"
+	v1 "k8s.io/api/core/v1"
+)
+
+const timelineTimeFormat = "2006-01-02 15:04:05"
+
+// timelineEventReasons are parts of the reasons of the events which are steps in the history of a job, e.g. the
+// preemption, the suspension and the resumption of the job. Other events are shown by describe job.
+var timelineEventReasons = []string{"preempt", "evict", "suspend", "resume", "unschedulable", "failedscheduling", "oomkill"}
+
+// timelineJob is a job which records the steps of its history
+type timelineJob interface {
+	Timeline() []trainer.TimelineEntry
+}
+
+func NewHistoryCommand() *cobra.Command {
+	var command = &cobra.Command{
+		Use:   "history JOB_NAME",
+		Short: "Display the history of a job.",
+		Long: `Display the history of a job as a chronological timeline: the creation and the scheduling of its pods, the starts,
+restarts and terminations of its containers, and its preemptions, suspensions and resumptions.`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: GenJobNames,
+		PreRun:            commandUtil.RoleAssertion(assertion.AssertViewerRole),
+		Run: func(cmd *cobra.Command, args []string) {
+			job, clientSet, err := PrepareJobInfo(cmd, args[0])
+			if err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+
+			events, err := getResourcesEvents(clientSet, job.Namespace(), job)
+			if err != nil {
+				fmt.Printf("Failed to get the events of job %s, due to: %v\n", job.Name(), err)
+			}
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			printTimelineEntries(w, getJobTimeline(job, events))
+			_ = w.Flush()
+		},
+	}
+
+	return command
+}
+
+// getJobTimeline merges the steps which are recorded in the job and its pods with the events of the job into a
+// chronological timeline
+func getJobTimeline(job trainer.TrainingJob, events []eventAndName) []trainer.TimelineEntry {
+	var entries []trainer.TimelineEntry
+	if jobWithTimeline, ok := job.(timelineJob); ok {
+		entries = jobWithTimeline.Timeline()
+	} else {
+		entries = trainer.GetPodsTimeline(job.AllPods())
+	}
+
+	for _, event := range events {
+		if !isTimelineEvent(event.event) {
+			continue
+		}
+		eventTime := event.event.FirstTimestamp.Time
+		if eventTime.IsZero() {
+			eventTime = event.event.CreationTimestamp.Time
+		}
+		message := fmt.Sprintf("[%s] %s", event.event.Reason, event.event.Message)
+		if event.event.Count > 1 {
+			message = fmt.Sprintf("%s (x%d)", message, event.event.Count)
+		}
+		entries = append(entries, trainer.TimelineEntry{
+			Time:    eventTime,
+			Source:  fmt.Sprintf("%s/%s", strings.ToLower(event.event.InvolvedObject.Kind), event.name),
+			Message: message,
+		})
+	}
+
+	trainer.SortTimeline(entries)
+	return entries
+}
+
+func isTimelineEvent(event v1.Event) bool {
+	reason := strings.ToLower(event.Reason)
+	for _, timelineReason := range timelineEventReasons {
+		if strings.Contains(reason, timelineReason) {
+			return true
+		}
+	}
+	return false
+}
+
+// printTimelineEntries prints the timeline with the duration between every step and the former one
+func printTimelineEntries(w io.Writer, entries []trainer.TimelineEntry) {
+	if len(entries) == 0 {
+		fmt.Fprintln(w, "No history for the job")
+		return
+	}
+	fmt.Fprintf(w, "TIME\tAFTER\tSOURCE\tEVENT\n")
+	for i, entry := range entries {
+		after := "-"
+		if i > 0 {
+			after = "+" + util.ShortHumanDuration(entry.Time.Sub(entries[i-1].Time))
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", entry.Time.Local().Format(timelineTimeFormat), after, entry.Source, entry.Message)
+	}
+}
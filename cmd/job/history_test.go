@@ -0,0 +1,73 @@
+package job
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/run-ai/runai-cli/cmd/trainer"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestIsTimelineEvent(t *testing.T) {
+	tests := []struct {
+		reason   string
+		expected bool
+	}{
+		{reason: "Preempted", expected: true},
+		{reason: "Evicted", expected: true},
+		{reason: "Suspended", expected: true},
+		{reason: "Resumed", expected: true},
+		{reason: "FailedScheduling", expected: true},
+		{reason: "Pulled", expected: false},
+		{reason: "Started", expected: false},
+	}
+
+	for _, test := range tests {
+		if isTimelineEvent(v1.Event{Reason: test.reason}) != test.expected {
+			t.Errorf("expected event %s to be in the timeline: %v", test.reason, test.expected)
+		}
+	}
+}
+
+func TestPrintTimelineEntries(t *testing.T) {
+	start := time.Date(2021, 3, 1, 10, 0, 0, 0, time.UTC)
+	entries := []trainer.TimelineEntry{
+		{Time: start, Source: "job/train1", Message: "Created"},
+		{Time: start.Add(90 * time.Second), Source: "podgroup/pg-train1", Message: "[Preempted] Job was preempted"},
+	}
+
+	var out bytes.Buffer
+	printTimelineEntries(&out, entries)
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected a header and 2 entries, got %q", out.String())
+	}
+	if !strings.Contains(lines[1], "\t-\tjob/train1\tCreated") {
+		t.Errorf("expected no duration before the first entry, got %q", lines[1])
+	}
+	if !strings.Contains(lines[2], "\t+1m\tpodgroup/pg-train1\t[Preempted] Job was preempted") {
+		t.Errorf("expected the duration since the former entry, got %q", lines[2])
+	}
+}
+
+func TestGetJobTimelineEventTime(t *testing.T) {
+	created := metav1.NewTime(time.Date(2021, 3, 1, 10, 0, 0, 0, time.UTC))
+	events := []eventAndName{{
+		name: "pg-train1",
+		event: v1.Event{
+			ObjectMeta:     metav1.ObjectMeta{CreationTimestamp: created},
+			InvolvedObject: v1.ObjectReference{Kind: "PodGroup"},
+			Reason:         "Suspended",
+			Message:        "Job was suspended",
+			Count:          2,
+		},
+	}}
+
+	entries := getJobTimeline(&trainer.RunaiWorkload{}, events)
+	if len(entries) != 1 || !entries[0].Time.Equal(created.Time) || entries[0].Message != "[Suspended] Job was suspended (x2)" {
+		t.Errorf("expected the suspension in the timeline, got %v", entries)
+	}
+}
@@ -0,0 +1,270 @@
+package job
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/run-ai/runai-cli/cmd/constants"
+	runaijobv1 "github.com/run-ai/runai-cli/cmd/mpi/api/runaijob/v1"
+	mpi "github.com/run-ai/runai-cli/cmd/mpi/api/v1alpha2"
+	"github.com/run-ai/runai-cli/cmd/mpi/client/clientset/versioned/scheme"
+	"github.com/run-ai/runai-cli/cmd/trainer"
+	"github.com/run-ai/runai-cli/pkg/client"
+	"github.com/run-ai/runai-cli/pkg/types"
+	log "github.com/sirupsen/logrus"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/tools/cache"
+)
+
+var (
+	runaiJobsResource = schema.GroupVersionResource{Group: "run.ai", Version: "v1", Resource: "runaijobs"}
+	mpiJobsResource   = schema.GroupVersionResource{Group: "kubeflow.org", Version: "v1alpha2", Resource: "mpijobs"}
+)
+
+// newChangeNotifier returns an event handler which notifies the changed channel on every add, update or delete.
+// The notification is not blocking, so bursts of events are coalesced into a single notification.
+func newChangeNotifier(changed chan<- struct{}) cache.ResourceEventHandler {
+	notify := func() {
+		select {
+		case changed <- struct{}{}:
+		default:
+		}
+	}
+	return cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(interface{}) { notify() },
+		UpdateFunc: func(interface{}, interface{}) { notify() },
+		DeleteFunc: func(interface{}) { notify() },
+	}
+}
+
+// jobPodLabels are the labels which the pods of a job carry its name in: the release of the jobs submitted by the cli,
+// the job name of the kubernetes job controller and the job name of the mpi operator
+var jobPodLabels = []string{"release", "job-name", "mpi_job_name"}
+
+// jobInformers are the informers of the objects which may make up jobs, and of their pods
+type jobInformers struct {
+	jobs         cache.SharedIndexInformer
+	statefulSets cache.SharedIndexInformer
+	deployments  cache.SharedIndexInformer
+	pods         []cache.SharedIndexInformer
+	// the informers of the custom jobs, only of the resources which the cluster serves
+	customJobs map[schema.GroupVersionResource]cache.SharedIndexInformer
+}
+
+// startJobInformers watches the objects which may make up jobs, and their pods, until stopCh is closed.
+// When name is empty all the jobs in the namespace, and all the pods of the runai scheduler, are watched.
+// Otherwise only the pods which carry the name of the job in one of their labels, or which are named as the job, are watched.
+func startJobInformers(kubeClient *client.Client, namespace, name string, handler cache.ResourceEventHandler, stopCh <-chan struct{}) *jobInformers {
+	byName := func(options *metav1.ListOptions) {
+		if name != "" {
+			options.FieldSelector = fmt.Sprintf("metadata.name=%s", name)
+		}
+	}
+
+	watch := func(informer cache.SharedIndexInformer) cache.SharedIndexInformer {
+		informer.AddEventHandler(handler)
+		return informer
+	}
+
+	ji := &jobInformers{customJobs: map[schema.GroupVersionResource]cache.SharedIndexInformer{}}
+	jobsFactory := informers.NewSharedInformerFactoryWithOptions(kubeClient.GetClientset(), 0, informers.WithNamespace(namespace), informers.WithTweakListOptions(byName))
+	ji.jobs = watch(jobsFactory.Batch().V1().Jobs().Informer())
+	ji.statefulSets = watch(jobsFactory.Apps().V1().StatefulSets().Informer())
+	ji.deployments = watch(jobsFactory.Apps().V1().Deployments().Informer())
+	jobsFactory.Start(stopCh)
+
+	for _, podsListOptions := range getJobPodsListOptions(name) {
+		podsFactory := informers.NewSharedInformerFactoryWithOptions(kubeClient.GetClientset(), 0, informers.WithNamespace(namespace), informers.WithTweakListOptions(podsListOptions))
+		ji.pods = append(ji.pods, watch(podsFactory.Core().V1().Pods().Informer()))
+		podsFactory.Start(stopCh)
+	}
+
+	customJobsFactory := dynamicinformer.NewFilteredDynamicSharedInformerFactory(kubeClient.GetDynamicClient(), 0, namespace, byName)
+	for _, resource := range []schema.GroupVersionResource{runaiJobsResource, mpiJobsResource} {
+		// an informer of a resource which the cluster does not serve would retry listing it forever
+		if !isResourceServed(kubeClient, resource) {
+			log.Debugf("The cluster does not serve %s, they are not watched", resource.Resource)
+			continue
+		}
+		ji.customJobs[resource] = watch(customJobsFactory.ForResource(resource).Informer())
+	}
+	customJobsFactory.Start(stopCh)
+	return ji
+}
+
+// synced returns the functions which report whether the informers have synced
+func (ji *jobInformers) synced() []cache.InformerSynced {
+	synced := []cache.InformerSynced{ji.jobs.HasSynced, ji.statefulSets.HasSynced, ji.deployments.HasSynced}
+	for _, informer := range ji.pods {
+		synced = append(synced, informer.HasSynced)
+	}
+	for _, informer := range ji.customJobs {
+		synced = append(synced, informer.HasSynced)
+	}
+	return synced
+}
+
+// getJob builds the job from the caches of the informers, looking for it in the same order as the trainers do. It
+// returns nil when the job is not in the caches.
+func (ji *jobInformers) getJob(namespace, name string) (trainer.TrainingJob, error) {
+	key := fmt.Sprintf("%s/%s", namespace, name)
+	pods := ji.getPods()
+
+	if informer, found := ji.customJobs[mpiJobsResource]; found {
+		if obj, exists, _ := informer.GetStore().GetByKey(key); exists {
+			var mpiJob mpi.MPIJob
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.(*unstructured.Unstructured).Object, &mpiJob); err != nil {
+				return nil, err
+			}
+			return trainer.NewMPITrainingJobOfPods(name, mpiJob, pods), nil
+		}
+	}
+
+	var podSpecJobs []*types.PodTemplateJob
+	if obj, exists, _ := ji.jobs.GetStore().GetByKey(key); exists {
+		podSpecJobs = append(podSpecJobs, types.PodTemplateJobFromJob(*obj.(*batchv1.Job)))
+	}
+	if obj, exists, _ := ji.statefulSets.GetStore().GetByKey(key); exists {
+		podSpecJobs = append(podSpecJobs, types.PodTemplateJobFromStatefulSet(*obj.(*appsv1.StatefulSet)))
+	}
+	if obj, exists, _ := ji.deployments.GetStore().GetByKey(key); exists {
+		podSpecJobs = append(podSpecJobs, types.PodTemplateJobFromDeployment(*obj.(*appsv1.Deployment)))
+	}
+	for _, pod := range pods {
+		if pod.Namespace == namespace && pod.Name == name && len(pod.OwnerReferences) == 0 {
+			podSpecJobs = append(podSpecJobs, types.PodTemplateJobFromPod(pod))
+		}
+	}
+	if informer, found := ji.customJobs[runaiJobsResource]; found {
+		if obj, exists, _ := informer.GetStore().GetByKey(key); exists {
+			var runaiJob runaijobv1.RunaiJob
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.(*unstructured.Unstructured).Object, &runaiJob); err != nil {
+				return nil, err
+			}
+			scheme.Scheme.Default(&runaiJob)
+			podSpecJobs = append(podSpecJobs, types.PodTemplateJobFromRunaiJob(runaiJob))
+		}
+	}
+
+	for _, podSpecJob := range podSpecJobs {
+		if job := trainer.NewRunaiTrainingJobOfPods(*podSpecJob, pods); job != nil {
+			return job, nil
+		}
+	}
+	return nil, nil
+}
+
+// getPods returns the pods in the caches of the pod informers, each once even when several informers watch it
+func (ji *jobInformers) getPods() []v1.Pod {
+	pods := []v1.Pod{}
+	seen := map[string]bool{}
+	for _, informer := range ji.pods {
+		for _, obj := range informer.GetStore().List() {
+			pod, ok := obj.(*v1.Pod)
+			if !ok || seen[string(pod.UID)] {
+				continue
+			}
+			seen[string(pod.UID)] = true
+			pods = append(pods, *pod)
+		}
+	}
+	return pods
+}
+
+// waitForJobInformersSync waits up to timeout for the informers to sync. The informers of objects which can't be
+// listed, e.g. for a lack of permissions, never sync, so afterwards the jobs are read from whatever was synced.
+func waitForJobInformersSync(synced []cache.InformerSynced, timeout time.Duration) {
+	timeoutCh := make(chan struct{})
+	timer := time.AfterFunc(timeout, func() { close(timeoutCh) })
+	defer timer.Stop()
+	if !cache.WaitForCacheSync(timeoutCh, synced...) {
+		log.Debugf("The job informers did not sync in %s", timeout)
+	}
+}
+
+// getJobPodsListOptions returns the list options of the pod informers of the job. A label selector can't match one
+// of several labels, so there is an informer for every label of jobPodLabels, and another for a pod without owner.
+func getJobPodsListOptions(name string) []func(*metav1.ListOptions) {
+	if name == "" {
+		return []func(*metav1.ListOptions){func(options *metav1.ListOptions) {
+			options.FieldSelector = fmt.Sprintf("spec.schedulerName=%s", constants.SchedulerName)
+		}}
+	}
+
+	podsListOptions := []func(*metav1.ListOptions){func(options *metav1.ListOptions) {
+		options.FieldSelector = fmt.Sprintf("metadata.name=%s", name)
+	}}
+	for _, label := range jobPodLabels {
+		labelSelector := fmt.Sprintf("%s=%s", label, name)
+		podsListOptions = append(podsListOptions, func(options *metav1.ListOptions) {
+			options.LabelSelector = labelSelector
+		})
+	}
+	return podsListOptions
+}
+
+// getJobKeyOfObject returns the namespace/name key of the job which an object of the job informers belongs to, or
+// false when the job can't be told from the object, e.g. for a pod of a replica set
+func getJobKeyOfObject(obj interface{}) (string, bool) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	object, err := meta.Accessor(obj)
+	if err != nil {
+		return "", false
+	}
+
+	name := object.GetName()
+	if pod, isPod := obj.(*v1.Pod); isPod {
+		name = getJobNameOfPod(pod)
+	} else if release := object.GetLabels()["release"]; release != "" && name == fmt.Sprintf("%s-%s", release, trainer.MpiTrainerType) {
+		// mpijobs which are named after their release are listed by the release name
+		name = release
+	}
+	if name == "" {
+		return "", false
+	}
+	return fmt.Sprintf("%s/%s", object.GetNamespace(), name), true
+}
+
+// getJobNameOfPod returns the name of the job of the pod, or an empty string when the pod belongs to a replica set,
+// as the job is the deployment of the replica set
+func getJobNameOfPod(pod *v1.Pod) string {
+	for _, label := range jobPodLabels {
+		if name := pod.Labels[label]; name != "" {
+			return name
+		}
+	}
+	controller := metav1.GetControllerOf(pod)
+	if controller == nil {
+		return pod.Name
+	}
+	if controller.Kind == "ReplicaSet" {
+		return ""
+	}
+	return controller.Name
+}
+
+// isResourceServed returns whether the cluster serves the resource, e.g. whether the crd of mpijobs is installed
+func isResourceServed(kubeClient *client.Client, resource schema.GroupVersionResource) bool {
+	resourcesList, err := kubeClient.GetClientset().Discovery().ServerResourcesForGroupVersion(resource.GroupVersion().String())
+	if err != nil {
+		log.Debugf("Failed to discover the resources of %s: %v", resource.GroupVersion(), err)
+		return false
+	}
+	for _, apiResource := range resourcesList.APIResources {
+		if apiResource.Name == resource.Resource {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,154 @@
+package job
+
+import (
+	"testing"
+
+	"github.com/run-ai/runai-cli/cmd/constants"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/cache"
+)
+
+func TestGetJobPodsListOptions(t *testing.T) {
+	var selectors []string
+	for _, podsListOptions := range getJobPodsListOptions("train1") {
+		options := metav1.ListOptions{}
+		podsListOptions(&options)
+		selectors = append(selectors, options.FieldSelector+options.LabelSelector)
+	}
+
+	expected := []string{"metadata.name=train1", "release=train1", "job-name=train1", "mpi_job_name=train1"}
+	if len(selectors) != len(expected) {
+		t.Fatalf("expected the selectors %v, got %v", expected, selectors)
+	}
+	for i := range expected {
+		if selectors[i] != expected[i] {
+			t.Errorf("expected the selectors %v, got %v", expected, selectors)
+		}
+	}
+}
+
+func TestGetJobPodsListOptionsOfAllJobs(t *testing.T) {
+	podsListOptions := getJobPodsListOptions("")
+	if len(podsListOptions) != 1 {
+		t.Fatalf("expected a single pods informer, got %d", len(podsListOptions))
+	}
+	options := metav1.ListOptions{}
+	podsListOptions[0](&options)
+	if options.FieldSelector != "spec.schedulerName=runai-scheduler" {
+		t.Errorf("expected the pods of the runai scheduler, got %s", options.FieldSelector)
+	}
+}
+
+func TestGetJobKeyOfObject(t *testing.T) {
+	controller := true
+	ownedPod := func(kind, name string) *v1.Pod {
+		return &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod1", Namespace: "runai-a", OwnerReferences: []metav1.OwnerReference{{Kind: kind, Name: name, Controller: &controller}}}}
+	}
+	mpiJob := &unstructured.Unstructured{}
+	mpiJob.SetName("mpi1-mpijob")
+	mpiJob.SetNamespace("runai-a")
+	mpiJob.SetLabels(map[string]string{"release": "mpi1"})
+
+	tests := []struct {
+		name   string
+		object interface{}
+		key    string
+		found  bool
+	}{
+		{name: "controller", object: &appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: "job1", Namespace: "runai-a"}}, key: "runai-a/job1", found: true},
+		{name: "mpijob named after its release", object: mpiJob, key: "runai-a/mpi1", found: true},
+		{name: "pod with a job label", object: &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "job1-0", Namespace: "runai-a", Labels: map[string]string{"release": "job1"}}}, key: "runai-a/job1", found: true},
+		{name: "pod without owner", object: &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod1", Namespace: "runai-a"}}, key: "runai-a/pod1", found: true},
+		{name: "pod of a job", object: ownedPod("Job", "job1"), key: "runai-a/job1", found: true},
+		{name: "pod of a replica set", object: ownedPod("ReplicaSet", "deploy1-5d8f"), found: false},
+		{name: "deleted object", object: cache.DeletedFinalStateUnknown{Obj: &v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "job1", Namespace: "runai-a"}}}, key: "runai-a/job1", found: true},
+	}
+
+	for _, tt := range tests {
+		key, found := getJobKeyOfObject(tt.object)
+		if key != tt.key || found != tt.found {
+			t.Errorf("%s: expected the key %s (%v), got %s (%v)", tt.name, tt.key, tt.found, key, found)
+		}
+	}
+}
+
+func newTestJobInformers(objects ...interface{}) *jobInformers {
+	newInformer := func(objectType runtime.Object) cache.SharedIndexInformer {
+		return cache.NewSharedIndexInformer(&cache.ListWatch{}, objectType, 0, cache.Indexers{})
+	}
+	ji := &jobInformers{
+		jobs:         newInformer(&batchv1.Job{}),
+		statefulSets: newInformer(&appsv1.StatefulSet{}),
+		deployments:  newInformer(&appsv1.Deployment{}),
+		pods:         []cache.SharedIndexInformer{newInformer(&v1.Pod{}), newInformer(&v1.Pod{})},
+		customJobs:   map[schema.GroupVersionResource]cache.SharedIndexInformer{},
+	}
+	for _, obj := range objects {
+		switch obj.(type) {
+		case *batchv1.Job:
+			ji.jobs.GetStore().Add(obj)
+		case *v1.Pod:
+			// the pods of a job may be watched by several informers
+			for _, informer := range ji.pods {
+				informer.GetStore().Add(obj)
+			}
+		}
+	}
+	return ji
+}
+
+func TestJobInformersGetJob(t *testing.T) {
+	controller := true
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{Name: "job1", Namespace: "runai-a", UID: "job1-uid"},
+		Spec: batchv1.JobSpec{
+			Template: v1.PodTemplateSpec{Spec: v1.PodSpec{SchedulerName: constants.SchedulerName}},
+		},
+		Status: batchv1.JobStatus{Active: 1},
+	}
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "job1-abc", Namespace: "runai-a", UID: "pod1-uid", Labels: map[string]string{"job-name": "job1"},
+			OwnerReferences: []metav1.OwnerReference{{Kind: "Job", Name: "job1", UID: "job1-uid", Controller: &controller}}},
+		Spec:   v1.PodSpec{SchedulerName: constants.SchedulerName},
+		Status: v1.PodStatus{Phase: v1.PodRunning},
+	}
+	ji := newTestJobInformers(job, pod)
+
+	trainingJob, err := ji.getJob("runai-a", "job1")
+	if err != nil || trainingJob == nil {
+		t.Fatalf("expected the job job1, got %v (%v)", trainingJob, err)
+	}
+	if len(trainingJob.AllPods()) != 1 {
+		t.Errorf("expected the job to have a single pod, got %d", len(trainingJob.AllPods()))
+	}
+	if status := GetJobRealStatus(trainingJob); status != constants.Status.Running {
+		t.Errorf("expected the job to be %s, got %s", constants.Status.Running, status)
+	}
+
+	if trainingJob, err := ji.getJob("runai-a", "job2"); err != nil || trainingJob != nil {
+		t.Errorf("expected no job job2, got %v (%v)", trainingJob, err)
+	}
+}
+
+func TestJobInformersGetPodWithoutOwner(t *testing.T) {
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "pod1", Namespace: "runai-a", UID: "pod1-uid"},
+		Spec:       v1.PodSpec{SchedulerName: constants.SchedulerName},
+		Status:     v1.PodStatus{Phase: v1.PodPending},
+	}
+	ji := newTestJobInformers(pod)
+
+	trainingJob, err := ji.getJob("runai-a", "pod1")
+	if err != nil || trainingJob == nil {
+		t.Fatalf("expected the job pod1, got %v (%v)", trainingJob, err)
+	}
+	if len(trainingJob.AllPods()) != 1 {
+		t.Errorf("expected the job to have a single pod, got %d", len(trainingJob.AllPods()))
+	}
+}
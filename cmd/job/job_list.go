@@ -17,7 +17,10 @@ package job
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
+	"reflect"
+	"sort"
 	"strings"
 	"text/tabwriter"
 	"time"
@@ -25,6 +28,7 @@ import (
 	"github.com/run-ai/runai-cli/cmd/completion"
 	"github.com/run-ai/runai-cli/pkg/types"
 
+	"github.com/run-ai/runai-cli/pkg/authentication"
 	"github.com/run-ai/runai-cli/pkg/authentication/assertion"
 	commandUtil "github.com/run-ai/runai-cli/pkg/util/command"
 
@@ -37,16 +41,97 @@ import (
 	cmdUtil "github.com/run-ai/runai-cli/cmd/util"
 
 	"github.com/run-ai/runai-cli/pkg/client"
+	"github.com/run-ai/runai-cli/pkg/jobs"
+	prom "github.com/run-ai/runai-cli/pkg/prometheus"
 	"github.com/run-ai/runai-cli/pkg/ui"
 	"github.com/run-ai/runai-cli/pkg/util"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 )
 
-const jobInvalidStateOnCreationTimeInSeconds = 30
+const (
+	jobInvalidStateOnCreationTimeInSeconds = 30
+	invalidJobStatus                       = "Invalid job"
+
+	SortJobsByAge    = "age"
+	SortJobsByGPU    = "gpu"
+	SortJobsByName   = "name"
+	SortJobsByStatus = "status"
+)
+
+var jobSortFields = []string{SortJobsByAge, SortJobsByGPU, SortJobsByName, SortJobsByStatus}
+
+// JobListFilter filters and sorts the listed jobs. The trainer list options are applied when listing the jobs, the
+// status is matched against the listed jobs, as it is calculated from their pods.
+type JobListFilter struct {
+	trainer.JobListOptions
+	Status string
+	SortBy string
+	// Mine lists only the jobs of the current user
+	Mine bool
+	// IdleFor lists only the jobs whose allocated gpus have been idle for at least the duration
+	IdleFor time.Duration
+}
+
+var (
+	jobListHiddenFields = ui.EnsureStringPaths(types.JobListView{}, []string{
+		"Duration",
+		"PriorityClass",
+		"RequestedGPUs",
+		"PendingPods",
+		"Invalid",
+	})
+
+	jobListWideHiddenFields = ui.EnsureStringPaths(types.JobListView{}, []string{
+		"RequestedGPUs",
+		"PendingPods",
+		"Invalid",
+	})
+
+	jobListFormatters = map[string]ui.FormatFunction{
+		"age": func(value, model interface{}) (string, error) {
+			duration, ok := value.(time.Duration)
+			if !ok {
+				return "", fmt.Errorf("[AGE Format]:: expecting time.Duration, got: %s", reflect.ValueOf(value).Type().Name())
+			}
+			if isInvalidJobView(model) {
+				return "", nil
+			}
+			return util.ShortHumanDuration(duration), nil
+		},
+		"allocatedgpus": func(value, model interface{}) (string, error) {
+			jobView := model.(types.JobListView)
+			if jobView.Invalid {
+				return "", nil
+			}
+			allocatedGPUs := fmt.Sprintf("%g", jobView.AllocatedGPUs)
+			if jobView.AllocatedGPUs == 0 && trainer.IsFinishedStatus(jobView.Status) {
+				allocatedGPUs = "-"
+			}
+			return fmt.Sprintf("%s (%v)", allocatedGPUs, jobView.RequestedGPUs), nil
+		},
+		"pods": func(value, model interface{}) (string, error) {
+			jobView := model.(types.JobListView)
+			if jobView.Invalid {
+				return "", nil
+			}
+			return fmt.Sprintf("%d (%d)", jobView.RunningPods, jobView.PendingPods), nil
+		},
+		"list": func(value, model interface{}) (string, error) {
+			items, ok := value.([]string)
+			if !ok {
+				return "", fmt.Errorf("[LIST Format]:: expecting []string, got: %s", reflect.ValueOf(value).Type().Name())
+			}
+			return strings.Join(items, ", "), nil
+		},
+	}
+)
 
 func ListCommand() *cobra.Command {
 	var allNamespaces bool
+	var watch bool
+	var output ui.OutputOpt
+	var filter JobListFilter
 	var command = &cobra.Command{
 		Use:               "jobs",
 		Aliases:           []string{"job"},
@@ -54,16 +139,47 @@ func ListCommand() *cobra.Command {
 		PreRun:            commandUtil.RoleAssertion(assertion.AssertViewerRole),
 		ValidArgsFunction: completion.NoArgs,
 		Run: func(cmd *cobra.Command, args []string) {
-			RunJobList(cmd, args, allNamespaces)
+			RunJobList(cmd, args, allNamespaces, watch, output, filter)
 		},
 	}
 
 	command.Flags().BoolVarP(&allNamespaces, "all-projects", "A", false, "list from all projects")
+	command.Flags().BoolVarP(&watch, "watch", "w", false, "keep the list updated as jobs change")
+	command.Flags().StringVar(&filter.Status, "status", "", "list only the jobs with the status, e.g. Running")
+	command.Flags().StringVar(&filter.User, "user", "", "list only the jobs submitted by the user")
+	command.Flags().BoolVar(&filter.Mine, "mine", false, "list only the jobs submitted by the current user")
+	command.Flags().StringVar(&filter.JobType, "type", "", fmt.Sprintf("list only the jobs of the type: %s", strings.Join(trainer.JobTypes, ", ")))
+	command.Flags().StringVar(&filter.NodeName, "node", "", "list only the jobs with pods on the node")
+	command.Flags().StringVarP(&filter.LabelSelector, "selector", "l", "", "list only the jobs which match the label selector, e.g. -l key1=value1,key2=value2")
+	command.Flags().DurationVar(&filter.IdleFor, "idle-for", 0, "list only the jobs whose allocated gpus have been idle for at least the duration, e.g. 2h")
+	command.Flags().StringVar(&filter.SortBy, "sort-by", "", fmt.Sprintf("sort the jobs by one of: %s", strings.Join(jobSortFields, ", ")))
+	flags.AddOutputFlags(command.Flags(), &output)
 
 	return command
 }
 
-func RunJobList(cmd *cobra.Command, args []string, allNamespaces bool) {
+func RunJobList(cmd *cobra.Command, args []string, allNamespaces bool, watch bool, output ui.OutputOpt, filter JobListFilter) {
+	if err := validateWatchOutput(watch, output); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	if err := filter.Validate(); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	if watch && filter.IdleFor != 0 {
+		// the idle time is computed from the gpu utilization history, which should not be queried on every change
+		fmt.Println("--watch and --idle-for can't be used together")
+		os.Exit(1)
+	}
+	if filter.Mine {
+		currentUser, err := authentication.GetCurrentUser()
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		filter.User = currentUser
+	}
 
 	kubeClient, err := client.GetClient()
 	if err != nil {
@@ -78,18 +194,62 @@ func RunJobList(cmd *cobra.Command, args []string, allNamespaces bool) {
 		os.Exit(1)
 	}
 
-	cmdUtil.PrintShowingJobsInNamespaceMessageByStatuses(namespaceInfo, cmdUtil.AllStatuses)
+	if output.IsTable() {
+		cmdUtil.PrintShowingJobsInNamespaceMessageByStatuses(namespaceInfo, cmdUtil.AllStatuses)
+	}
 
-	jobs, invalidJobs, err := PrepareTrainerJobList(kubeClient, namespaceInfo)
-	if err != nil {
-		log.Error(err)
-		os.Exit(1)
+	var promClient *prom.Client
+	if filter.IdleFor != 0 {
+		promClient, err = prom.BuildMetricsClient(kubeClient)
+		if err == nil && promClient == nil {
+			err = fmt.Errorf("prometheus was not found in the cluster, --idle-for requires prometheus")
+		}
+		if err != nil {
+			log.Error(err)
+			os.Exit(1)
+		}
 	}
 
-	jobs = trainer.MakeTrainingJobOrderdByProject(trainer.MakeTrainingJobOrderdByName(jobs))
+	// invalid jobs have no pods, labels or user, so they can't match a filter
+	printJobList := func(w io.Writer, jobs []trainer.TrainingJob, invalidJobs []string) ([]string, error) {
+		if !filter.IsEmpty() {
+			invalidJobs = nil
+		}
+		if filter.IdleFor != 0 {
+			var err error
+			if jobs, err = filterIdleJobs(promClient, jobs, filter.IdleFor); err != nil {
+				return nil, err
+			}
+		}
+
+		jobs = trainer.MakeTrainingJobOrderdByProject(trainer.MakeTrainingJobOrderdByName(jobs))
 
-	displayTrainingJobList(jobs, invalidJobs)
+		jobViews := filter.apply(toJobListViews(jobs, invalidJobs))
+		rowKeys := []string{}
+		for _, jobView := range jobViews {
+			rowKeys = append(rowKeys, getJobRowKey(jobView.Project, jobView.Name))
+		}
+		return rowKeys, displayJobListViews(w, jobViews, output)
+	}
 
+	if watch {
+		err = watchJobsTable(kubeClient, namespaceInfo.Namespace, 0, newJobsSource(kubeClient, namespaceInfo, filter.JobListOptions, nil), printJobList)
+	} else {
+		var jobs []trainer.TrainingJob
+		var invalidJobs []string
+		if filter.IsEmpty() {
+			jobs, invalidJobs, err = PrepareTrainerJobList(kubeClient, namespaceInfo)
+		} else {
+			jobs, err = trainer.GetJobs(kubeClient, namespaceInfo, filter.JobListOptions, nil)
+		}
+		if err == nil {
+			_, err = printJobList(os.Stdout, jobs, invalidJobs)
+		}
+	}
+	if err != nil {
+		log.Error(err)
+		os.Exit(1)
+	}
 }
 
 func PrepareTrainerJobList(kubeClient *client.Client, namespaceInfo types.NamespaceInfo) ([]trainer.TrainingJob, []string, error) {
@@ -121,6 +281,21 @@ func PrepareTrainerJobList(kubeClient *client.Client, namespaceInfo types.Namesp
 	return jobs, invalidJobs, nil
 }
 
+// ListJobNames returns the names of the jobs in the namespace which match the options. Jobs which failed to be created
+// are not included, as they have neither a user nor labels.
+func ListJobNames(kubeClient *client.Client, namespaceInfo types.NamespaceInfo, options trainer.JobListOptions) ([]string, error) {
+	jobs, err := trainer.GetJobs(kubeClient, namespaceInfo, options, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	jobNames := []string{}
+	for _, job := range jobs {
+		jobNames = append(jobNames, job.Name())
+	}
+	return jobNames, nil
+}
+
 func ListJobNamesByNamespace(kubeClient *client.Client, namespaceInfo types.NamespaceInfo) ([]string, error) {
 	jobs, invalidJobs, err := PrepareTrainerJobList(kubeClient, namespaceInfo)
 	if err != nil {
@@ -143,47 +318,153 @@ func isJobCreationTimePass(configMap *v1.ConfigMap) bool {
 	return time.Now().Sub(configMap.CreationTimestamp.Time).Seconds() > jobInvalidStateOnCreationTimeInSeconds
 }
 
-func displayTrainingJobList(jobInfoList []trainer.TrainingJob, invalidJobs []string) {
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	labelField := []string{"NAME", "STATUS", "AGE", "NODE", "IMAGE", "TYPE", "PROJECT", "USER", "GPUs Allocated (Requested)", "PODs Running (Pending)", "SERVICE URL(S)"}
+// Validate checks the job type and the sort field of the filter, and that it doesn't set the user twice
+func (filter JobListFilter) Validate() error {
+	if filter.JobType != "" && !containsFold(trainer.JobTypes, filter.JobType) {
+		return fmt.Errorf("unsupported job type: %s, supported types are %s", filter.JobType, strings.Join(trainer.JobTypes, ", "))
+	}
+	if filter.SortBy != "" && !containsFold(jobSortFields, filter.SortBy) {
+		return fmt.Errorf("unsupported sort field: %s, supported fields are %s", filter.SortBy, strings.Join(jobSortFields, ", "))
+	}
+	if filter.Mine && filter.User != "" {
+		return fmt.Errorf("--mine and --user can't be used together")
+	}
+	if filter.IdleFor < 0 {
+		return fmt.Errorf("--idle-for must be a positive duration")
+	}
+	return nil
+}
 
-	ui.Line(w, labelField...)
+// IsEmpty returns true when the filter doesn't filter out any job, it may still sort them
+func (filter JobListFilter) IsEmpty() bool {
+	return filter.JobListOptions == trainer.JobListOptions{} && filter.Status == "" && filter.IdleFor == 0
+}
 
-	for _, jobInfo := range jobInfoList {
+// filterIdleJobs keeps only the jobs whose allocated gpus have been idle for at least `idleFor`
+func filterIdleJobs(promClient *prom.Client, trainingJobs []trainer.TrainingJob, idleFor time.Duration) ([]trainer.TrainingJob, error) {
+	idleJobViews, err := jobs.GetIdleJobs(promClient, trainingJobs, idleFor, time.Now())
+	if err != nil {
+		return nil, err
+	}
 
-		status := GetJobRealStatus(jobInfo)
-		nodeName := jobInfo.HostIPOfChief()
-		if strings.Contains(nodeName, ", ") {
-			nodeName = "<multiple>"
+	idleJobs := map[string]bool{}
+	for _, view := range idleJobViews {
+		idleJobs[view.Project+"/"+view.Name] = true
+	}
+	filtered := []trainer.TrainingJob{}
+	for _, job := range trainingJobs {
+		if idleJobs[job.Project()+"/"+job.Name()] {
+			filtered = append(filtered, job)
 		}
+	}
+	return filtered, nil
+}
 
-		// For backward compatability. Indicat jobs on default namespace
-		var projectName string
-		if jobInfo.Namespace() == "default" {
-			projectName = fmt.Sprintf("%s (old)", jobInfo.Project())
-		} else {
-			projectName = jobInfo.Project()
+// apply returns the job views which match the status of the filter, sorted by its sort field.
+// The sort is stable, so jobs with equal sort values keep their order.
+func (filter JobListFilter) apply(jobViews []types.JobListView) []types.JobListView {
+	filtered := []types.JobListView{}
+	for _, jobView := range jobViews {
+		if filter.Status != "" && !strings.EqualFold(jobView.Status, filter.Status) {
+			continue
 		}
+		filtered = append(filtered, jobView)
+	}
 
-		currentAllocatedGPUs := jobInfo.CurrentAllocatedGPUs()
-		currentAllocatedGPUsAsString := fmt.Sprintf("%g", currentAllocatedGPUs)
-		if currentAllocatedGPUs == 0 && trainer.IsFinishedStatus(status) {
-			currentAllocatedGPUsAsString = "-"
-		}
-		allocatedFromRequestedGPUs := fmt.Sprintf("%s (%v)", currentAllocatedGPUsAsString, jobInfo.RequestedGPUString())
-		runningOfActivePods := fmt.Sprintf("%d (%d)", int(jobInfo.RunningPods()), int(jobInfo.PendingPods()))
+	var less func(i, j int) bool
+	switch strings.ToLower(filter.SortBy) {
+	case SortJobsByAge:
+		less = func(i, j int) bool { return filtered[i].Age < filtered[j].Age }
+	case SortJobsByGPU:
+		less = func(i, j int) bool { return filtered[i].AllocatedGPUs > filtered[j].AllocatedGPUs }
+	case SortJobsByName:
+		less = func(i, j int) bool { return filtered[i].Name < filtered[j].Name }
+	case SortJobsByStatus:
+		less = func(i, j int) bool { return strings.ToLower(filtered[i].Status) < strings.ToLower(filtered[j].Status) }
+	default:
+		return filtered
+	}
+	sort.SliceStable(filtered, less)
+	return filtered
+}
 
-		ui.Line(w, jobInfo.Name(),
-			status,
-			util.ShortHumanDuration(jobInfo.Age()),
-			nodeName, jobInfo.Image(), jobInfo.Trainer(), projectName, jobInfo.User(),
-			allocatedFromRequestedGPUs,
-			runningOfActivePods,
-			strings.Join(jobInfo.ServiceURLs(), ", "))
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
 	}
+	return false
+}
 
+func toJobListViews(jobInfoList []trainer.TrainingJob, invalidJobs []string) []types.JobListView {
+	jobViews := []types.JobListView{}
+	for _, jobInfo := range jobInfoList {
+		jobViews = append(jobViews, toJobListView(jobInfo))
+	}
 	for _, invalidJob := range invalidJobs {
-		ui.Line(w, invalidJob, "Invalid job", "", "", "", "", "", "", "", "", "")
+		jobViews = append(jobViews, types.JobListView{Name: invalidJob, Status: invalidJobStatus, Invalid: true})
+	}
+	return jobViews
+}
+
+func displayJobListViews(out io.Writer, jobViews []types.JobListView, output ui.OutputOpt) error {
+	if !output.IsTable() {
+		return ui.PrintList(out, jobViews, ui.ListOpt{Kind: "job", NamePath: "{.name}"}, output)
+	}
+
+	hiddenFields := jobListHiddenFields
+	if output.IsWide() {
+		hiddenFields = jobListWideHiddenFields
 	}
-	_ = w.Flush()
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	err := ui.CreateTable(types.JobListView{}, ui.TableOpt{
+		DisplayOpt: ui.DisplayOpt{Hide: hiddenFields},
+		Formatts:   jobListFormatters,
+		NoHeaders:  output.NoHeaders,
+	}).Render(w, jobViews).Error()
+	if err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
+func toJobListView(jobInfo trainer.TrainingJob) types.JobListView {
+	status := GetJobRealStatus(jobInfo)
+	nodeName := jobInfo.HostIPOfChief()
+	if strings.Contains(nodeName, ", ") {
+		nodeName = "<multiple>"
+	}
+
+	// For backward compatability. Indicat jobs on default namespace
+	var projectName string
+	if jobInfo.Namespace() == "default" {
+		projectName = fmt.Sprintf("%s (old)", jobInfo.Project())
+	} else {
+		projectName = jobInfo.Project()
+	}
+
+	return types.JobListView{
+		Name:          jobInfo.Name(),
+		Status:        status,
+		Age:           jobInfo.Age(),
+		Duration:      jobInfo.Duration(),
+		Node:          nodeName,
+		Image:         jobInfo.Image(),
+		Type:          jobInfo.Trainer(),
+		Project:       projectName,
+		User:          jobInfo.User(),
+		PriorityClass: jobInfo.GetPriorityClass(),
+		AllocatedGPUs: jobInfo.CurrentAllocatedGPUs(),
+		RequestedGPUs: jobInfo.RequestedGPUString(),
+		RunningPods:   int(jobInfo.RunningPods()),
+		PendingPods:   int(jobInfo.PendingPods()),
+		ServiceURLs:   jobInfo.ServiceURLs(),
+	}
+}
+
+func isInvalidJobView(model interface{}) bool {
+	jobView, ok := model.(types.JobListView)
+	return ok && jobView.Invalid
 }
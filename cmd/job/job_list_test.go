@@ -0,0 +1,71 @@
+package job
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/run-ai/runai-cli/cmd/trainer"
+	"github.com/run-ai/runai-cli/pkg/types"
+)
+
+var jobListTestViews = []types.JobListView{
+	{Name: "b-job", Status: "Running", Age: 2 * time.Hour, AllocatedGPUs: 1},
+	{Name: "a-job", Status: "PENDING", Age: time.Hour, AllocatedGPUs: 0},
+	{Name: "c-job", Status: "Succeeded", Age: 3 * time.Hour, AllocatedGPUs: 2},
+}
+
+func jobViewNames(jobViews []types.JobListView) string {
+	names := []string{}
+	for _, jobView := range jobViews {
+		names = append(names, jobView.Name)
+	}
+	return strings.Join(names, ",")
+}
+
+func TestJobListFilterApply(t *testing.T) {
+	tests := []struct {
+		name     string
+		filter   JobListFilter
+		expected string
+	}{
+		{name: "no filter", filter: JobListFilter{}, expected: "b-job,a-job,c-job"},
+		{name: "status", filter: JobListFilter{Status: "pending"}, expected: "a-job"},
+		{name: "sort by age", filter: JobListFilter{SortBy: SortJobsByAge}, expected: "a-job,b-job,c-job"},
+		{name: "sort by gpu", filter: JobListFilter{SortBy: SortJobsByGPU}, expected: "c-job,b-job,a-job"},
+		{name: "sort by name", filter: JobListFilter{SortBy: SortJobsByName}, expected: "a-job,b-job,c-job"},
+		{name: "sort by status", filter: JobListFilter{SortBy: SortJobsByStatus}, expected: "a-job,b-job,c-job"},
+		{name: "status sorted by name", filter: JobListFilter{Status: "running", SortBy: "NAME"}, expected: "b-job"},
+	}
+
+	for _, tt := range tests {
+		if names := jobViewNames(tt.filter.apply(jobListTestViews)); names != tt.expected {
+			t.Errorf("%s: expected jobs %s, got %s", tt.name, tt.expected, names)
+		}
+	}
+}
+
+func TestJobListFilterValidate(t *testing.T) {
+	valid := []JobListFilter{
+		{},
+		{JobListOptions: trainer.JobListOptions{JobType: "mpi"}, SortBy: SortJobsByGPU},
+		{JobListOptions: trainer.JobListOptions{JobType: trainer.RunaiInteractiveType}, IdleFor: 2 * time.Hour},
+	}
+	for _, filter := range valid {
+		if err := filter.Validate(); err != nil {
+			t.Errorf("expected filter %+v to be valid, got: %v", filter, err)
+		}
+	}
+
+	invalid := []JobListFilter{
+		{JobListOptions: trainer.JobListOptions{JobType: "spark"}},
+		{SortBy: "project"},
+		{JobListOptions: trainer.JobListOptions{User: "alice"}, Mine: true},
+		{IdleFor: -time.Hour},
+	}
+	for _, filter := range invalid {
+		if err := filter.Validate(); err == nil {
+			t.Errorf("expected filter %+v to be invalid", filter)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package submit
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/run-ai/runai-cli/cmd/flags"
+	"github.com/run-ai/runai-cli/cmd/job"
+	"github.com/run-ai/runai-cli/pkg/client"
+	"github.com/run-ai/runai-cli/pkg/config"
+	"github.com/run-ai/runai-cli/pkg/workflow"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+const (
+	resubmitCommand  = "resubmit"
+	resubmitExamples = `
+# Submit job train1 again, the new job is named by adding an index to the name of the job, e.g. train1-1
+runai resubmit train1
+
+# Submit job train1 again with another name and with 2 GPUs
+runai resubmit train1 --name train2 -g 2
+
+# Submit job train1 again with another command
+runai resubmit train1 -- python train.py --epochs 20
+`
+)
+
+// NewResubmitCommand creates the resubmit command, which submits an existing job again by running the submit command
+// of its chart with '--from'
+func NewResubmitCommand() *cobra.Command {
+	var command = &cobra.Command{
+		Use:                   resubmitCommand + " JOB_NAME [flags] -- [COMMAND] [args...]",
+		DisableFlagsInUseLine: true,
+		Short:                 "Submit an existing job again.",
+		Long: fmt.Sprintf(`Submit an existing job again, with the values it was submitted with and a name generated from its name.
+Any flag of '%[1]s %[2]s' can be set to override the values of the existing job.
+MPI jobs are submitted again by '%[1]s %[3]s --from JOB_NAME', so any flag of '%[1]s %[3]s' can be set for them.`, config.CLIName, submitCommand, SubmitMpiCommand),
+		Example:            resubmitExamples,
+		ValidArgsFunction:  job.GenJobNames,
+		DisableFlagParsing: true,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) > 0 && (args[0] == "-h" || args[0] == "--help") {
+				cmd.HelpFunc()(cmd, args)
+				return
+			}
+			if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+				fmt.Printf("The name of the job to submit again must be the first argument\n\n")
+				cmd.HelpFunc()(cmd, args)
+				os.Exit(1)
+			}
+
+			chartName, err := getResubmittedJobChartName(cmd, args)
+			if err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+
+			// the flags are parsed by the submit command, so they are the same as the flags of the submit command
+			root := cmd.Root()
+			root.SetArgs(getResubmitArgs(args, chartName))
+			if err := root.Execute(); err != nil {
+				os.Exit(1)
+			}
+		},
+	}
+
+	return command
+}
+
+// getResubmittedJobChartName returns the chart the job was submitted with. The flags of the resubmit command are not
+// parsed, so the project flag is parsed from the args to find the job in its project
+func getResubmittedJobChartName(cmd *cobra.Command, args []string) (string, error) {
+	projectFlags := pflag.NewFlagSet(resubmitCommand, pflag.ContinueOnError)
+	projectFlags.ParseErrorsWhitelist.UnknownFlags = true
+	projectFlags.AddFlag(cmd.InheritedFlags().Lookup(flags.ProjectFlag))
+	if err := projectFlags.Parse(args[1:]); err != nil {
+		return "", err
+	}
+
+	kubeClient, err := client.GetClient()
+	if err != nil {
+		return "", err
+	}
+	namespaceInfo, err := flags.GetNamespaceToUseFromProjectFlag(cmd, kubeClient)
+	if err != nil {
+		return "", err
+	}
+	jobConfig, err := workflow.GetJobConfig(args[0], namespaceInfo.Namespace, kubeClient.GetClientset())
+	if err != nil {
+		return "", err
+	}
+	return jobConfig.ChartName, nil
+}
+
+// getResubmitArgs returns the args of the submit command of the chart, which submits the job again
+func getResubmitArgs(args []string, chartName string) []string {
+	submitCommandName, found := chartSubmitCommands[chartName]
+	if !found {
+		submitCommandName = submitCommand
+	}
+	submitArgs := append([]string{config.CLIName, submitCommandName, "--from", args[0]}, args[1:]...)
+	return AlignArgsPreParsing(submitArgs)[1:]
+}
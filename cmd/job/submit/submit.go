@@ -31,7 +31,9 @@ import (
 	raUtil "github.com/run-ai/runai-cli/cmd/util"
 	"github.com/run-ai/runai-cli/pkg/client"
 	"github.com/run-ai/runai-cli/pkg/clusterConfig"
+	"github.com/run-ai/runai-cli/pkg/localcode"
 	"github.com/run-ai/runai-cli/pkg/util"
+	"github.com/run-ai/runai-cli/pkg/workflow"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -59,7 +61,6 @@ const (
 )
 
 var (
-	dryRun                  bool
 	templateName            string
 	gitSyncConnectionString string
 )
@@ -113,7 +114,20 @@ type submitArgs struct {
 	NamePrefix                 string            `yaml:"namePrefix,omitempty"`
 	BackoffLimit               *int              `yaml:"backoffLimit,omitempty"`
 	GitSync                    *GitSync          `yaml:"gitSync,omitempty"`
+	Tolerations                []string          `yaml:"toleration,omitempty"`
+	NodeSelectors              []string          `yaml:"nodeSelectorLabels,omitempty"`
+	NodeAffinity               []string          `yaml:"nodeAffinity,omitempty"`
+	PreferredNodeAffinity      []string          `yaml:"preferredNodeAffinity,omitempty"`
+	Scheduling                 *schedulingValues `yaml:"scheduling,omitempty"`
+	EnvFromSecrets             []string          `yaml:"envFromSecret,omitempty"`
+	SecretEnvironmentVariables []string          `yaml:"secretEnvironment,omitempty"`
+	SecretMounts               []string          `yaml:"secretMount,omitempty"`
+	ConfigMapMounts            []string          `yaml:"configMapMount,omitempty"`
+	Secrets                    *secretValues     `yaml:"secrets,omitempty"`
+	SyncLocal                  string            `yaml:"syncLocal,omitempty"`
+	LocalCode                  *localCodeValues  `yaml:"localCode,omitempty"`
 	generateSuffix             bool
+	localCodeArchive           *localcode.Archive
 }
 
 func (s submitArgs) check() error {
@@ -136,7 +150,7 @@ func (submitArgs *submitArgs) addCommonSubmit(fbg flags.FlagsByGroups) {
 }
 
 func (submitArgs *submitArgs) addCliCommand() {
-	submitArgs.CliCommand = strings.Join(os.Args, " ")
+	submitArgs.CliCommand = strings.Join(redactGitSyncCredentials(os.Args), " ")
 }
 
 func (submitArgs *submitArgs) addCommonFlags(fbg flags.FlagsByGroups) {
@@ -146,10 +160,11 @@ func (submitArgs *submitArgs) addCommonFlags(fbg flags.FlagsByGroups) {
 	flags.AddBoolNullableFlag(flagSet, &(submitArgs.Interactive), "interactive", "", "Mark this Job as interactive.")
 	flagSet.StringVarP(&(templateName), "template", "", "", "Use a specific template to run this job (otherwise use the default template if exists).")
 	flagSet.StringVarP(&(submitArgs.Project), "project", "p", "", "Specifies a project. Set a default project using 'runai config project <project name>'.")
-	// Will not submit the job to the cluster, just print the template to the screen
-	flagSet.BoolVar(&dryRun, "dry-run", false, "Run as dry run")
-	flagSet.MarkHidden("dry-run")
+	addDryRunFlags(flagSet)
 	flagSet.StringVar(&submitArgs.NamePrefix, "job-name-prefix", "", "Set defined prefix for the job name and add index as suffix")
+	flagSet.StringVarP(&jobSpecFile, "file", "f", "", "Load the job specification from a yaml or json file. Flags set on the command line override the values in the file.")
+	flagSet.StringVar(&exportJobName, "export", "", "Print the specification of an existing job, which can be submitted again using --file.")
+	flagSet.StringVar(&fromJobName, "from", "", "Submit a copy of an existing job, with a name generated from its name. Flags set on the command line override the values of the existing job.")
 
 	flagSet = fbg.GetOrAddFlagSet(ContainerDefinitionFlagGroup)
 	flagSet.StringVar(&(submitArgs.ImagePullPolicy), "image-pull-policy", "Always", "set image pull policy: always, ifNotPresent or never.")
@@ -158,6 +173,8 @@ func (submitArgs *submitArgs) addCommonFlags(fbg flags.FlagsByGroups) {
 	flagSet.StringArrayVar(&(submitArgs.SpecArgs), "args", []string{}, "Arguments to pass to the command run on container start. Use together with --command.")
 	flagSet.MarkDeprecated("args", "please use -- with extra arguments. See usage")
 	flagSet.StringArrayVarP(&(submitArgs.EnvironmentVariable), "environment", "e", []string{}, "Set environment variables in the container.")
+	flagSet.StringArrayVar(&(submitArgs.EnvFromSecrets), "env-from-secret", []string{}, "Set environment variables in the container from all the keys of a secret.")
+	flagSet.StringArrayVar(&(submitArgs.SecretEnvironmentVariables), "env", []string{}, "Set an environment variable in the container from a key of a secret, in the format of SECRET:KEY=VAR.")
 	flagSet.StringVarP(&(submitArgs.Image), "image", "i", "", "Container image to use when creating the job.")
 	flagSet.StringArrayVar(&(submitArgs.SpecCommand), oldCommandFlag, []string{}, "Run this command on container start. Use together with --args.")
 	flagSet.MarkHidden(oldCommandFlag)
@@ -168,7 +185,9 @@ func (submitArgs *submitArgs) addCommonFlags(fbg flags.FlagsByGroups) {
 	flags.AddBoolNullableFlag(flagSet, &submitArgs.StdIn, "stdin", "", "Keep stdin open on the container(s) in the pod, even if nothing is attached.")
 	flags.AddBoolNullableFlag(flagSet, &submitArgs.Attach, "attach", "", `If true, wait for the Pod to start running, and then attach to the Pod as if 'runai attach ...' were called. Attach makes tty and stdin true by default. Default false`)
 	flagSet.StringVar(&(submitArgs.WorkingDir), "working-dir", "", "Set the container's working directory.")
-	flagSet.StringVar(&gitSyncConnectionString, "git-sync", "", "sync string in the template of: source=REPO,branch=BRANCH_NAME,rev=REVISION,username=USER,password=PASSWORD,target=TARGET_DIRECTORY_TO_CLONE")
+	flagSet.StringVar(&gitSyncConnectionString, "git-sync", "", "sync string in the template of: source=REPO,branch=BRANCH_NAME,rev=REVISION,username=USER,password=PASSWORD,target=TARGET_DIRECTORY_TO_CLONE. Instead of a password, set token=TOKEN, ssh-key=PRIVATE_KEY_FILE[,known-hosts=KNOWN_HOSTS_FILE] or secret=SECRET_NAME")
+	flagSet.StringVar(&(submitArgs.SyncLocal), "sync-local", "", "Upload the code of a local directory into the working directory of the job (default /code). Files which are ignored by .gitignore are not uploaded.")
+	flagSet.StringVar(&gitSyncSecret, "git-sync-secret", "", "Use the git sync credentials of an existing secret, which has either the keys username and password, or a key ssh with a private ssh key and an optional key known_hosts.")
 	flags.AddBoolNullableFlag(flagSet, &(submitArgs.RunAsCurrentUser), "run-as-user", "", "Run in the context of the current CLI user rather than the root user.")
 
 	flagSet = fbg.GetOrAddFlagSet(ResourceAllocationFlagGroup)
@@ -186,6 +205,8 @@ func (submitArgs *submitArgs) addCommonFlags(fbg flags.FlagsByGroups) {
 	flagSet.StringArrayVar(&(submitArgs.PersistentVolumes), "pvc", []string{}, "Mount a persistent volume. Syntax: 'StorageClass[optional]:Size:ContainerMountPath:ro[optional]")
 	flagSet.StringArrayVar(&(submitArgs.Volumes), "volumes", []string{}, "Volumes to mount into the container.")
 	flagSet.MarkDeprecated("volumes", "please use 'volume' flag instead.")
+	flagSet.StringArrayVar(&(submitArgs.SecretMounts), "mount-secret", []string{}, "Mount the keys of a secret as files in a directory, in the format of NAME:/path.")
+	flagSet.StringArrayVar(&(submitArgs.ConfigMapMounts), "mount-configmap", []string{}, "Mount the keys of a config map as files in a directory, in the format of NAME:/path.")
 
 	flagSet = fbg.GetOrAddFlagSet(NetworkFlagGroup)
 	flags.AddBoolNullableFlag(flagSet, &(submitArgs.HostIPC), "host-ipc", "", "Use the host's ipc namespace.")
@@ -195,6 +216,7 @@ func (submitArgs *submitArgs) addCommonFlags(fbg flags.FlagsByGroups) {
 	flags.AddIntNullableFlag(flagSet, &(submitArgs.BackoffLimit), "backoff-limit", "The number of times the job will be retried before failing. Default 6.")
 	flags.AddIntNullableFlag(flagSet, &(submitArgs.BackoffLimit), "backoffLimit", "The number of times the job will be retried before failing. Default 6.")
 	flagSet.MarkDeprecated("backoffLimit", "use backoff-limit instead")
+	addAfterFlags(flagSet)
 
 	flagSet = fbg.GetOrAddFlagSet(AccessControlFlagGroup)
 	flags.AddBoolNullableFlag(flagSet, &submitArgs.CreateHomeDir, "create-home-dir", "", "Create a temporary home directory. Default is true when the --run-as-user flag is set, and false if not.")
@@ -204,10 +226,17 @@ func (submitArgs *submitArgs) addCommonFlags(fbg flags.FlagsByGroups) {
 
 	flagSet = fbg.GetOrAddFlagSet(SchedulingFlagGroup)
 	flagSet.StringVar(&(submitArgs.NodeType), "node-type", "", "Enforce node type affinity by setting a node-type label.")
+	flagSet.StringArrayVar(&(submitArgs.Tolerations), "toleration", []string{}, "Tolerate a node taint, in the format of key[=value][:NoSchedule|PreferNoSchedule|NoExecute].")
+	flagSet.StringArrayVar(&(submitArgs.NodeSelectors), "node-selector", []string{}, "Run only on nodes with a label, in the format of key=value.")
+	flagSet.StringArrayVar(&(submitArgs.NodeAffinity), "node-affinity", []string{}, "Run only on nodes whose labels match the expressions, e.g. 'gpu-type in (a100,v100),!spot'.")
+	flagSet.StringArrayVar(&(submitArgs.PreferredNodeAffinity), "preferred-node-affinity", []string{}, "Prefer nodes whose labels match the expressions, optionally prefixed by a weight between 1 and 100, e.g. '50:pool=spot'.")
 }
 
 func (submitArgs *submitArgs) setCommonRun(cmd *cobra.Command, args []string, kubeClient *client.Client, clientset kubernetes.Interface) error {
 	util.SetLogLevel(global.LogLevel)
+	if err := validateDryRunFlags(); err != nil {
+		return err
+	}
 	assignUser(submitArgs)
 	name, generateSuffix, err := getJobNameWithSuffixGenerationFlag(cmd, args, submitArgs)
 	if err != nil {
@@ -273,14 +302,19 @@ func (submitArgs *submitArgs) setCommonRun(cmd *cobra.Command, args []string, ku
 		return err
 	}
 
-	index, err := getJobIndex(clientset)
+	if err = submitArgs.setSchedulingValues(); err != nil {
+		return err
+	}
 
-	if err != nil {
-		log.Debug("Could not get job index. Will not set a label.")
-	} else {
-		submitArgs.Labels = make(map[string]string)
-		submitArgs.Labels["runai/job-index"] = index
+	if err = submitArgs.setSecretValues(); err != nil {
+		return err
+	}
+	if err = validateSecretReferences(clientset, submitArgs.Namespace, submitArgs.Project, submitArgs.Secrets); err != nil {
+		return err
 	}
+	warnOnLiteralCredentials(submitArgs.EnvironmentVariable)
+
+	submitArgs.setJobIndexLabel(clientset)
 
 	// by default when the user set --attach the --stdin and --tty set to true
 	if raUtil.IsBoolPTrue(submitArgs.Attach) {
@@ -318,6 +352,12 @@ func (submitArgs *submitArgs) setCommonRun(cmd *cobra.Command, args []string, ku
 	if err = submitArgs.GitSync.HandleGitSync(); err != nil {
 		return err
 	}
+	if err = submitArgs.GitSync.setSecretAuth(clientset, submitArgs.Namespace, submitArgs.Project); err != nil {
+		return err
+	}
+	if err = submitArgs.packLocalCode(); err != nil {
+		return err
+	}
 
 	if raUtil.IsBoolPTrue(submitArgs.Interactive) {
 		noBackoffLimit := 0
@@ -354,14 +394,78 @@ func (sa *submitArgs) applyRunAsAuthenticatedUser() (bool, error) {
 
 func assignUser(submitArgs *submitArgs) {
 	if submitArgs.User == "" {
-		if authenticatedUser, err := authentication.GetCurrentAuthenticateUser(); err == nil && authenticatedUser != "" {
-			submitArgs.User = authenticatedUser
-		} else if osUser, err := user.Current(); err == nil {
-			submitArgs.User = osUser.Username
+		if currentUser, err := authentication.GetCurrentUser(); err == nil {
+			submitArgs.User = currentUser
 		}
 	}
 }
 
+// submitJob submits a job of the chart. The git sync credentials of the job are moved to a secret, and its local code
+// is uploaded to a config map, both of which are owned by the job.
+func submitJob(submitArgs *submitArgs, values interface{}, chart string, kubeClient *client.Client) (string, error) {
+	clientset := kubeClient.GetClientset()
+	credentialsSecret, secretCreated, err := submitArgs.GitSync.createCredentialsSecret(clientset, submitArgs.Namespace, submitArgs.Name)
+	if err != nil {
+		return "", err
+	}
+	codeConfigMap, codeUploaded, err := submitArgs.uploadLocalCode(clientset)
+	if err != nil {
+		return "", err
+	}
+	if isDryRun() {
+		return submitArgs.Name, dryRunJob(submitArgs.Name, submitArgs.Namespace, values, chart, kubeClient)
+	}
+
+	jobName, err := workflow.SubmitJob(submitArgs.Name, submitArgs.Namespace, submitArgs.generateSuffix, values, chart, kubeClient)
+	jobNames := []string{}
+	if err == nil {
+		jobNames = append(jobNames, jobName)
+	}
+	if ownerErr := setGitSyncSecretOwners(clientset, submitArgs.Namespace, credentialsSecret, secretCreated, jobNames); ownerErr != nil {
+		log.Warnf("Failed to set the owner of the git sync secret %s: %v", credentialsSecret.Name, ownerErr)
+	}
+	if ownerErr := setLocalCodeOwners(clientset, submitArgs.Namespace, codeConfigMap, codeUploaded, jobNames); ownerErr != nil {
+		log.Warnf("Failed to set the owner of the local code config map %s: %v", codeConfigMap.Name, ownerErr)
+	}
+	return jobName, err
+}
+
+// getJobOwnerReferences returns references to the config maps of the jobs, for objects which should be deleted
+// along with the jobs
+func getJobOwnerReferences(clientset kubernetes.Interface, namespace string, jobNames []string) ([]metav1.OwnerReference, error) {
+	owners := []metav1.OwnerReference{}
+	for _, jobName := range jobNames {
+		configMap, err := clientset.CoreV1().ConfigMaps(namespace).Get(context.TODO(), jobName, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		owners = append(owners, metav1.OwnerReference{
+			APIVersion: "v1",
+			Kind:       "ConfigMap",
+			Name:       configMap.Name,
+			UID:        configMap.UID,
+		})
+	}
+	return owners, nil
+}
+
+// setJobIndexLabel labels the job with the next job index, a dry run should not consume a job index
+func (submitArgs *submitArgs) setJobIndexLabel(clientset kubernetes.Interface) {
+	if isDryRun() {
+		return
+	}
+
+	index, err := getJobIndex(clientset)
+	if err != nil {
+		log.Debug("Could not get job index. Will not set a label.")
+		return
+	}
+	if submitArgs.Labels == nil {
+		submitArgs.Labels = make(map[string]string)
+	}
+	submitArgs.Labels["runai/job-index"] = index
+}
+
 func getJobIndex(clientset kubernetes.Interface) (string, error) {
 	for i := 0; i < getResourceMaxRetries; i++ {
 		index, shouldTryAgain, err := tryGetJobIndexOnce(clientset)
@@ -441,12 +545,16 @@ func mergeOldCommandAndArgsWithNew(argsLenAtDash int, positionalArgs, oldCommand
 	return append(oldCommand, oldArgs...), &isAnyCommand
 }
 
-func getJobNameWithSuffixGenerationFlag(cmd *cobra.Command, args []string, submitArgs *submitArgs) (string, bool, error) {
+func getArgsUntilDash(cmd *cobra.Command, args []string) []string {
 	argsLenUntilDash := cmd.ArgsLenAtDash()
-	argsUntilDash := args
 	if argsLenUntilDash != -1 {
-		argsUntilDash = args[:argsLenUntilDash]
+		return args[:argsLenUntilDash]
 	}
+	return args
+}
+
+func getJobNameWithSuffixGenerationFlag(cmd *cobra.Command, args []string, submitArgs *submitArgs) (string, bool, error) {
+	argsUntilDash := getArgsUntilDash(cmd, args)
 	if submitArgs.NameParameter != "" {
 		if len(argsUntilDash) > 0 {
 			return "", false, fmt.Errorf("unexpected arguments %v", argsUntilDash)
@@ -0,0 +1,56 @@
+package submit
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/run-ai/runai-cli/cmd/job"
+	"github.com/run-ai/runai-cli/pkg/client"
+	"github.com/run-ai/runai-cli/pkg/types"
+	"github.com/spf13/pflag"
+)
+
+var (
+	afterJobs      []string
+	afterCondition string
+
+	afterConditions = []string{job.WaitForSucceeded, job.WaitForCompleted}
+)
+
+func addAfterFlags(fs *pflag.FlagSet) {
+	fs.StringSliceVar(&afterJobs, "after", []string{}, "Submit the job only after the given jobs finish, e.g. --after preprocess1,preprocess2. The command waits until then.")
+	fs.StringVar(&afterCondition, "after-condition", job.WaitForSucceeded, fmt.Sprintf("The state the --after jobs must reach for the job to be submitted: %s. With succeeded, the job is not submitted if one of them fails.", strings.Join(afterConditions, "|")))
+}
+
+func validateAfterFlags() error {
+	for _, condition := range afterConditions {
+		if afterCondition == condition {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid value for --after-condition: %s, supported values are %s", afterCondition, strings.Join(afterConditions, ", "))
+}
+
+// waitForAfterJobs blocks until all the --after jobs reach the --after-condition. It returns an error if one of them
+// reaches a state from which the condition can no longer be met, in which case the job should not be submitted.
+func waitForAfterJobs(kubeClient *client.Client, namespace, project string) error {
+	if err := validateAfterFlags(); err != nil {
+		return err
+	}
+	if isDryRun() {
+		return nil
+	}
+
+	namespaceInfo := types.NamespaceInfo{Namespace: namespace, ProjectName: project}
+	for _, name := range afterJobs {
+		fmt.Printf("Waiting for job %s to be %s\n", name, afterCondition)
+		status, met, err := job.WaitForJob(kubeClient, name, namespaceInfo, afterCondition, 0)
+		if err != nil {
+			return err
+		}
+		if !met {
+			return fmt.Errorf("job %s is %s and will not be %s, the job was not submitted", name, status, afterCondition)
+		}
+	}
+	return nil
+}
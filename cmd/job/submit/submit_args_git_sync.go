@@ -1,17 +1,41 @@
 package submit
 
 import (
+	"context"
 	"fmt"
-	raUtil "github.com/run-ai/runai-cli/cmd/util"
+	"io/ioutil"
+	"regexp"
 	"strings"
+
+	raUtil "github.com/run-ai/runai-cli/cmd/util"
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
 )
 
 const (
 	defaultGitSyncImage  = "k8s.gcr.io/git-sync/git-sync:v3.2.0"
 	defaultSyncDirectory = "/code"
 	defaultBranch        = "master"
+	// defaultTokenUsername is the username of token authentication, which is ignored by most git providers
+	defaultTokenUsername = "oauth2"
+
+	gitSyncPasswordAuth = "password"
+	gitSyncSSHAuth      = "ssh"
+
+	// the keys of the git-sync credentials secret, as expected by git-sync
+	gitSyncUsernameKey   = "username"
+	gitSyncPasswordKey   = "password"
+	gitSyncSSHKey        = "ssh"
+	gitSyncKnownHostsKey = "known_hosts"
+
+	GitSyncSecretLabel = "runai/git-sync"
 )
 
+var gitSyncSecret string
+
 type GitSync struct {
 	Sync           *bool  `yaml:"sync,omitempty"`
 	Image          string `yaml:"image,omitempty"`
@@ -21,6 +45,12 @@ type GitSync struct {
 	Revision       string `yaml:"revision,omitempty"`
 	Username       string `yaml:"username,omitempty"`
 	Password       string `yaml:"password,omitempty"`
+	Token          string `yaml:"token,omitempty"`
+	SSHKeyFile     string `yaml:"sshKeyFile,omitempty"`
+	KnownHostsFile string `yaml:"knownHostsFile,omitempty"`
+	Secret         string `yaml:"secret,omitempty"`
+	Auth           string `yaml:"auth,omitempty"`
+	KnownHosts     bool   `yaml:"knownHosts,omitempty"`
 	UseCredentials bool   `yaml:"useCredentials,omitempty"`
 	Directory      string `yaml:"directory,omitempty"`
 }
@@ -41,6 +71,9 @@ func NewGitSync() *GitSync {
 
 func (gs *GitSync) HandleGitSync() error {
 	if !raUtil.IsBoolPTrue(gs.Sync) {
+		if gs.Secret != "" {
+			return fmt.Errorf("--git-sync-secret requires a repository to sync, set by --git-sync")
+		}
 		return nil
 	}
 
@@ -49,9 +82,6 @@ func (gs *GitSync) HandleGitSync() error {
 	} else if gs.Branch == "" {
 		gs.Branch = defaultBranch
 	}
-	if gs.Username != "" || gs.Password != "" {
-		gs.UseCredentials = true
-	}
 	if gs.Image == "" {
 		gs.Image = defaultGitSyncImage
 	}
@@ -62,9 +92,235 @@ func (gs *GitSync) HandleGitSync() error {
 	if gs.Repository == "" {
 		return fmt.Errorf("git sync must contain Repository")
 	}
+	return gs.setInlineAuth()
+}
+
+// setInlineAuth sets the authentication mode of credentials which are set on the command line or in a template
+func (gs *GitSync) setInlineAuth() error {
+	modes := []string{}
+	if gs.Password != "" {
+		modes = append(modes, "password")
+	}
+	if gs.Token != "" {
+		modes = append(modes, "token")
+	}
+	if gs.SSHKeyFile != "" {
+		modes = append(modes, "ssh-key")
+	}
+	if gs.Secret != "" {
+		modes = append(modes, "secret")
+	}
+	if len(modes) > 1 {
+		return fmt.Errorf("git sync credentials can be set by only one of password, token, ssh-key or secret, got: %s", strings.Join(modes, ", "))
+	}
+
+	switch {
+	case gs.Password != "" || gs.Token != "":
+		gs.Auth = gitSyncPasswordAuth
+	case gs.SSHKeyFile != "":
+		gs.Auth = gitSyncSSHAuth
+		gs.KnownHosts = gs.KnownHostsFile != ""
+	case gs.Username != "" && gs.Secret == "":
+		return fmt.Errorf("git sync username requires a password or a token")
+	}
+	gs.UseCredentials = gs.Auth != ""
 	return nil
 }
 
+// hasInlineCredentials returns whether the credentials are set in the git sync itself, rather than in a secret
+func (gs *GitSync) hasInlineCredentials() bool {
+	return gs.Password != "" || gs.Token != "" || gs.SSHKeyFile != ""
+}
+
+// setSecretAuth sets the authentication mode of an existing credentials secret by the keys it has
+func (gs *GitSync) setSecretAuth(clientset kubernetes.Interface, namespace, project string) error {
+	if gs == nil || !raUtil.IsBoolPTrue(gs.Sync) || gs.Secret == "" || gs.hasInlineCredentials() {
+		return nil
+	}
+
+	secret, err := clientset.CoreV1().Secrets(namespace).Get(context.TODO(), gs.Secret, metav1.GetOptions{})
+	if errors.IsForbidden(err) {
+		log.Debugf("Not allowed to read the git sync secret %s, assuming it holds a username and a password: %v", gs.Secret, err)
+		gs.Auth = gitSyncPasswordAuth
+		gs.UseCredentials = true
+		return nil
+	}
+	if errors.IsNotFound(err) {
+		return fmt.Errorf("the git sync secret %s does not exist in project %s", gs.Secret, project)
+	}
+	if err != nil {
+		return err
+	}
+
+	_, hasSSHKey := secret.Data[gitSyncSSHKey]
+	_, hasPassword := secret.Data[gitSyncPasswordKey]
+	switch {
+	case hasSSHKey:
+		gs.Auth = gitSyncSSHAuth
+		_, gs.KnownHosts = secret.Data[gitSyncKnownHostsKey]
+	case hasPassword:
+		if _, hasUsername := secret.Data[gitSyncUsernameKey]; !hasUsername {
+			return fmt.Errorf("the git sync secret %s has a key %s but no key %s", gs.Secret, gitSyncPasswordKey, gitSyncUsernameKey)
+		}
+		gs.Auth = gitSyncPasswordAuth
+	default:
+		return fmt.Errorf("the git sync secret %s should have the keys %s and %s, or a key %s with a private ssh key", gs.Secret, gitSyncUsernameKey, gitSyncPasswordKey, gitSyncSSHKey)
+	}
+	gs.UseCredentials = true
+	return nil
+}
+
+// newCredentialsSecret returns a secret holding the credentials which are set in the git sync
+func (gs *GitSync) newCredentialsSecret(jobName string) (*corev1.Secret, error) {
+	data := map[string][]byte{}
+	switch {
+	case gs.SSHKeyFile != "":
+		sshKey, err := ioutil.ReadFile(gs.SSHKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("could not read the git sync ssh key: %v", err)
+		}
+		data[gitSyncSSHKey] = sshKey
+		if gs.KnownHostsFile != "" {
+			knownHosts, err := ioutil.ReadFile(gs.KnownHostsFile)
+			if err != nil {
+				return nil, fmt.Errorf("could not read the git sync known hosts: %v", err)
+			}
+			data[gitSyncKnownHostsKey] = knownHosts
+		}
+	case gs.Token != "":
+		username := gs.Username
+		if username == "" {
+			username = defaultTokenUsername
+		}
+		data[gitSyncUsernameKey] = []byte(username)
+		data[gitSyncPasswordKey] = []byte(gs.Token)
+	default:
+		data[gitSyncUsernameKey] = []byte(gs.Username)
+		data[gitSyncPasswordKey] = []byte(gs.Password)
+	}
+
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: fmt.Sprintf("%s-git-sync-", jobName),
+			Labels:       map[string]string{GitSyncSecretLabel: jobName},
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: data,
+	}, nil
+}
+
+// clearInlineCredentials removes the credentials from the git sync, so they are not kept in the values of the job
+func (gs *GitSync) clearInlineCredentials() {
+	gs.Username = ""
+	gs.Password = ""
+	gs.Token = ""
+	gs.SSHKeyFile = ""
+	gs.KnownHostsFile = ""
+}
+
+// createCredentialsSecret moves the credentials which are set in the git sync to a new secret, which the git-sync
+// container reads them from. When the git sync uses a secret which has been created this way for another job, e.g.
+// when a job is submitted again, that secret is returned instead, so the new job is added to its owners. It returns
+// the secret and whether it has been created. In a dry run the secret is not created, but the credentials are still
+// removed from the values.
+func (gs *GitSync) createCredentialsSecret(clientset kubernetes.Interface, namespace, jobName string) (*corev1.Secret, bool, error) {
+	if gs == nil || !raUtil.IsBoolPTrue(gs.Sync) {
+		return nil, false, nil
+	}
+	if !gs.hasInlineCredentials() {
+		return gs.getCreatedCredentialsSecret(clientset, namespace), false, nil
+	}
+
+	secret, err := gs.newCredentialsSecret(jobName)
+	if err != nil {
+		return nil, false, err
+	}
+	gs.clearInlineCredentials()
+
+	if isDryRun() {
+		gs.Secret = secret.GenerateName + "dry-run"
+		return nil, false, nil
+	}
+
+	secret, err = clientset.CoreV1().Secrets(namespace).Create(context.TODO(), secret, metav1.CreateOptions{})
+	if err != nil {
+		return nil, false, fmt.Errorf("could not create the git sync secret: %v", err)
+	}
+	gs.Secret = secret.Name
+	return secret, true, nil
+}
+
+// getCreatedCredentialsSecret returns the secret of the git sync when it has been created for the credentials of
+// another job, or nil when the secret is one of the user
+func (gs *GitSync) getCreatedCredentialsSecret(clientset kubernetes.Interface, namespace string) *corev1.Secret {
+	if gs.Secret == "" || isDryRun() {
+		return nil
+	}
+	secret, err := clientset.CoreV1().Secrets(namespace).Get(context.TODO(), gs.Secret, metav1.GetOptions{})
+	if err != nil {
+		log.Debugf("Could not get the git sync secret %s: %v", gs.Secret, err)
+		return nil
+	}
+	if _, found := secret.Labels[GitSyncSecretLabel]; !found {
+		return nil
+	}
+	return secret
+}
+
+// setGitSyncSecretOwners adds the config maps of the jobs to the owners of the git sync secret, so it is deleted
+// along with the last job which uses it. A secret which has been created for jobs which were not submitted is deleted.
+func setGitSyncSecretOwners(clientset kubernetes.Interface, namespace string, secret *corev1.Secret, created bool, jobNames []string) error {
+	if secret == nil {
+		return nil
+	}
+	if len(jobNames) == 0 {
+		if !created {
+			return nil
+		}
+		return clientset.CoreV1().Secrets(namespace).Delete(context.TODO(), secret.Name, metav1.DeleteOptions{})
+	}
+
+	owners, err := getJobOwnerReferences(clientset, namespace, jobNames)
+	if err != nil {
+		return err
+	}
+	secret.OwnerReferences = append(secret.OwnerReferences, owners...)
+	_, err = clientset.CoreV1().Secrets(namespace).Update(context.TODO(), secret, metav1.UpdateOptions{})
+	return err
+}
+
+// cleanCalculatedValues removes the values which are calculated during the submission
+func (gs *GitSync) cleanCalculatedValues() {
+	gs.Auth = ""
+	gs.KnownHosts = false
+	gs.UseCredentials = false
+}
+
+var gitSyncCredentialsPattern = regexp.MustCompile(`(^|,)(password|token)=[^,]*`)
+
+// redactGitSyncCredentials hides the credentials of --git-sync in the command line, as it is kept in the job
+func redactGitSyncCredentials(args []string) []string {
+	redacted := make([]string, len(args))
+	for i, arg := range args {
+		redacted[i] = gitSyncCredentialsPattern.ReplaceAllString(arg, "${1}${2}=***")
+	}
+	return redacted
+}
+
+// applyGitSyncFlags sets the git sync of the job by the --git-sync and --git-sync-secret flags, which override the
+// git sync of a job spec file
+func applyGitSyncFlags(submitArgs *submitArgs) {
+	if gitSyncConnectionString != "" {
+		submitArgs.GitSync = GitSyncFromConnectionString(gitSyncConnectionString)
+	}
+	if gitSyncSecret != "" {
+		if submitArgs.GitSync == nil {
+			submitArgs.GitSync = NewGitSync()
+		}
+		submitArgs.GitSync.Secret = gitSyncSecret
+	}
+}
+
 func GitSyncFromConnectionString(connectionString string) *GitSync {
 	parameters := strings.Split(connectionString, ",")
 
@@ -103,6 +359,14 @@ func ParseGitSyncArguments(arguments map[string]string) *GitSync {
 			syncObject.Username = value
 		case "password":
 			syncObject.Password = value
+		case "token":
+			syncObject.Token = value
+		case "ssh-key":
+			syncObject.SSHKeyFile = value
+		case "known-hosts":
+			syncObject.KnownHostsFile = value
+		case "secret":
+			syncObject.Secret = value
 		case "target":
 			syncObject.Directory = value
 		}
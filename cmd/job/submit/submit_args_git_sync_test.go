@@ -1,8 +1,14 @@
 package submit
 
 import (
-	"gotest.tools/assert"
+	"context"
 	"testing"
+
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
 )
 
 func TestGitSyncConnectionStringFullCS(t *testing.T) {
@@ -37,3 +43,126 @@ func TestGitSyncConnectionStringContainParentheses(t *testing.T) {
 	assert.Equal(t, gitSync.Branch, "branch-name")
 	assert.Equal(t, gitSync.Directory, "path/to/sync/to")
 }
+
+func TestGitSyncConnectionStringCredentials(t *testing.T) {
+	gitSync := GitSyncFromConnectionString("source=git@github.com:run-ai/runai-cli.git,ssh-key=/home/john/.ssh/id_rsa,known-hosts=/home/john/.ssh/known_hosts")
+	assert.Equal(t, gitSync.SSHKeyFile, "/home/john/.ssh/id_rsa")
+	assert.Equal(t, gitSync.KnownHostsFile, "/home/john/.ssh/known_hosts")
+
+	gitSync = GitSyncFromConnectionString("source=repo-url,token=ghp_token")
+	assert.Equal(t, gitSync.Token, "ghp_token")
+
+	gitSync = GitSyncFromConnectionString("source=repo-url,secret=github")
+	assert.Equal(t, gitSync.Secret, "github")
+}
+
+func TestHandleGitSyncAuth(t *testing.T) {
+	gitSync := GitSyncFromConnectionString("source=repo-url,token=ghp_token")
+	assert.NilError(t, gitSync.HandleGitSync())
+	assert.Equal(t, gitSync.Auth, gitSyncPasswordAuth)
+	assert.Equal(t, gitSync.UseCredentials, true)
+
+	gitSync = GitSyncFromConnectionString("source=repo-url,ssh-key=id_rsa")
+	assert.NilError(t, gitSync.HandleGitSync())
+	assert.Equal(t, gitSync.Auth, gitSyncSSHAuth)
+	assert.Equal(t, gitSync.KnownHosts, false)
+
+	gitSync = GitSyncFromConnectionString("source=repo-url")
+	assert.NilError(t, gitSync.HandleGitSync())
+	assert.Equal(t, gitSync.Auth, "")
+	assert.Equal(t, gitSync.UseCredentials, false)
+
+	for _, connectionString := range []string{"source=repo-url,password=asd,token=ghp_token", "source=repo-url,token=ghp_token,secret=github", "source=repo-url,username=john"} {
+		assert.ErrorContains(t, GitSyncFromConnectionString(connectionString).HandleGitSync(), "", connectionString)
+	}
+
+	gitSync = NewGitSync()
+	gitSync.Secret = "github"
+	assert.ErrorContains(t, gitSync.HandleGitSync(), "requires a repository")
+}
+
+func TestSetGitSyncSecretAuth(t *testing.T) {
+	clientset := fake.NewSimpleClientset(
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "github", Namespace: "runai-team-a"}, Data: map[string][]byte{"username": []byte("john"), "password": []byte("asd")}},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "deploy-key", Namespace: "runai-team-a"}, Data: map[string][]byte{"ssh": []byte("key"), "known_hosts": []byte("hosts")}},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "wandb", Namespace: "runai-team-a"}, Data: map[string][]byte{"api-key": []byte("key")}},
+	)
+
+	gitSync := GitSyncFromConnectionString("source=repo-url,secret=github")
+	assert.NilError(t, gitSync.setSecretAuth(clientset, "runai-team-a", "team-a"))
+	assert.Equal(t, gitSync.Auth, gitSyncPasswordAuth)
+
+	gitSync = GitSyncFromConnectionString("source=repo-url,secret=deploy-key")
+	assert.NilError(t, gitSync.setSecretAuth(clientset, "runai-team-a", "team-a"))
+	assert.Equal(t, gitSync.Auth, gitSyncSSHAuth)
+	assert.Equal(t, gitSync.KnownHosts, true)
+
+	gitSync = GitSyncFromConnectionString("source=repo-url,secret=wandb")
+	assert.ErrorContains(t, gitSync.setSecretAuth(clientset, "runai-team-a", "team-a"), "should have the keys username and password")
+
+	gitSync = GitSyncFromConnectionString("source=repo-url,secret=gitlab")
+	assert.ErrorContains(t, gitSync.setSecretAuth(clientset, "runai-team-a", "team-a"), "does not exist in project team-a")
+}
+
+func TestNewGitSyncCredentialsSecret(t *testing.T) {
+	gitSync := GitSyncFromConnectionString("source=repo-url,token=ghp_token")
+	secret, err := gitSync.newCredentialsSecret("train1")
+	assert.NilError(t, err)
+	assert.Equal(t, secret.GenerateName, "train1-git-sync-")
+	assert.Equal(t, string(secret.Data["username"]), defaultTokenUsername)
+	assert.Equal(t, string(secret.Data["password"]), "ghp_token")
+
+	gitSync.clearInlineCredentials()
+	assert.Equal(t, gitSync.hasInlineCredentials(), false)
+
+	gitSync = GitSyncFromConnectionString("source=repo-url,ssh-key=/does/not/exist")
+	_, err = gitSync.newCredentialsSecret("train1")
+	assert.ErrorContains(t, err, "could not read the git sync ssh key")
+}
+
+func TestSetGitSyncSecretOwners(t *testing.T) {
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "train1-git-sync-x7k2p", Namespace: "runai-team-a"}}
+	clientset := fake.NewSimpleClientset(secret, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "train1", Namespace: "runai-team-a", UID: "uid-1"}})
+
+	assert.NilError(t, setGitSyncSecretOwners(clientset, "runai-team-a", secret, true, []string{"train1"}))
+	updated, err := clientset.CoreV1().Secrets("runai-team-a").Get(context.TODO(), secret.Name, metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, len(updated.OwnerReferences), 1)
+	assert.Equal(t, updated.OwnerReferences[0].Kind, "ConfigMap")
+	assert.Equal(t, string(updated.OwnerReferences[0].UID), "uid-1")
+
+	// a secret of other jobs is kept when no job has been submitted
+	assert.NilError(t, setGitSyncSecretOwners(clientset, "runai-team-a", secret, false, []string{}))
+	_, err = clientset.CoreV1().Secrets("runai-team-a").Get(context.TODO(), secret.Name, metav1.GetOptions{})
+	assert.NilError(t, err)
+
+	// the secret is deleted when no job has been submitted
+	assert.NilError(t, setGitSyncSecretOwners(clientset, "runai-team-a", secret, true, []string{}))
+	_, err = clientset.CoreV1().Secrets("runai-team-a").Get(context.TODO(), secret.Name, metav1.GetOptions{})
+	assert.Assert(t, errors.IsNotFound(err))
+}
+
+func TestCreateCredentialsSecretOfSubmittedJob(t *testing.T) {
+	clientset := fake.NewSimpleClientset(
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "train1-git-sync-x7k2p", Namespace: "runai-team-a", Labels: map[string]string{GitSyncSecretLabel: "train1"}}},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "github", Namespace: "runai-team-a"}},
+	)
+
+	// a job which is submitted again uses the secret which has been created for the credentials of the original job
+	gitSync := GitSyncFromConnectionString("source=repo-url,secret=train1-git-sync-x7k2p")
+	secret, created, err := gitSync.createCredentialsSecret(clientset, "runai-team-a", "train2")
+	assert.NilError(t, err)
+	assert.Equal(t, created, false)
+	assert.Equal(t, secret.Name, "train1-git-sync-x7k2p")
+
+	gitSync = GitSyncFromConnectionString("source=repo-url,secret=github")
+	secret, created, err = gitSync.createCredentialsSecret(clientset, "runai-team-a", "train2")
+	assert.NilError(t, err)
+	assert.Equal(t, created, false)
+	assert.Assert(t, secret == nil)
+}
+
+func TestRedactGitSyncCredentials(t *testing.T) {
+	args := redactGitSyncCredentials([]string{"runai", "submit", "--git-sync", "source=repo-url,username=john,password=asd", "--git-sync=source=repo-url,token=ghp_token", "-e", "LR=0.1"})
+	assert.DeepEqual(t, args, []string{"runai", "submit", "--git-sync", "source=repo-url,username=john,password=***", "--git-sync=source=repo-url,token=***", "-e", "LR=0.1"})
+}
@@ -0,0 +1,96 @@
+package submit
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/run-ai/runai-cli/pkg/client"
+	"github.com/run-ai/runai-cli/pkg/workflow"
+	"github.com/spf13/pflag"
+	yaml "gopkg.in/yaml.v2"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	dryRunFlag       = "dry-run"
+	yamlOutputFormat = "yaml"
+	jsonOutputFormat = "json"
+)
+
+var (
+	dryRun       string
+	dryRunOutput string
+)
+
+func addDryRunFlags(flagSet *pflag.FlagSet) {
+	flagSet.StringVar(&dryRun, dryRunFlag, "", "Print the job objects instead of submitting the job. One of: client|server. With server, the objects are also validated by the cluster without being created.")
+	flagSet.Lookup(dryRunFlag).NoOptDefVal = string(workflow.DryRunClient)
+	flagSet.StringVarP(&dryRunOutput, "output", "o", yamlOutputFormat, "Output format of the job objects printed by --dry-run. One of: yaml|json")
+}
+
+func isDryRun() bool {
+	return dryRun != string(workflow.DryRunNone)
+}
+
+func validateDryRunFlags() error {
+	if _, err := workflow.ParseDryRunStrategy(dryRun); err != nil {
+		return err
+	}
+
+	switch dryRunOutput {
+	case yamlOutputFormat, jsonOutputFormat:
+		return nil
+	default:
+		return fmt.Errorf("unknown output format: %s. One of: %s|%s", dryRunOutput, yamlOutputFormat, jsonOutputFormat)
+	}
+}
+
+func dryRunJob(name, namespace string, values interface{}, chart string, kubeClient *client.Client) error {
+	strategy, err := workflow.ParseDryRunStrategy(dryRun)
+	if err != nil {
+		return err
+	}
+
+	objects, err := workflow.DryRunJob(name, namespace, values, chart, kubeClient, strategy)
+	if err != nil {
+		return err
+	}
+
+	return printDryRunObjects(os.Stdout, objects, dryRunOutput)
+}
+
+func printDryRunObjects(w io.Writer, objects []*unstructured.Unstructured, output string) error {
+	switch output {
+	case yamlOutputFormat:
+		documents := []string{}
+		for _, object := range objects {
+			outBytes, err := yaml.Marshal(object.Object)
+			if err != nil {
+				return err
+			}
+			documents = append(documents, string(outBytes))
+		}
+		fmt.Fprint(w, strings.Join(documents, "---\n"))
+	case jsonOutputFormat:
+		items := []interface{}{}
+		for _, object := range objects {
+			items = append(items, object.Object)
+		}
+		list := map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "List",
+			"items":      items,
+		}
+		outBytes, err := json.MarshalIndent(list, "", "    ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(w, string(outBytes))
+	default:
+		return fmt.Errorf("unknown output format: %s", output)
+	}
+	return nil
+}
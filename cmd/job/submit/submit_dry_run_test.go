@@ -0,0 +1,110 @@
+package submit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/run-ai/runai-cli/pkg/workflow"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+const runaiChartPath = "../../../charts/runai"
+
+func TestValidateDryRunFlags(t *testing.T) {
+	tests := []struct {
+		name        string
+		dryRun      string
+		output      string
+		expectError bool
+	}{
+		{name: "no dry run", dryRun: "", output: yamlOutputFormat, expectError: false},
+		{name: "client dry run", dryRun: "client", output: yamlOutputFormat, expectError: false},
+		{name: "server dry run as json", dryRun: "server", output: jsonOutputFormat, expectError: false},
+		{name: "unknown dry run strategy", dryRun: "cluster", output: yamlOutputFormat, expectError: true},
+		{name: "unknown output format", dryRun: "client", output: "wide", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dryRun = tt.dryRun
+			dryRunOutput = tt.output
+			defer func() {
+				dryRun = ""
+				dryRunOutput = yamlOutputFormat
+			}()
+
+			err := validateDryRunFlags()
+			if (err != nil) != tt.expectError {
+				t.Errorf("unexpected validation result for dry-run=%s output=%s: %v", tt.dryRun, tt.output, err)
+			}
+		})
+	}
+}
+
+func TestPrintClientDryRunObjects(t *testing.T) {
+	values := map[string]interface{}{
+		"image":   "gcr.io/run-ai-demo/quickstart",
+		"labels":  map[string]string{"team": "vision"},
+		"gitSync": map[string]interface{}{"sync": false},
+	}
+	// a client dry run only renders the chart, so it does not use the cluster
+	objects, err := workflow.DryRunJob("train1", "runai-team-a", values, runaiChartPath, nil, workflow.DryRunClient)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var yamlOutput bytes.Buffer
+	if err = printDryRunObjects(&yamlOutput, objects, yamlOutputFormat); err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []string{"kind: Job", "name: train1", "team: vision", "image: gcr.io/run-ai-demo/quickstart"} {
+		if !strings.Contains(yamlOutput.String(), expected) {
+			t.Errorf("expected the yaml output to contain '%s', got:\n%s", expected, yamlOutput.String())
+		}
+	}
+
+	var jsonOutput bytes.Buffer
+	if err = printDryRunObjects(&jsonOutput, objects, jsonOutputFormat); err != nil {
+		t.Fatal(err)
+	}
+	list := map[string]interface{}{}
+	if err = json.Unmarshal(jsonOutput.Bytes(), &list); err != nil {
+		t.Fatal(err)
+	}
+	if list["kind"] != "List" || len(list["items"].([]interface{})) != len(objects) {
+		t.Errorf("expected the json output to be a list of the %d objects, got:\n%s", len(objects), jsonOutput.String())
+	}
+}
+
+func TestDryRunDoesNotConsumeJobIndex(t *testing.T) {
+	clientset := fake.NewSimpleClientset(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "runai-cli-index", Namespace: runaiNamespace},
+		Data:       map[string]string{"index": "7"},
+	})
+	getIndex := func() string {
+		configMap, err := clientset.CoreV1().ConfigMaps(runaiNamespace).Get(context.TODO(), "runai-cli-index", metav1.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return configMap.Data["index"]
+	}
+	defer func() { dryRun = "" }()
+
+	dryRun = string(workflow.DryRunClient)
+	args := &submitArgs{}
+	args.setJobIndexLabel(clientset)
+	if _, found := args.Labels["runai/job-index"]; found || getIndex() != "7" {
+		t.Errorf("expected a dry run not to consume a job index, got label %v and index %s", args.Labels, getIndex())
+	}
+
+	dryRun = ""
+	args.setJobIndexLabel(clientset)
+	if args.Labels["runai/job-index"] != "8" || getIndex() != "8" {
+		t.Errorf("expected the job to be labeled with the next job index, got label %v and index %s", args.Labels, getIndex())
+	}
+}
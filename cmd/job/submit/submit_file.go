@@ -0,0 +1,218 @@
+package submit
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/run-ai/runai-cli/cmd/flags"
+	"github.com/run-ai/runai-cli/pkg/client"
+	"github.com/run-ai/runai-cli/pkg/config"
+	"github.com/run-ai/runai-cli/pkg/workflow"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	yaml "gopkg.in/yaml.v2"
+)
+
+const (
+	ldapUidEnvVarPrefix = "LDAP_UID="
+	ldapGidEnvVarPrefix = "LDAP_GID="
+	gpuMemoryUnit       = "M"
+)
+
+var (
+	jobSpecFile   string
+	exportJobName string
+	fromJobName   string
+
+	// the submit command of the jobs of each chart, by the chart name kept in the job config map
+	chartSubmitCommands = map[string]string{
+		"runai":  submitCommand,
+		"mpijob": SubmitMpiCommand,
+	}
+)
+
+// loadJobSpecFile fills the submit args from a yaml or json job spec file.
+// Flags which were explicitly set on the command line take precedence over the values in the file.
+func loadJobSpecFile(cmd *cobra.Command, args []string, fileName string, spec interface{}) error {
+	content, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return err
+	}
+
+	return loadJobSpec(cmd, args, spec, func() error {
+		if err := yaml.UnmarshalStrict(content, spec); err != nil {
+			return fmt.Errorf("could not parse the job spec file %s: %v", fileName, err)
+		}
+		return nil
+	})
+}
+
+// loadJobSpecFromJob fills the submit args from the configuration an existing job was submitted with, so the job is
+// submitted again with a new name, generated from the name of the existing job.
+// Flags which were explicitly set on the command line take precedence over the values of the existing job.
+func loadJobSpecFromJob(cmd *cobra.Command, args []string, kubeClient *client.Client, jobName, chartName string, spec interface{}) error {
+	namespaceInfo, err := flags.GetNamespaceToUseFromProjectFlag(cmd, kubeClient)
+	if err != nil {
+		return err
+	}
+
+	jobConfig, err := workflow.GetJobConfig(jobName, namespaceInfo.Namespace, kubeClient.GetClientset())
+	if err != nil {
+		return err
+	}
+	return loadJobSpecFromConfig(cmd, args, jobName, jobConfig, chartName, spec)
+}
+
+func loadJobSpecFromConfig(cmd *cobra.Command, args []string, jobName string, jobConfig *workflow.JobConfig, chartName string, spec interface{}) error {
+	if jobConfig.ChartName != "" && jobConfig.ChartName != chartName {
+		if submitCommandName, found := chartSubmitCommands[jobConfig.ChartName]; found {
+			return fmt.Errorf("job %s can't be submitted again by this command, use '%s %s --from %s' instead", jobName, config.CLIName, submitCommandName, jobName)
+		}
+		return fmt.Errorf("job %s was submitted with the %s chart and can't be submitted again by this command", jobName, jobConfig.ChartName)
+	}
+
+	return loadJobSpec(cmd, args, spec, func() error {
+		if err := yaml.Unmarshal([]byte(jobConfig.Values), spec); err != nil {
+			return fmt.Errorf("could not parse the configuration of job %s: %v", jobName, err)
+		}
+		cleanJobSpec(spec)
+
+		commonArgs := getCommonSubmitArgs(spec)
+		commonArgs.Name = ""
+		commonArgs.NamePrefix = jobConfig.BaseName
+		return nil
+	})
+}
+
+// loadJobSpec fills the submit args using load, and then sets again the flags which were explicitly set on the
+// command line, so they take precedence over the loaded values
+func loadJobSpec(cmd *cobra.Command, args []string, spec interface{}, load func() error) error {
+	changedFlags := getChangedFlagsValues(cmd)
+	if err := load(); err != nil {
+		return err
+	}
+	if err := setFlagsValues(cmd, changedFlags); err != nil {
+		return err
+	}
+
+	commonArgs := getCommonSubmitArgs(spec)
+	if commonArgs.NameParameter == "" && len(getArgsUntilDash(cmd, args)) == 0 {
+		commonArgs.NameParameter = commonArgs.Name
+	}
+	commonArgs.Name = ""
+	commonArgs.addCliCommand()
+	return nil
+}
+
+// exportJobSpec prints the spec an existing job was submitted with, in a format which can be submitted again using --file
+func exportJobSpec(cmd *cobra.Command, kubeClient *client.Client, jobName string, spec interface{}) error {
+	namespaceInfo, err := flags.GetNamespaceToUseFromProjectFlag(cmd, kubeClient)
+	if err != nil {
+		return err
+	}
+
+	values, err := workflow.GetJobValues(jobName, namespaceInfo.Namespace, kubeClient.GetClientset())
+	if err != nil {
+		return err
+	}
+
+	if err = yaml.Unmarshal([]byte(values), spec); err != nil {
+		return fmt.Errorf("could not parse the configuration of job %s: %v", jobName, err)
+	}
+	cleanJobSpec(spec)
+
+	content, err := yaml.Marshal(spec)
+	if err != nil {
+		return err
+	}
+	fmt.Print(string(content))
+	return nil
+}
+
+func getCommonSubmitArgs(spec interface{}) *submitArgs {
+	switch spec.(type) {
+	case *submitRunaiJobArgs:
+		return &spec.(*submitRunaiJobArgs).submitArgs
+	case *submitMPIJobArgs:
+		return &spec.(*submitMPIJobArgs).submitArgs
+	}
+	return nil
+}
+
+// cleanJobSpec removes the values which are calculated during the submission, so the spec can be submitted again
+func cleanJobSpec(spec interface{}) {
+	getCommonSubmitArgs(spec).cleanCalculatedValues()
+
+	switch spec.(type) {
+	case *submitRunaiJobArgs:
+		runaiJobArgs := spec.(*submitRunaiJobArgs)
+		runaiJobArgs.IsRunaiJob = nil
+		runaiJobArgs.TTL = nil
+	case *submitMPIJobArgs:
+		mpiJobArgs := spec.(*submitMPIJobArgs)
+		mpiJobArgs.NumberProcesses = 0
+		mpiJobArgs.TotalGPUs = 0
+		mpiJobArgs.TotalGPUsMemory = 0
+	}
+}
+
+func (sa *submitArgs) cleanCalculatedValues() {
+	sa.NameParameter = ""
+	sa.Namespace = ""
+	sa.User = ""
+	sa.CliCommand = ""
+	// the job index is set again when the job is submitted
+	delete(sa.Labels, "runai/job-index")
+	sa.GPUInt = nil
+	sa.GPUFraction = ""
+	sa.MigDevice = strings.TrimPrefix(sa.MigDevice, migDeviceResourcePrefix)
+	if sa.GPUMemory != "" {
+		// handleRequestedGPUs keeps the gpu memory in MB without a unit
+		sa.GPUMemory = sa.GPUMemory + gpuMemoryUnit
+	}
+	sa.RunAsUser = ""
+	sa.RunAsGroup = ""
+	sa.SupplementalGroups = nil
+	sa.Scheduling = nil
+	sa.Secrets = nil
+	sa.LocalCode = nil
+	if sa.GitSync != nil {
+		sa.GitSync.cleanCalculatedValues()
+	}
+
+	environmentVariables := []string{}
+	for _, environmentVariable := range sa.EnvironmentVariable {
+		if strings.HasPrefix(environmentVariable, ldapUidEnvVarPrefix) || strings.HasPrefix(environmentVariable, ldapGidEnvVarPrefix) {
+			continue
+		}
+		environmentVariables = append(environmentVariables, environmentVariable)
+	}
+	sa.EnvironmentVariable = environmentVariables
+}
+
+func getChangedFlagsValues(cmd *cobra.Command) map[string][]string {
+	values := make(map[string][]string)
+	cmd.Flags().Visit(func(flag *pflag.Flag) {
+		if sliceValue, ok := flag.Value.(pflag.SliceValue); ok {
+			values[flag.Name] = sliceValue.GetSlice()
+		} else {
+			values[flag.Name] = []string{flag.Value.String()}
+		}
+	})
+	return values
+}
+
+func setFlagsValues(cmd *cobra.Command, values map[string][]string) error {
+	for name, value := range values {
+		flag := cmd.Flags().Lookup(name)
+		if sliceValue, ok := flag.Value.(pflag.SliceValue); ok {
+			if err := sliceValue.Replace(value); err != nil {
+				return err
+			}
+		} else if err := flag.Value.Set(value[0]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
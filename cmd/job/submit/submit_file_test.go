@@ -0,0 +1,172 @@
+package submit
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/magiconair/properties/assert"
+	"github.com/run-ai/runai-cli/cmd/flags"
+	"github.com/run-ai/runai-cli/pkg/workflow"
+	"github.com/spf13/cobra"
+)
+
+const jobSpecYaml = `
+name: train1
+image: gcr.io/run-ai-demo/quickstart
+gpu: 1
+imagePullPolicy: IfNotPresent
+environment:
+- EPOCHS=10
+`
+
+func newJobSpecTestCommand(submitArgs *submitRunaiJobArgs) *cobra.Command {
+	command := &cobra.Command{Use: submitCommand}
+	fbg := flags.NewFlagsByGroups(command)
+	submitArgs.addCommonSubmit(fbg)
+	submitArgs.addFlags(fbg)
+	fbg.UpdateFlagsByGroupsToCmd()
+	return command
+}
+
+func writeJobSpecFile(t *testing.T, content string) string {
+	file, err := ioutil.TempFile("", "job-spec")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	if _, err = file.WriteString(content); err != nil {
+		t.Fatal(err)
+	}
+	return file.Name()
+}
+
+func TestLoadJobSpecFile(t *testing.T) {
+	fileName := writeJobSpecFile(t, jobSpecYaml)
+	defer os.Remove(fileName)
+
+	submitArgs := NewSubmitRunaiJobArgs()
+	command := newJobSpecTestCommand(submitArgs)
+	if err := command.ParseFlags([]string{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := loadJobSpecFile(command, []string{}, fileName, submitArgs); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, submitArgs.NameParameter, "train1")
+	assert.Equal(t, submitArgs.Image, "gcr.io/run-ai-demo/quickstart")
+	assert.Equal(t, *submitArgs.GPU, float64(1))
+	assert.Equal(t, submitArgs.ImagePullPolicy, "IfNotPresent")
+	assert.Equal(t, submitArgs.EnvironmentVariable, []string{"EPOCHS=10"})
+}
+
+func TestLoadJobSpecFileFlagsOverrideFile(t *testing.T) {
+	fileName := writeJobSpecFile(t, jobSpecYaml)
+	defer os.Remove(fileName)
+
+	submitArgs := NewSubmitRunaiJobArgs()
+	command := newJobSpecTestCommand(submitArgs)
+	if err := command.ParseFlags([]string{"--name", "train2", "-g", "0.5", "-e", "EPOCHS=20"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := loadJobSpecFile(command, []string{}, fileName, submitArgs); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, submitArgs.NameParameter, "train2")
+	assert.Equal(t, submitArgs.Image, "gcr.io/run-ai-demo/quickstart")
+	assert.Equal(t, *submitArgs.GPU, 0.5)
+	assert.Equal(t, submitArgs.EnvironmentVariable, []string{"EPOCHS=20"})
+}
+
+func TestLoadJobSpecFileUnknownField(t *testing.T) {
+	fileName := writeJobSpecFile(t, "image: ubuntu\ngpus: 1\n")
+	defer os.Remove(fileName)
+
+	submitArgs := NewSubmitRunaiJobArgs()
+	command := newJobSpecTestCommand(submitArgs)
+	if err := command.ParseFlags([]string{}); err != nil {
+		t.Fatal(err)
+	}
+
+	err := loadJobSpecFile(command, []string{}, fileName, submitArgs)
+	assert.Equal(t, err != nil, true)
+}
+
+func TestCleanJobSpec(t *testing.T) {
+	gpuInt := 1
+	submitArgs := NewSubmitRunaiJobArgs()
+	submitArgs.Name = "train1"
+	submitArgs.Namespace = "runai-team-a"
+	submitArgs.User = "john"
+	submitArgs.GPUInt = &gpuInt
+	submitArgs.GPUMemory = "4000"
+	submitArgs.MigDevice = migDeviceResourcePrefix + "1g.5gb"
+	submitArgs.Labels = map[string]string{"runai/job-index": "5", "team": "vision"}
+	submitArgs.EnvironmentVariable = []string{"EPOCHS=10", "LDAP_UID=1000", "LDAP_GID=1000"}
+
+	cleanJobSpec(submitArgs)
+
+	assert.Equal(t, submitArgs.Name, "train1")
+	assert.Equal(t, submitArgs.Namespace, "")
+	assert.Equal(t, submitArgs.User, "")
+	assert.Equal(t, submitArgs.GPUInt == nil, true)
+	assert.Equal(t, submitArgs.GPUMemory, "4000M")
+	assert.Equal(t, submitArgs.MigDevice, "1g.5gb")
+	assert.Equal(t, submitArgs.Labels, map[string]string{"team": "vision"})
+	assert.Equal(t, submitArgs.EnvironmentVariable, []string{"EPOCHS=10"})
+}
+
+func TestLoadJobSpecFromConfig(t *testing.T) {
+	submitArgs := NewSubmitRunaiJobArgs()
+	command := newJobSpecTestCommand(submitArgs)
+	if err := command.ParseFlags([]string{"-g", "2"}); err != nil {
+		t.Fatal(err)
+	}
+
+	jobConfig := &workflow.JobConfig{
+		Values:    "name: train1-1\nnamespace: runai-team-a\nimage: gcr.io/run-ai-demo/quickstart\ngpu: 1\nuser: john\n",
+		ChartName: "runai",
+		BaseName:  "train1",
+	}
+	if err := loadJobSpecFromConfig(command, []string{}, "train1-1", jobConfig, "runai", submitArgs); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, submitArgs.NameParameter, "")
+	assert.Equal(t, submitArgs.NamePrefix, "train1")
+	assert.Equal(t, submitArgs.Image, "gcr.io/run-ai-demo/quickstart")
+	assert.Equal(t, *submitArgs.GPU, float64(2))
+	assert.Equal(t, submitArgs.Namespace, "")
+	assert.Equal(t, submitArgs.User, "")
+}
+
+func TestLoadJobSpecFromConfigOfAnotherChart(t *testing.T) {
+	submitArgs := NewSubmitRunaiJobArgs()
+	command := newJobSpecTestCommand(submitArgs)
+	if err := command.ParseFlags([]string{}); err != nil {
+		t.Fatal(err)
+	}
+
+	jobConfig := &workflow.JobConfig{Values: "name: mpi1\n", ChartName: "mpijob", BaseName: "mpi1"}
+	err := loadJobSpecFromConfig(command, []string{}, "mpi1", jobConfig, "runai", submitArgs)
+	assert.Equal(t, err != nil, true)
+}
+
+func TestGetResubmitArgs(t *testing.T) {
+	args := getResubmitArgs([]string{"train1", "--name", "train2", "--", "python", "train.py"}, "runai")
+	assert.Equal(t, args, []string{submitCommand, "--from", "train1", "--name", "train2", "--", "python", "train.py"})
+}
+
+func TestGetResubmitArgsOfMpiJob(t *testing.T) {
+	args := getResubmitArgs([]string{"mpi1", "--processes", "4"}, "mpijob")
+	assert.Equal(t, args, []string{SubmitMpiCommand, "--from", "mpi1", "--processes", "4"})
+}
+
+func TestGetResubmitArgsOfUnknownChart(t *testing.T) {
+	args := getResubmitArgs([]string{"train1"}, "")
+	assert.Equal(t, args, []string{submitCommand, "--from", "train1"})
+}
@@ -31,7 +31,7 @@ import (
 	"github.com/run-ai/runai-cli/pkg/client"
 	"github.com/run-ai/runai-cli/pkg/config"
 	"github.com/run-ai/runai-cli/pkg/util"
-	"github.com/run-ai/runai-cli/pkg/workflow"
+	"github.com/run-ai/runai-cli/pkg/util/helm"
 	"github.com/spf13/cobra"
 )
 
@@ -73,10 +73,37 @@ func NewRunaiSubmitMPIJobCommand() *cobra.Command {
 
 			mpijob_chart = path.Join(chartPath, "mpijob")
 
+			if exportJobName != "" {
+				if err = exportJobSpec(cmd, kubeClient, exportJobName, &submitMPIJobArgs{}); err != nil {
+					fmt.Println(err)
+					os.Exit(1)
+				}
+				return
+			}
+
 			clientset := kubeClient.GetClientset()
 
+			if jobSpecFile != "" && fromJobName != "" {
+				fmt.Println("--file and --from can't be used together")
+				os.Exit(1)
+			}
+
+			if jobSpecFile != "" {
+				if err = loadJobSpecFile(cmd, args, jobSpecFile, &submitArgs); err != nil {
+					fmt.Println(err)
+					os.Exit(1)
+				}
+			}
+
+			if fromJobName != "" {
+				if err = loadJobSpecFromJob(cmd, args, kubeClient, fromJobName, helm.GetChartName(mpijob_chart), &submitArgs); err != nil {
+					fmt.Println(err)
+					os.Exit(1)
+				}
+			}
+
 			commandArgs := convertOldCommandArgsFlags(cmd, &submitArgs.submitArgs, args)
-			submitArgs.GitSync = GitSyncFromConnectionString(gitSyncConnectionString)
+			applyGitSyncFlags(&submitArgs.submitArgs)
 
 			err = applyTemplate(&submitArgs, commandArgs, clientset)
 			if err != nil {
@@ -95,6 +122,11 @@ func NewRunaiSubmitMPIJobCommand() *cobra.Command {
 				os.Exit(1)
 			}
 
+			if err = waitForAfterJobs(kubeClient, submitArgs.Namespace, submitArgs.Project); err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+
 			err = submitMPIJob(cmd, args, &submitArgs, kubeClient)
 			if err != nil {
 				fmt.Println(err)
@@ -172,20 +204,18 @@ func submitMPIJob(cmd *cobra.Command, args []string, submitArgs *submitMPIJobArg
 
 	// the master is also considered as a worker
 	// submitArgs.WorkerCount = submitArgs.WorkerCount - 1
-	submitArgs.Name, err = workflow.SubmitJob(submitArgs.Name, submitArgs.Namespace, submitArgs.generateSuffix, submitArgs, mpijob_chart, client.GetClientset(), dryRun)
-	if err != nil {
+	submitArgs.Name, err = submitJob(&submitArgs.submitArgs, submitArgs, mpijob_chart, client)
+	if err != nil || isDryRun() {
 		return err
 	}
 
-	if !dryRun {
-		fmt.Printf("The job '%s' has been submitted successfully\n", submitArgs.Name)
-		fmt.Printf("You can run `%s describe job %s -p %s` to check the job status\n", config.CLIName, submitArgs.Name, submitArgs.Project)
+	fmt.Printf("The job '%s' has been submitted successfully\n", submitArgs.Name)
+	fmt.Printf("You can run `%s describe job %s -p %s` to check the job status\n", config.CLIName, submitArgs.Name, submitArgs.Project)
 
-		if submitArgs.Attach != nil && *submitArgs.Attach {
-			if err := attach.Attach(cmd, submitArgs.Name, raUtil.IsBoolPTrue(submitArgs.StdIn), raUtil.IsBoolPTrue(submitArgs.TTY), "", attach.DefaultAttachTimeout); err != nil {
-				fmt.Println(err)
-				os.Exit(1)
-			}
+	if submitArgs.Attach != nil && *submitArgs.Attach {
+		if err := attach.Attach(cmd, submitArgs.Name, raUtil.IsBoolPTrue(submitArgs.StdIn), raUtil.IsBoolPTrue(submitArgs.TTY), "", attach.DefaultAttachTimeout); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
 		}
 	}
 
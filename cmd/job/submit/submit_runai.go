@@ -6,7 +6,6 @@ import (
 	"os"
 	"path"
 	"regexp"
-	"strings"
 	"time"
 
 	"github.com/run-ai/runai-cli/cmd/completion"
@@ -20,6 +19,7 @@ import (
 
 	"github.com/run-ai/runai-cli/cmd/attach"
 	"github.com/run-ai/runai-cli/cmd/flags"
+	"github.com/run-ai/runai-cli/cmd/portforward"
 	"github.com/run-ai/runai-cli/cmd/trainer"
 
 	runaiclientset "github.com/run-ai/runai-cli/cmd/mpi/client/clientset/versioned"
@@ -27,8 +27,8 @@ import (
 	"github.com/run-ai/runai-cli/pkg/client"
 	"github.com/run-ai/runai-cli/pkg/config"
 	"github.com/run-ai/runai-cli/pkg/util"
+	"github.com/run-ai/runai-cli/pkg/util/helm"
 	"github.com/run-ai/runai-cli/pkg/util/kubectl"
-	"github.com/run-ai/runai-cli/pkg/workflow"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -53,6 +53,32 @@ runai submit --name hpo1 -i gcr.io/run-ai-demo/quickstart-hpo -g 1  \
 
 # Auto generate job name
 runai submit -i gcr.io/run-ai-demo/quickstart -g 1
+
+# Submit a job defined in a file, overriding the number of GPUs
+runai submit -f train1.yaml -g 2
+
+# Save the specification of an existing job to a file
+runai submit --export train1 > train1.yaml
+
+# Submit a copy of an existing job with 2 GPUs
+runai submit --from train1 -g 2
+
+# Submit a job once job preprocess1 succeeds
+runai submit --name train1 -i gcr.io/run-ai-demo/quickstart -g 1 --after preprocess1
+
+# Submit a job for each parameter set of a sweep file, the parameters are set as environment variables
+runai submit --job-name-prefix lr-sweep -i gcr.io/run-ai-demo/quickstart -g 1 --sweep params.yaml \
+    -- python train.py --lr '$(LR)' --batch-size '$(BATCH_SIZE)'
+
+# Set WANDB_API_KEY from key api-key of secret wandb, and mount the keys of config map train-config under /etc/train
+runai submit --name train1 -i gcr.io/run-ai-demo/quickstart -g 1 --env wandb:api-key=WANDB_API_KEY \
+    --mount-configmap train-config:/etc/train
+
+# Run the local code of ./src, which is uploaded to /code in the job
+runai submit --name train1 -i gcr.io/run-ai-demo/quickstart -g 1 --sync-local ./src -- python train.py
+
+# Print the job objects and validate them with the cluster without submitting the job
+runai submit --name train1 -i gcr.io/run-ai-demo/quickstart -g 1 --dry-run=server
 `
 )
 
@@ -85,11 +111,38 @@ func NewRunaiJobCommand() *cobra.Command {
 				os.Exit(1)
 			}
 
+			if exportJobName != "" {
+				if err = exportJobSpec(cmd, kubeClient, exportJobName, NewSubmitRunaiJobArgs()); err != nil {
+					fmt.Println(err)
+					os.Exit(1)
+				}
+				return
+			}
+
 			clientset := kubeClient.GetClientset()
 			runaijobClient := runaiclientset.NewForConfigOrDie(kubeClient.GetRestConfig())
 
+			if jobSpecFile != "" && fromJobName != "" {
+				fmt.Println("--file and --from can't be used together")
+				os.Exit(1)
+			}
+
+			if jobSpecFile != "" {
+				if err = loadJobSpecFile(cmd, args, jobSpecFile, submitArgs); err != nil {
+					fmt.Println(err)
+					os.Exit(1)
+				}
+			}
+
+			if fromJobName != "" {
+				if err = loadJobSpecFromJob(cmd, args, kubeClient, fromJobName, helm.GetChartName(runaiChart), submitArgs); err != nil {
+					fmt.Println(err)
+					os.Exit(1)
+				}
+			}
+
 			commandArgs := convertOldCommandArgsFlags(cmd, &submitArgs.submitArgs, args)
-			submitArgs.GitSync = GitSyncFromConnectionString(gitSyncConnectionString)
+			applyGitSyncFlags(&submitArgs.submitArgs)
 
 			err = applyTemplate(submitArgs, commandArgs, clientset)
 			if err != nil {
@@ -127,12 +180,33 @@ func NewRunaiJobCommand() *cobra.Command {
 				os.Exit(1)
 			}
 
-			err = submitRunaiJob(submitArgs, clientset, *runaijobClient)
+			if err = waitForAfterJobs(kubeClient, submitArgs.Namespace, submitArgs.Project); err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+
+			if sweepFile != "" {
+				if raUtil.IsBoolPTrue(submitArgs.Attach) || raUtil.IsBoolPTrue(submitArgs.IsJupyter) {
+					fmt.Println("--sweep can't be used together with --attach or --jupyter")
+					os.Exit(1)
+				}
+				if err = submitSweep(submitArgs, kubeClient, *runaijobClient); err != nil {
+					fmt.Println(err)
+					os.Exit(1)
+				}
+				return
+			}
+
+			err = submitRunaiJob(submitArgs, kubeClient, *runaijobClient)
 			if err != nil {
 				fmt.Println(err)
 				os.Exit(1)
 			}
 
+			if isDryRun() {
+				return
+			}
+
 			printJobInfoIfNeeded(submitArgs)
 			if raUtil.IsBoolPTrue(submitArgs.IsJupyter) || (submitArgs.Interactive != nil && *submitArgs.Interactive && submitArgs.ServiceType == "portforward") {
 				kubeClient, err := client.GetClient()
@@ -173,20 +247,7 @@ func NewRunaiJobCommand() *cobra.Command {
 				}
 
 				if submitArgs.Interactive != nil && *submitArgs.Interactive && submitArgs.ServiceType == "portforward" {
-					localPorts := []string{}
-					for _, port := range submitArgs.Ports {
-						split := strings.Split(port, ":")
-						localPorts = append(localPorts, split[0])
-					}
-
-					localUrls := []string{}
-					for _, localPort := range localPorts {
-						localUrls = append(localUrls, fmt.Sprintf("localhost:%s", localPort))
-					}
-
-					accessPoints := strings.Join(localUrls, ",")
-					fmt.Printf("Open access point(s) to service from %s\n", accessPoints)
-					err = kubectl.PortForward(localPorts, submitArgs.Name, submitArgs.Namespace)
+					err = portforward.PortForward(cmd, submitArgs.Name, "", submitArgs.Ports, []string{"localhost"})
 					if err != nil {
 						fmt.Println(err)
 						os.Exit(1)
@@ -356,6 +417,7 @@ func (sa *submitRunaiJobArgs) addFlags(fbg flags.FlagsByGroups) {
 	flags.AddIntNullableFlag(fs, &(sa.Completions), "completions", "Number of successful pods required for this job to be completed. Used with HPO.")
 	flags.AddIntNullableFlag(fs, &(sa.Parallelism), "parallelism", "Number of pods to run in parallel at any given time.  Used with HPO.")
 	flags.AddDurationNullableFlagP(fs, &(sa.TtlAfterFinished), "ttl-after-finish", "", "The duration, after which a finished job is automatically deleted (e.g. 5s, 2m, 3h).")
+	addSweepFlags(fs)
 
 	fs = fbg.GetOrAddFlagSet(AliasesAndShortcutsFlagGroup)
 	flags.AddBoolNullableFlag(fs, &(sa.Inference), "inference", "", "Mark this Job as inference.")
@@ -373,20 +435,19 @@ func (sa *submitRunaiJobArgs) addFlags(fbg flags.FlagsByGroups) {
 
 }
 
-func submitRunaiJob(submitArgs *submitRunaiJobArgs, clientset kubernetes.Interface, runaiclientset runaiclientset.Clientset) error {
+func submitRunaiJob(submitArgs *submitRunaiJobArgs, kubeClient *client.Client, runaiclientset runaiclientset.Clientset) error {
 	err := verifyHPOFlags(submitArgs)
 	if err != nil {
 		return err
 	}
 	handleRunaiJobCRD(submitArgs, runaiclientset)
-	submitArgs.Name, err = workflow.SubmitJob(submitArgs.Name, submitArgs.Namespace, submitArgs.generateSuffix, submitArgs, runaiChart, clientset, dryRun)
-	if err != nil {
+
+	submitArgs.Name, err = submitJob(&submitArgs.submitArgs, submitArgs, runaiChart, kubeClient)
+	if err != nil || isDryRun() {
 		return err
 	}
-	if !dryRun {
-		fmt.Printf("The job '%s' has been submitted successfully\n", submitArgs.Name)
-		fmt.Printf("You can run `%s describe job %s -p %s` to check the job status\n", config.CLIName, submitArgs.Name, submitArgs.Project)
-	}
+	fmt.Printf("The job '%s' has been submitted successfully\n", submitArgs.Name)
+	fmt.Printf("You can run `%s describe job %s -p %s` to check the job status\n", config.CLIName, submitArgs.Name, submitArgs.Project)
 
 	return nil
 }
@@ -0,0 +1,210 @@
+package submit
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const (
+	defaultPreferredNodeAffinityWeight = 1
+	maxPreferredNodeAffinityWeight     = 100
+)
+
+var tolerationEffects = []string{"NoSchedule", "PreferNoSchedule", "NoExecute"}
+
+// schedulingValues are the tolerations, node selector and node affinity of the job pods, in the format of the charts
+type schedulingValues struct {
+	Tolerations           []toleration              `yaml:"tolerations,omitempty"`
+	NodeSelector          map[string]string         `yaml:"nodeSelector,omitempty"`
+	RequiredNodeAffinity  []nodeSelectorRequirement `yaml:"requiredNodeAffinity,omitempty"`
+	PreferredNodeAffinity []preferredSchedulingTerm `yaml:"preferredNodeAffinity,omitempty"`
+}
+
+type toleration struct {
+	Key      string `yaml:"key,omitempty"`
+	Operator string `yaml:"operator"`
+	Value    string `yaml:"value,omitempty"`
+	Effect   string `yaml:"effect,omitempty"`
+}
+
+type nodeSelectorRequirement struct {
+	Key      string   `yaml:"key"`
+	Operator string   `yaml:"operator"`
+	Values   []string `yaml:"values,omitempty"`
+}
+
+type nodeSelectorTerm struct {
+	MatchExpressions []nodeSelectorRequirement `yaml:"matchExpressions"`
+}
+
+type preferredSchedulingTerm struct {
+	Weight     int              `yaml:"weight"`
+	Preference nodeSelectorTerm `yaml:"preference"`
+}
+
+// setSchedulingValues parses the scheduling flags into the values of the charts
+func (submitArgs *submitArgs) setSchedulingValues() error {
+	if len(submitArgs.Tolerations) == 0 && len(submitArgs.NodeSelectors) == 0 && len(submitArgs.NodeAffinity) == 0 && len(submitArgs.PreferredNodeAffinity) == 0 {
+		submitArgs.Scheduling = nil
+		return nil
+	}
+
+	scheduling := &schedulingValues{}
+	for _, value := range submitArgs.Tolerations {
+		toleration, err := parseToleration(value)
+		if err != nil {
+			return err
+		}
+		scheduling.Tolerations = append(scheduling.Tolerations, toleration)
+	}
+
+	for _, value := range submitArgs.NodeSelectors {
+		key, labelValue, err := parseNodeSelector(value)
+		if err != nil {
+			return err
+		}
+		if scheduling.NodeSelector == nil {
+			scheduling.NodeSelector = map[string]string{}
+		}
+		scheduling.NodeSelector[key] = labelValue
+	}
+
+	// all the required expressions must be met, so they are set in a single node selector term
+	for _, value := range submitArgs.NodeAffinity {
+		requirements, err := parseNodeAffinityExpressions(value)
+		if err != nil {
+			return fmt.Errorf("invalid node affinity '%s': %v", value, err)
+		}
+		scheduling.RequiredNodeAffinity = append(scheduling.RequiredNodeAffinity, requirements...)
+	}
+
+	for _, value := range submitArgs.PreferredNodeAffinity {
+		term, err := parsePreferredNodeAffinity(value)
+		if err != nil {
+			return fmt.Errorf("invalid preferred node affinity '%s': %v", value, err)
+		}
+		scheduling.PreferredNodeAffinity = append(scheduling.PreferredNodeAffinity, term)
+	}
+
+	submitArgs.Scheduling = scheduling
+	return nil
+}
+
+// parseToleration parses a toleration in the format of key[=value][:effect]. Without a value the toleration
+// tolerates any value of the key, and without an effect it tolerates all effects.
+func parseToleration(value string) (toleration, error) {
+	result := toleration{Operator: "Exists"}
+	keyValue := value
+	if index := strings.LastIndex(value, ":"); index != -1 {
+		keyValue = value[:index]
+		result.Effect = value[index+1:]
+		if !contains(tolerationEffects, result.Effect) {
+			return result, fmt.Errorf("invalid toleration '%s', the effect must be one of %s", value, strings.Join(tolerationEffects, ", "))
+		}
+	}
+
+	parts := strings.SplitN(keyValue, "=", 2)
+	result.Key = parts[0]
+	if len(parts) == 2 {
+		result.Operator = "Equal"
+		result.Value = parts[1]
+		if errs := validation.IsValidLabelValue(result.Value); len(errs) > 0 {
+			return result, fmt.Errorf("invalid toleration '%s': %s", value, strings.Join(errs, ", "))
+		}
+	}
+	if errs := validation.IsQualifiedName(result.Key); len(errs) > 0 {
+		return result, fmt.Errorf("invalid toleration '%s', the format is key[=value][:effect]: %s", value, strings.Join(errs, ", "))
+	}
+	return result, nil
+}
+
+// parseNodeSelector parses a node selector in the format of key=value
+func parseNodeSelector(value string) (string, string, error) {
+	parts := strings.SplitN(value, "=", 2)
+	if len(parts) != 2 {
+		return "", "", fmt.Errorf("invalid node selector '%s', the format is key=value", value)
+	}
+	if errs := validation.IsQualifiedName(parts[0]); len(errs) > 0 {
+		return "", "", fmt.Errorf("invalid node selector '%s': %s", value, strings.Join(errs, ", "))
+	}
+	if errs := validation.IsValidLabelValue(parts[1]); len(errs) > 0 {
+		return "", "", fmt.Errorf("invalid node selector '%s': %s", value, strings.Join(errs, ", "))
+	}
+	return parts[0], parts[1], nil
+}
+
+// parseNodeAffinityExpressions parses node label expressions in the format of kubectl label selectors, e.g.
+// 'gpu-type in (a100,v100),!spot'
+func parseNodeAffinityExpressions(value string) ([]nodeSelectorRequirement, error) {
+	selector, err := labels.Parse(value)
+	if err != nil {
+		return nil, err
+	}
+	requirements, _ := selector.Requirements()
+	if len(requirements) == 0 {
+		return nil, fmt.Errorf("no expressions were found")
+	}
+
+	result := []nodeSelectorRequirement{}
+	for _, requirement := range requirements {
+		var operator string
+		switch requirement.Operator() {
+		case selection.In, selection.Equals, selection.DoubleEquals:
+			operator = "In"
+		case selection.NotIn, selection.NotEquals:
+			operator = "NotIn"
+		case selection.Exists:
+			operator = "Exists"
+		case selection.DoesNotExist:
+			operator = "DoesNotExist"
+		case selection.GreaterThan:
+			operator = "Gt"
+		case selection.LessThan:
+			operator = "Lt"
+		default:
+			return nil, fmt.Errorf("unsupported operator %s", requirement.Operator())
+		}
+		result = append(result, nodeSelectorRequirement{
+			Key:      requirement.Key(),
+			Operator: operator,
+			Values:   requirement.Values().List(),
+		})
+	}
+	return result, nil
+}
+
+// parsePreferredNodeAffinity parses node label expressions which are optionally prefixed by a weight between 1 and
+// 100, e.g. '50:pool=spot'
+func parsePreferredNodeAffinity(value string) (preferredSchedulingTerm, error) {
+	term := preferredSchedulingTerm{Weight: defaultPreferredNodeAffinityWeight}
+	expressions := value
+	if parts := strings.SplitN(value, ":", 2); len(parts) == 2 {
+		weight, err := strconv.Atoi(parts[0])
+		if err != nil || weight < 1 || weight > maxPreferredNodeAffinityWeight {
+			return term, fmt.Errorf("the weight must be a number between 1 and %d", maxPreferredNodeAffinityWeight)
+		}
+		term.Weight = weight
+		expressions = parts[1]
+	}
+
+	requirements, err := parseNodeAffinityExpressions(expressions)
+	if err != nil {
+		return term, err
+	}
+	term.Preference.MatchExpressions = requirements
+	return term, nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,119 @@
+package submit
+
+import (
+	"testing"
+
+	"github.com/magiconair/properties/assert"
+)
+
+func TestParseToleration(t *testing.T) {
+	tests := []struct {
+		value      string
+		toleration toleration
+	}{
+		{value: "nvidia.com/gpu=a100:NoSchedule", toleration: toleration{Key: "nvidia.com/gpu", Operator: "Equal", Value: "a100", Effect: "NoSchedule"}},
+		{value: "spot:NoExecute", toleration: toleration{Key: "spot", Operator: "Exists", Effect: "NoExecute"}},
+		{value: "spot=true", toleration: toleration{Key: "spot", Operator: "Equal", Value: "true"}},
+		{value: "spot", toleration: toleration{Key: "spot", Operator: "Exists"}},
+	}
+
+	for _, test := range tests {
+		result, err := parseToleration(test.value)
+		if err != nil {
+			t.Errorf("unexpected error for %s: %v", test.value, err)
+			continue
+		}
+		assert.Equal(t, result, test.toleration)
+	}
+
+	for _, value := range []string{"spot:NoRun", "=true:NoSchedule", "spot=not valid"} {
+		if _, err := parseToleration(value); err == nil {
+			t.Errorf("expected an error for %s", value)
+		}
+	}
+}
+
+func TestParseNodeSelector(t *testing.T) {
+	key, value, err := parseNodeSelector("node-pool=a100")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, key, "node-pool")
+	assert.Equal(t, value, "a100")
+
+	for _, value := range []string{"node-pool", "=a100", "node-pool=a 100"} {
+		if _, _, err := parseNodeSelector(value); err == nil {
+			t.Errorf("expected an error for %s", value)
+		}
+	}
+}
+
+func TestParseNodeAffinityExpressions(t *testing.T) {
+	requirements, err := parseNodeAffinityExpressions("gpu-type in (v100,a100),!spot,zone=us-east-1a,gpu-count>4")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the expressions are sorted by key
+	assert.Equal(t, requirements, []nodeSelectorRequirement{
+		{Key: "gpu-count", Operator: "Gt", Values: []string{"4"}},
+		{Key: "gpu-type", Operator: "In", Values: []string{"a100", "v100"}},
+		{Key: "spot", Operator: "DoesNotExist", Values: []string{}},
+		{Key: "zone", Operator: "In", Values: []string{"us-east-1a"}},
+	})
+
+	for _, value := range []string{"", "gpu-type in (a100"} {
+		if _, err := parseNodeAffinityExpressions(value); err == nil {
+			t.Errorf("expected an error for '%s'", value)
+		}
+	}
+}
+
+func TestParsePreferredNodeAffinity(t *testing.T) {
+	term, err := parsePreferredNodeAffinity("50:pool=spot")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, term, preferredSchedulingTerm{
+		Weight:     50,
+		Preference: nodeSelectorTerm{MatchExpressions: []nodeSelectorRequirement{{Key: "pool", Operator: "In", Values: []string{"spot"}}}},
+	})
+
+	term, err = parsePreferredNodeAffinity("pool=spot")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, term.Weight, defaultPreferredNodeAffinityWeight)
+
+	for _, value := range []string{"0:pool=spot", "high:pool=spot"} {
+		if _, err := parsePreferredNodeAffinity(value); err == nil {
+			t.Errorf("expected an error for %s", value)
+		}
+	}
+}
+
+func TestSetSchedulingValues(t *testing.T) {
+	args := submitArgs{
+		Tolerations:   []string{"nvidia.com/gpu=a100:NoSchedule"},
+		NodeSelectors: []string{"node-pool=spot", "node-pool=a100"},
+		NodeAffinity:  []string{"gpu-type=a100", "!spot"},
+	}
+
+	if err := args.setSchedulingValues(); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, args.Scheduling.Tolerations, []toleration{{Key: "nvidia.com/gpu", Operator: "Equal", Value: "a100", Effect: "NoSchedule"}})
+	// a later node selector of the same label, e.g. of a template, overrides the former
+	assert.Equal(t, args.Scheduling.NodeSelector, map[string]string{"node-pool": "a100"})
+	assert.Equal(t, len(args.Scheduling.RequiredNodeAffinity), 2)
+	assert.Equal(t, len(args.Scheduling.PreferredNodeAffinity), 0)
+
+	args = submitArgs{}
+	if err := args.setSchedulingValues(); err != nil {
+		t.Fatal(err)
+	}
+	if args.Scheduling != nil {
+		t.Errorf("expected no scheduling values without scheduling flags")
+	}
+}
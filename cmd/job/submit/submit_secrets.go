@@ -0,0 +1,214 @@
+package submit
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/kubernetes"
+)
+
+// legacySecretEnvironmentPrefix is the prefix of a secret reference in the value of --environment, e.g. VAR=SECRET:name,key
+const legacySecretEnvironmentPrefix = "SECRET:"
+
+var (
+	credentialNamePattern  = regexp.MustCompile(`(?i)(PASSWORD|PASSWD|SECRET|TOKEN|API_?KEY|ACCESS_?KEY|PRIVATE_?KEY|CREDENTIAL)`)
+	credentialValuePattern = regexp.MustCompile(`^(AKIA[0-9A-Z]{16}|gh[opsu]_[A-Za-z0-9]{36}|glpat-[A-Za-z0-9_-]{20}|hf_[A-Za-z0-9]{30,}|sk-[A-Za-z0-9_-]{20,}|xox[abpr]-[A-Za-z0-9-]+)`)
+)
+
+// secretValues are the secrets and config maps the job pods use, in the format of the charts
+type secretValues struct {
+	EnvFrom         []string                    `yaml:"envFrom,omitempty"`
+	Env             []secretEnvironmentVariable `yaml:"env,omitempty"`
+	SecretMounts    []objectMount               `yaml:"secretMounts,omitempty"`
+	ConfigMapMounts []objectMount               `yaml:"configMapMounts,omitempty"`
+}
+
+type secretEnvironmentVariable struct {
+	Name   string `yaml:"name"`
+	Secret string `yaml:"secret"`
+	Key    string `yaml:"key"`
+}
+
+type objectMount struct {
+	Name      string `yaml:"name"`
+	MountPath string `yaml:"mountPath"`
+}
+
+// setSecretValues parses the secret and config map flags into the values of the charts
+func (submitArgs *submitArgs) setSecretValues() error {
+	if len(submitArgs.EnvFromSecrets) == 0 && len(submitArgs.SecretEnvironmentVariables) == 0 && len(submitArgs.SecretMounts) == 0 && len(submitArgs.ConfigMapMounts) == 0 {
+		submitArgs.Secrets = nil
+		return nil
+	}
+
+	secrets := &secretValues{}
+	for _, name := range submitArgs.EnvFromSecrets {
+		if errs := validation.IsDNS1123Subdomain(name); len(errs) > 0 {
+			return fmt.Errorf("invalid secret name '%s': %s", name, strings.Join(errs, ", "))
+		}
+		secrets.EnvFrom = append(secrets.EnvFrom, name)
+	}
+
+	for _, value := range submitArgs.SecretEnvironmentVariables {
+		environmentVariable, err := parseSecretEnvironmentVariable(value)
+		if err != nil {
+			return err
+		}
+		secrets.Env = append(secrets.Env, environmentVariable)
+	}
+
+	for _, value := range submitArgs.SecretMounts {
+		mount, err := parseObjectMount(value, "secret")
+		if err != nil {
+			return err
+		}
+		secrets.SecretMounts = append(secrets.SecretMounts, mount)
+	}
+
+	for _, value := range submitArgs.ConfigMapMounts {
+		mount, err := parseObjectMount(value, "config map")
+		if err != nil {
+			return err
+		}
+		secrets.ConfigMapMounts = append(secrets.ConfigMapMounts, mount)
+	}
+
+	submitArgs.Secrets = secrets
+	return nil
+}
+
+// parseSecretEnvironmentVariable parses an environment variable set from a key of a secret, in the format of
+// SECRET:KEY=VAR
+func parseSecretEnvironmentVariable(value string) (secretEnvironmentVariable, error) {
+	result := secretEnvironmentVariable{}
+	parts := strings.SplitN(value, "=", 2)
+	secretParts := strings.SplitN(parts[0], ":", 2)
+	if len(parts) != 2 || len(secretParts) != 2 {
+		return result, fmt.Errorf("invalid secret environment variable '%s', the format is SECRET:KEY=VAR", value)
+	}
+
+	result.Secret, result.Key, result.Name = secretParts[0], secretParts[1], parts[1]
+	if errs := validation.IsDNS1123Subdomain(result.Secret); len(errs) > 0 {
+		return result, fmt.Errorf("invalid secret name in '%s': %s", value, strings.Join(errs, ", "))
+	}
+	if errs := validation.IsConfigMapKey(result.Key); len(errs) > 0 {
+		return result, fmt.Errorf("invalid secret key in '%s': %s", value, strings.Join(errs, ", "))
+	}
+	if errs := validation.IsEnvVarName(result.Name); len(errs) > 0 {
+		return result, fmt.Errorf("invalid environment variable name in '%s': %s", value, strings.Join(errs, ", "))
+	}
+	return result, nil
+}
+
+// parseObjectMount parses a secret or config map mount in the format of NAME:/path
+func parseObjectMount(value, kind string) (objectMount, error) {
+	parts := strings.SplitN(value, ":", 2)
+	if len(parts) != 2 {
+		return objectMount{}, fmt.Errorf("invalid %s mount '%s', the format is NAME:/path", kind, value)
+	}
+	if errs := validation.IsDNS1123Subdomain(parts[0]); len(errs) > 0 {
+		return objectMount{}, fmt.Errorf("invalid %s name in '%s': %s", kind, value, strings.Join(errs, ", "))
+	}
+	if !path.IsAbs(parts[1]) {
+		return objectMount{}, fmt.Errorf("invalid %s mount '%s', the mount path must be absolute", kind, value)
+	}
+	return objectMount{Name: parts[0], MountPath: path.Clean(parts[1])}, nil
+}
+
+// validateSecretReferences checks that the secrets and config maps the job uses exist in the namespace of the
+// project, and that the secrets have the keys the environment variables are set from. Objects the user is not
+// allowed to read are not validated, as the job may still be allowed to use them.
+func validateSecretReferences(clientset kubernetes.Interface, namespace, project string, secrets *secretValues) error {
+	if secrets == nil {
+		return nil
+	}
+
+	// the keys each secret is required to have
+	secretKeys := map[string][]string{}
+	addSecret := func(name string, keys ...string) {
+		secretKeys[name] = append(secretKeys[name], keys...)
+	}
+	for _, name := range secrets.EnvFrom {
+		addSecret(name)
+	}
+	for _, environmentVariable := range secrets.Env {
+		addSecret(environmentVariable.Secret, environmentVariable.Key)
+	}
+	for _, mount := range secrets.SecretMounts {
+		addSecret(mount.Name)
+	}
+
+	for _, name := range sortedKeys(secretKeys) {
+		secret, err := clientset.CoreV1().Secrets(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+		if errors.IsForbidden(err) {
+			log.Debugf("Not allowed to validate the secret %s: %v", name, err)
+			continue
+		}
+		if errors.IsNotFound(err) {
+			return fmt.Errorf("the secret %s does not exist in project %s", name, project)
+		}
+		if err != nil {
+			return err
+		}
+		for _, key := range secretKeys[name] {
+			if _, found := secret.Data[key]; !found {
+				return fmt.Errorf("the secret %s has no key %s", name, key)
+			}
+		}
+	}
+
+	for _, mount := range secrets.ConfigMapMounts {
+		_, err := clientset.CoreV1().ConfigMaps(namespace).Get(context.TODO(), mount.Name, metav1.GetOptions{})
+		if errors.IsForbidden(err) {
+			log.Debugf("Not allowed to validate the config map %s: %v", mount.Name, err)
+			continue
+		}
+		if errors.IsNotFound(err) {
+			return fmt.Errorf("the config map %s does not exist in project %s", mount.Name, project)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// warnOnLiteralCredentials warns about environment variables which seem to hold credentials, as their values are
+// stored in the job
+func warnOnLiteralCredentials(environmentVariables []string) {
+	for _, name := range findLiteralCredentials(environmentVariables) {
+		log.Warnf("The environment variable %s seems to hold a credential, which is stored in the job as plain text. Consider creating a secret and using --env SECRET:KEY=%s instead.", name, name)
+	}
+}
+
+// findLiteralCredentials returns the names of the environment variables whose name or value looks like a credential
+func findLiteralCredentials(environmentVariables []string) []string {
+	names := []string{}
+	for _, environmentVariable := range environmentVariables {
+		parts := strings.SplitN(environmentVariable, "=", 2)
+		if len(parts) != 2 || parts[1] == "" || strings.HasPrefix(parts[1], legacySecretEnvironmentPrefix) {
+			continue
+		}
+		if credentialNamePattern.MatchString(parts[0]) || credentialValuePattern.MatchString(parts[1]) {
+			names = append(names, parts[0])
+		}
+	}
+	return names
+}
+
+func sortedKeys(values map[string][]string) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,335 @@
+package submit
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	runaiclientset "github.com/run-ai/runai-cli/cmd/mpi/client/clientset/versioned"
+	"github.com/run-ai/runai-cli/pkg/client"
+	"github.com/run-ai/runai-cli/pkg/config"
+	"github.com/run-ai/runai-cli/pkg/ui"
+	"github.com/run-ai/runai-cli/pkg/workflow"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/pflag"
+	yaml "gopkg.in/yaml.v2"
+)
+
+const (
+	// SweepLabel is the label of the jobs submitted by a sweep, its value is the name of the sweep
+	SweepLabel = "runai/sweep"
+
+	defaultSweepParallelism = 4
+	sweepNameTimeFormat     = "20060102150405"
+	maxLabelValueLength     = 63
+
+	sweepJobSubmitted = "Submitted"
+	sweepJobFailed    = "Failed"
+)
+
+var (
+	sweepFile        string
+	sweepParallelism int
+
+	sweepParameterNameRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+)
+
+// sweepSpec is the content of a yaml sweep file, either a grid or a list of parameter sets
+type sweepSpec struct {
+	// Grid expands to a parameter set for each combination of the values of the parameters
+	Grid map[string][]string `yaml:"grid,omitempty"`
+	// List is an explicit list of parameter sets
+	List []map[string]string `yaml:"list,omitempty"`
+}
+
+// sweepJobView is a row of the summary table printed after the sweep submission
+type sweepJobView struct {
+	Name       string `title:"NAME" def:"-"`
+	Status     string `title:"STATUS"`
+	Parameters string `title:"PARAMETERS"`
+	Error      string `title:"ERROR" def:"-"`
+}
+
+func addSweepFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&sweepFile, "sweep", "", "Submit a job for each parameter set of a yaml (grid or list) or csv sweep file. The parameters are set as environment variables of the jobs.")
+	fs.IntVar(&sweepParallelism, "sweep-parallelism", defaultSweepParallelism, "The maximal number of sweep jobs submitted concurrently.")
+}
+
+// loadSweepFile returns the parameter sets of a sweep file. In a csv file the first row holds the parameter names and
+// every other row is a parameter set. A yaml file holds either a grid of parameter values or a list of parameter sets:
+//
+//	grid:
+//	  LR: [0.1, 0.01]
+//	  BATCH_SIZE: [32, 64]
+func loadSweepFile(fileName string) ([]map[string]string, error) {
+	content, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+
+	var parameterSets []map[string]string
+	if strings.EqualFold(filepath.Ext(fileName), ".csv") {
+		parameterSets, err = parseSweepCsv(string(content))
+	} else {
+		parameterSets, err = parseSweepYaml(content)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not parse the sweep file %s: %v", fileName, err)
+	}
+
+	if len(parameterSets) == 0 {
+		return nil, fmt.Errorf("the sweep file %s has no parameter sets", fileName)
+	}
+	for _, parameterSet := range parameterSets {
+		for name := range parameterSet {
+			if !sweepParameterNameRegex.MatchString(name) {
+				return nil, fmt.Errorf("invalid sweep parameter %s, parameters are set as environment variables so they must consist of letters, digits and '_'", name)
+			}
+		}
+	}
+	return parameterSets, nil
+}
+
+func parseSweepYaml(content []byte) ([]map[string]string, error) {
+	spec := sweepSpec{}
+	if err := yaml.UnmarshalStrict(content, &spec); err != nil {
+		return nil, err
+	}
+	if len(spec.Grid) != 0 && len(spec.List) != 0 {
+		return nil, fmt.Errorf("either a grid or a list of parameter sets should be set")
+	}
+	if len(spec.List) != 0 {
+		return spec.List, nil
+	}
+	return expandSweepGrid(spec.Grid), nil
+}
+
+func parseSweepCsv(content string) ([]map[string]string, error) {
+	records, err := csv.NewReader(strings.NewReader(content)).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	names := records[0]
+	parameterSets := []map[string]string{}
+	for _, record := range records[1:] {
+		parameterSet := map[string]string{}
+		for i, name := range names {
+			parameterSet[strings.TrimSpace(name)] = strings.TrimSpace(record[i])
+		}
+		parameterSets = append(parameterSets, parameterSet)
+	}
+	return parameterSets, nil
+}
+
+// expandSweepGrid returns the combinations of the values of the grid parameters. The parameters are iterated by name,
+// so the last parameter changes between consecutive sets.
+func expandSweepGrid(grid map[string][]string) []map[string]string {
+	if len(grid) == 0 {
+		return nil
+	}
+
+	names := []string{}
+	for name := range grid {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	parameterSets := []map[string]string{{}}
+	for _, name := range names {
+		expanded := []map[string]string{}
+		for _, parameterSet := range parameterSets {
+			for _, value := range grid[name] {
+				expandedSet := map[string]string{name: value}
+				for key, setValue := range parameterSet {
+					expandedSet[key] = setValue
+				}
+				expanded = append(expanded, expandedSet)
+			}
+		}
+		parameterSets = expanded
+	}
+	return parameterSets
+}
+
+// formatSweepParameters returns the parameters as NAME=VALUE environment variables, sorted by name
+func formatSweepParameters(parameterSet map[string]string) []string {
+	parameters := []string{}
+	for name, value := range parameterSet {
+		parameters = append(parameters, fmt.Sprintf("%s=%s", name, value))
+	}
+	sort.Strings(parameters)
+	return parameters
+}
+
+// getSweepName returns a name for the sweep, which is unique enough to be used as the label of its jobs
+func getSweepName(namePrefix string, now time.Time) string {
+	suffix := fmt.Sprintf("-%s", now.Format(sweepNameTimeFormat))
+	if len(namePrefix)+len(suffix) > maxLabelValueLength {
+		namePrefix = strings.TrimRight(namePrefix[:maxLabelValueLength-len(suffix)], "-")
+	}
+	return namePrefix + suffix
+}
+
+// getSweepJobArgs returns a copy of the submit args of the sweep with the parameters of one job. The parameters
+// override environment variables with the same name.
+func getSweepJobArgs(submitArgs *submitRunaiJobArgs, parameterSet map[string]string, sweepName string) *submitRunaiJobArgs {
+	jobArgs := *submitArgs
+
+	jobArgs.EnvironmentVariable = []string{}
+	for _, environmentVariable := range submitArgs.EnvironmentVariable {
+		name := strings.SplitN(environmentVariable, "=", 2)[0]
+		if _, found := parameterSet[name]; !found {
+			jobArgs.EnvironmentVariable = append(jobArgs.EnvironmentVariable, environmentVariable)
+		}
+	}
+	jobArgs.EnvironmentVariable = append(jobArgs.EnvironmentVariable, formatSweepParameters(parameterSet)...)
+
+	jobArgs.Labels = map[string]string{}
+	for key, value := range submitArgs.Labels {
+		jobArgs.Labels[key] = value
+	}
+	jobArgs.Labels[SweepLabel] = sweepName
+	return &jobArgs
+}
+
+// runSweepSubmissions calls submit for every parameter set, with at most parallelism concurrent calls, and returns
+// the results in the order of the parameter sets
+func runSweepSubmissions(parameterSets []map[string]string, parallelism int, submit func(parameterSet map[string]string) (string, error)) []sweepJobView {
+	if parallelism < 1 {
+		parallelism = 1
+	}
+
+	results := make([]sweepJobView, len(parameterSets))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for worker := 0; worker < parallelism; worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				parameterSet := parameterSets[i]
+				result := sweepJobView{
+					Status:     sweepJobSubmitted,
+					Parameters: strings.Join(formatSweepParameters(parameterSet), " "),
+				}
+				name, err := submit(parameterSet)
+				result.Name = name
+				if err != nil {
+					result.Status = sweepJobFailed
+					result.Error = err.Error()
+				}
+				results[i] = result
+			}
+		}()
+	}
+
+	for i := range parameterSets {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+	return results
+}
+
+// submitSweep submits a job for every parameter set of the sweep file and prints a summary of the submitted jobs
+func submitSweep(submitArgs *submitRunaiJobArgs, kubeClient *client.Client, runaiclientset runaiclientset.Clientset) error {
+	parameterSets, err := loadSweepFile(sweepFile)
+	if err != nil {
+		return err
+	}
+
+	if err = verifyHPOFlags(submitArgs); err != nil {
+		return err
+	}
+	handleRunaiJobCRD(submitArgs, runaiclientset)
+
+	sweepName := getSweepName(submitArgs.Name, time.Now())
+	clientset := kubeClient.GetClientset()
+
+	parallelism := sweepParallelism
+	if isDryRun() {
+		// the objects of the jobs are printed, so they should not be interleaved
+		parallelism = 1
+	}
+
+	// the jobs of the sweep share a single git sync credentials secret and a single upload of the local code
+	credentialsSecret, secretCreated, err := submitArgs.GitSync.createCredentialsSecret(clientset, submitArgs.Namespace, sweepName)
+	if err != nil {
+		return err
+	}
+	codeConfigMap, codeUploaded, err := submitArgs.uploadLocalCode(clientset)
+	if err != nil {
+		return err
+	}
+
+	results := runSweepSubmissions(parameterSets, parallelism, func(parameterSet map[string]string) (string, error) {
+		jobArgs := getSweepJobArgs(submitArgs, parameterSet, sweepName)
+		if isDryRun() {
+			return jobArgs.Name, dryRunJob(jobArgs.Name, jobArgs.Namespace, jobArgs, runaiChart, kubeClient)
+		}
+
+		if index, err := getJobIndex(clientset); err != nil {
+			log.Debug("Could not get job index. Will not set a label.")
+			delete(jobArgs.Labels, "runai/job-index")
+		} else {
+			jobArgs.Labels["runai/job-index"] = index
+		}
+		return workflow.SubmitJob(jobArgs.Name, jobArgs.Namespace, true, jobArgs, runaiChart, kubeClient)
+	})
+
+	if isDryRun() {
+		return nil
+	}
+
+	jobNames := []string{}
+	for _, result := range results {
+		if result.Status != sweepJobFailed {
+			jobNames = append(jobNames, result.Name)
+		}
+	}
+	if err = setGitSyncSecretOwners(clientset, submitArgs.Namespace, credentialsSecret, secretCreated, jobNames); err != nil {
+		log.Warnf("Failed to set the owners of the git sync secret %s: %v", credentialsSecret.Name, err)
+	}
+	if err = setLocalCodeOwners(clientset, submitArgs.Namespace, codeConfigMap, codeUploaded, jobNames); err != nil {
+		log.Warnf("Failed to set the owners of the local code config map %s: %v", codeConfigMap.Name, err)
+	}
+
+	if err = printSweepResults(os.Stdout, results); err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, result := range results {
+		if result.Status == sweepJobFailed {
+			failed++
+		}
+	}
+	fmt.Printf("\nSubmitted %d of %d jobs of sweep %s\n", len(results)-failed, len(results), sweepName)
+	fmt.Printf("You can run `%s list jobs -l %s=%s -p %s` to check the jobs status\n", config.CLIName, SweepLabel, sweepName, submitArgs.Project)
+	if failed > 0 {
+		return fmt.Errorf("failed to submit %d jobs", failed)
+	}
+	return nil
+}
+
+func printSweepResults(out io.Writer, results []sweepJobView) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	err := ui.CreateTable(sweepJobView{}, ui.TableOpt{}).Render(w, results).Error()
+	if err != nil {
+		return err
+	}
+	return w.Flush()
+}
@@ -0,0 +1,173 @@
+package submit
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/magiconair/properties/assert"
+)
+
+func writeSweepFile(t *testing.T, fileName, content string) string {
+	dir, err := ioutil.TempDir("", "sweep")
+	if err != nil {
+		t.Fatal(err)
+	}
+	filePath := filepath.Join(dir, fileName)
+	if err = ioutil.WriteFile(filePath, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return filePath
+}
+
+func TestLoadSweepFileGrid(t *testing.T) {
+	fileName := writeSweepFile(t, "params.yaml", `
+grid:
+  LR: [0.1, 0.01]
+  BATCH_SIZE: [32, 64]
+`)
+	defer os.RemoveAll(filepath.Dir(fileName))
+
+	parameterSets, err := loadSweepFile(fileName)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, parameterSets, []map[string]string{
+		{"BATCH_SIZE": "32", "LR": "0.1"},
+		{"BATCH_SIZE": "32", "LR": "0.01"},
+		{"BATCH_SIZE": "64", "LR": "0.1"},
+		{"BATCH_SIZE": "64", "LR": "0.01"},
+	})
+}
+
+func TestLoadSweepFileList(t *testing.T) {
+	fileName := writeSweepFile(t, "params.yaml", `
+list:
+- LR: 0.1
+  BATCH_SIZE: 32
+- LR: 0.01
+  BATCH_SIZE: 64
+`)
+	defer os.RemoveAll(filepath.Dir(fileName))
+
+	parameterSets, err := loadSweepFile(fileName)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, parameterSets, []map[string]string{
+		{"BATCH_SIZE": "32", "LR": "0.1"},
+		{"BATCH_SIZE": "64", "LR": "0.01"},
+	})
+}
+
+func TestLoadSweepFileCsv(t *testing.T) {
+	fileName := writeSweepFile(t, "params.csv", "LR, BATCH_SIZE\n0.1, 32\n0.01, 64\n")
+	defer os.RemoveAll(filepath.Dir(fileName))
+
+	parameterSets, err := loadSweepFile(fileName)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, parameterSets, []map[string]string{
+		{"BATCH_SIZE": "32", "LR": "0.1"},
+		{"BATCH_SIZE": "64", "LR": "0.01"},
+	})
+}
+
+func TestLoadSweepFileErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		fileName string
+		content  string
+	}{
+		{name: "grid and list", fileName: "params.yaml", content: "grid:\n  LR: [0.1]\nlist:\n- LR: 0.1\n"},
+		{name: "no parameter sets", fileName: "params.yaml", content: "grid: {}\n"},
+		{name: "unknown field", fileName: "params.yaml", content: "lists:\n- LR: 0.1\n"},
+		{name: "invalid parameter name", fileName: "params.yaml", content: "grid:\n  learning-rate: [0.1]\n"},
+		{name: "missing csv value", fileName: "params.csv", content: "LR,BATCH_SIZE\n0.1\n"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fileName := writeSweepFile(t, test.fileName, test.content)
+			defer os.RemoveAll(filepath.Dir(fileName))
+
+			if _, err := loadSweepFile(fileName); err == nil {
+				t.Errorf("expected an error for %s", test.content)
+			}
+		})
+	}
+}
+
+func TestGetSweepJobArgs(t *testing.T) {
+	submitArgs := NewSubmitRunaiJobArgs()
+	submitArgs.Name = "lr-sweep"
+	submitArgs.EnvironmentVariable = []string{"EPOCHS=10", "LR=1"}
+	submitArgs.Labels = map[string]string{"team": "vision"}
+
+	jobArgs := getSweepJobArgs(submitArgs, map[string]string{"LR": "0.1", "BATCH_SIZE": "32"}, "lr-sweep-20210101120000")
+
+	assert.Equal(t, jobArgs.Name, "lr-sweep")
+	assert.Equal(t, jobArgs.EnvironmentVariable, []string{"EPOCHS=10", "BATCH_SIZE=32", "LR=0.1"})
+	assert.Equal(t, jobArgs.Labels, map[string]string{"team": "vision", SweepLabel: "lr-sweep-20210101120000"})
+
+	// the args of the sweep are shared by all the jobs, so they should not change
+	assert.Equal(t, submitArgs.EnvironmentVariable, []string{"EPOCHS=10", "LR=1"})
+	assert.Equal(t, submitArgs.Labels, map[string]string{"team": "vision"})
+}
+
+func TestGetSweepName(t *testing.T) {
+	now := time.Date(2021, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, getSweepName("lr-sweep", now), "lr-sweep-20210101120000")
+
+	longName := getSweepName("a-very-long-job-name-prefix-which-is-longer-than-a-label-value", now)
+	assert.Equal(t, len(longName) <= maxLabelValueLength, true)
+	assert.Equal(t, longName, "a-very-long-job-name-prefix-which-is-longer-than-20210101120000")
+}
+
+func TestRunSweepSubmissions(t *testing.T) {
+	parameterSets := []map[string]string{}
+	for i := 0; i < 10; i++ {
+		parameterSets = append(parameterSets, map[string]string{"INDEX": fmt.Sprint(i)})
+	}
+
+	var lock sync.Mutex
+	running, maxRunning := 0, 0
+	results := runSweepSubmissions(parameterSets, 3, func(parameterSet map[string]string) (string, error) {
+		lock.Lock()
+		running++
+		if running > maxRunning {
+			maxRunning = running
+		}
+		lock.Unlock()
+
+		time.Sleep(10 * time.Millisecond)
+
+		lock.Lock()
+		running--
+		lock.Unlock()
+
+		if parameterSet["INDEX"] == "4" {
+			return "", fmt.Errorf("quota exceeded")
+		}
+		return "job-" + parameterSet["INDEX"], nil
+	})
+
+	assert.Equal(t, maxRunning <= 3, true)
+	assert.Equal(t, len(results), len(parameterSets))
+	for i, result := range results {
+		if i == 4 {
+			assert.Equal(t, result, sweepJobView{Status: sweepJobFailed, Parameters: "INDEX=4", Error: "quota exceeded"})
+			continue
+		}
+		assert.Equal(t, result, sweepJobView{Name: fmt.Sprintf("job-%d", i), Status: sweepJobSubmitted, Parameters: fmt.Sprintf("INDEX=%d", i)})
+	}
+}
@@ -0,0 +1,133 @@
+package submit
+
+import (
+	"context"
+	"fmt"
+
+	raUtil "github.com/run-ai/runai-cli/cmd/util"
+	"github.com/run-ai/runai-cli/pkg/localcode"
+	"github.com/run-ai/runai-cli/pkg/ui"
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	defaultSyncLocalImage     = "busybox:1.33"
+	defaultSyncLocalDirectory = "/code"
+	localCodeArchiveKey       = "code.tar.gz"
+	// maxLocalCodeSize is the size limit of the packed code, as a config map is limited to 1MiB including its metadata
+	maxLocalCodeSize = 1000 * 1024
+	// localCodeChecksumLength is the length of the checksum prefix in the name of the config map of the code
+	localCodeChecksumLength = 32
+
+	LocalCodeLabel = "runai/local-code"
+)
+
+// localCodeValues is the local code of the job, which is unpacked by an init container, in the format of the charts
+type localCodeValues struct {
+	ConfigMap string `yaml:"configMap"`
+	Directory string `yaml:"directory"`
+	Image     string `yaml:"image"`
+}
+
+// packLocalCode packs the directory of --sync-local, to be unpacked in the working directory of the job
+func (submitArgs *submitArgs) packLocalCode() error {
+	submitArgs.LocalCode = nil
+	submitArgs.localCodeArchive = nil
+	if submitArgs.SyncLocal == "" {
+		return nil
+	}
+	if submitArgs.GitSync != nil && raUtil.IsBoolPTrue(submitArgs.GitSync.Sync) {
+		return fmt.Errorf("--sync-local and --git-sync can't be used together")
+	}
+
+	archive, err := localcode.Pack(submitArgs.SyncLocal)
+	if err != nil {
+		return fmt.Errorf("could not pack the code of %s: %v", submitArgs.SyncLocal, err)
+	}
+	if len(archive.Content) > maxLocalCodeSize {
+		return fmt.Errorf("the packed code of %s is %s, which is larger than the limit of %s. Add large files to .gitignore, or use --git-sync or a volume instead",
+			submitArgs.SyncLocal, ui.ByteCountIEC(int64(len(archive.Content))), ui.ByteCountIEC(maxLocalCodeSize))
+	}
+	log.Debugf("Packed %d files of %s, checksum %s", archive.Files, submitArgs.SyncLocal, archive.Checksum)
+
+	if submitArgs.WorkingDir == "" {
+		submitArgs.WorkingDir = defaultSyncLocalDirectory
+	}
+	submitArgs.localCodeArchive = archive
+	submitArgs.LocalCode = &localCodeValues{
+		ConfigMap: localCodeConfigMapName(archive.Checksum),
+		Directory: submitArgs.WorkingDir,
+		Image:     defaultSyncLocalImage,
+	}
+	return nil
+}
+
+// localCodeConfigMapName returns the name of the config map of the code, which is the same for unchanged code
+func localCodeConfigMapName(checksum string) string {
+	return fmt.Sprintf("runai-code-%s", checksum[:localCodeChecksumLength])
+}
+
+// uploadLocalCode creates the config map of the packed code, unless the same code has already been uploaded. It
+// returns the config map and whether it has been created. Nothing is uploaded in a dry run.
+func (submitArgs *submitArgs) uploadLocalCode(clientset kubernetes.Interface) (*corev1.ConfigMap, bool, error) {
+	archive := submitArgs.localCodeArchive
+	if archive == nil || isDryRun() {
+		return nil, false, nil
+	}
+
+	name := submitArgs.LocalCode.ConfigMap
+	configMap, err := clientset.CoreV1().ConfigMaps(submitArgs.Namespace).Get(context.TODO(), name, metav1.GetOptions{})
+	if err == nil {
+		fmt.Printf("The code of %s is unchanged, using the code which has already been uploaded\n", submitArgs.SyncLocal)
+		return configMap, false, nil
+	}
+	if !errors.IsNotFound(err) {
+		return nil, false, err
+	}
+
+	configMap = &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: map[string]string{LocalCodeLabel: archive.Checksum[:localCodeChecksumLength]},
+		},
+		BinaryData: map[string][]byte{localCodeArchiveKey: archive.Content},
+	}
+	configMap, err = clientset.CoreV1().ConfigMaps(submitArgs.Namespace).Create(context.TODO(), configMap, metav1.CreateOptions{})
+	if errors.IsAlreadyExists(err) {
+		// the same code has been uploaded by another submission in the meantime
+		configMap, err = clientset.CoreV1().ConfigMaps(submitArgs.Namespace).Get(context.TODO(), name, metav1.GetOptions{})
+		return configMap, false, err
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("could not upload the code of %s: %v", submitArgs.SyncLocal, err)
+	}
+	fmt.Printf("Uploaded %d files of %s (%s)\n", archive.Files, submitArgs.SyncLocal, ui.ByteCountIEC(int64(len(archive.Content))))
+	return configMap, true, nil
+}
+
+// setLocalCodeOwners adds the config maps of the jobs to the owners of the config map of the code, so it is deleted
+// along with the last job which uses it. A config map which has been created for jobs which were not submitted is
+// deleted.
+func setLocalCodeOwners(clientset kubernetes.Interface, namespace string, configMap *corev1.ConfigMap, created bool, jobNames []string) error {
+	if configMap == nil {
+		return nil
+	}
+	if len(jobNames) == 0 {
+		if !created {
+			return nil
+		}
+		return clientset.CoreV1().ConfigMaps(namespace).Delete(context.TODO(), configMap.Name, metav1.DeleteOptions{})
+	}
+
+	owners, err := getJobOwnerReferences(clientset, namespace, jobNames)
+	if err != nil {
+		return err
+	}
+	configMap.OwnerReferences = append(configMap.OwnerReferences, owners...)
+	_, err = clientset.CoreV1().ConfigMaps(namespace).Update(context.TODO(), configMap, metav1.UpdateOptions{})
+	return err
+}
@@ -0,0 +1,90 @@
+package submit
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	raUtil "github.com/run-ai/runai-cli/cmd/util"
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newLocalCodeDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "sync-local")
+	assert.NilError(t, err)
+	assert.NilError(t, ioutil.WriteFile(filepath.Join(dir, "train.py"), []byte("print('train')"), 0644))
+	return dir
+}
+
+func TestPackLocalCode(t *testing.T) {
+	dir := newLocalCodeDir(t)
+	defer os.RemoveAll(dir)
+
+	args := &submitArgs{SyncLocal: dir}
+	assert.NilError(t, args.packLocalCode())
+	assert.Equal(t, args.WorkingDir, defaultSyncLocalDirectory)
+	assert.Equal(t, args.LocalCode.Directory, defaultSyncLocalDirectory)
+	assert.Equal(t, args.LocalCode.ConfigMap, localCodeConfigMapName(args.localCodeArchive.Checksum))
+
+	// the code is unpacked in the working directory of the job
+	args = &submitArgs{SyncLocal: dir, WorkingDir: "/workspace"}
+	assert.NilError(t, args.packLocalCode())
+	assert.Equal(t, args.LocalCode.Directory, "/workspace")
+
+	gitSync := NewGitSync()
+	gitSync.Sync = raUtil.BoolP(true)
+	args = &submitArgs{SyncLocal: dir, GitSync: gitSync}
+	assert.ErrorContains(t, args.packLocalCode(), "can't be used together")
+}
+
+func TestUploadLocalCode(t *testing.T) {
+	dir := newLocalCodeDir(t)
+	defer os.RemoveAll(dir)
+
+	args := &submitArgs{SyncLocal: dir, Namespace: "runai-team-a"}
+	assert.NilError(t, args.packLocalCode())
+
+	clientset := fake.NewSimpleClientset()
+	configMap, created, err := args.uploadLocalCode(clientset)
+	assert.NilError(t, err)
+	assert.Assert(t, created)
+	assert.DeepEqual(t, configMap.BinaryData[localCodeArchiveKey], args.localCodeArchive.Content)
+
+	// unchanged code is not uploaded again
+	configMap, created, err = args.uploadLocalCode(clientset)
+	assert.NilError(t, err)
+	assert.Assert(t, !created)
+	assert.Equal(t, configMap.Name, args.LocalCode.ConfigMap)
+}
+
+func TestSetLocalCodeOwners(t *testing.T) {
+	configMap := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "runai-code-0123", Namespace: "runai-team-a"}}
+	clientset := fake.NewSimpleClientset(configMap,
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "train1", Namespace: "runai-team-a", UID: "uid-1"}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "train2", Namespace: "runai-team-a", UID: "uid-2"}})
+
+	assert.NilError(t, setLocalCodeOwners(clientset, "runai-team-a", configMap, true, []string{"train1"}))
+	updated, err := clientset.CoreV1().ConfigMaps("runai-team-a").Get(context.TODO(), configMap.Name, metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, len(updated.OwnerReferences), 1)
+
+	// a job which reuses the code is added to its owners
+	assert.NilError(t, setLocalCodeOwners(clientset, "runai-team-a", updated, false, []string{"train2"}))
+	updated, err = clientset.CoreV1().ConfigMaps("runai-team-a").Get(context.TODO(), configMap.Name, metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, len(updated.OwnerReferences), 2)
+
+	// reused code is kept when no job has been submitted, and code which has been uploaded for no job is deleted
+	assert.NilError(t, setLocalCodeOwners(clientset, "runai-team-a", updated, false, []string{}))
+	_, err = clientset.CoreV1().ConfigMaps("runai-team-a").Get(context.TODO(), configMap.Name, metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.NilError(t, setLocalCodeOwners(clientset, "runai-team-a", updated, true, []string{}))
+	_, err = clientset.CoreV1().ConfigMaps("runai-team-a").Get(context.TODO(), configMap.Name, metav1.GetOptions{})
+	assert.Assert(t, errors.IsNotFound(err))
+}
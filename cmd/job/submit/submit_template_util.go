@@ -49,6 +49,15 @@ func mergeTemplateToCommonSubmitArgs(submitArgs submitArgs, template *templates.
 	submitArgs.MemoryLimit = applyTemplateFieldForString(submitArgs.MemoryLimit, template.MemoryLimit, "memory-limit")
 	submitArgs.Ports = append(submitArgs.Ports, template.Ports...)
 	submitArgs.PersistentVolumes = append(submitArgs.PersistentVolumes, template.PersistentVolumes...)
+	submitArgs.Tolerations = append(submitArgs.Tolerations, template.Tolerations...)
+	// the template node selectors are set last, so they override the node selectors of the user
+	submitArgs.NodeSelectors = append(submitArgs.NodeSelectors, template.NodeSelectors...)
+	submitArgs.NodeAffinity = append(submitArgs.NodeAffinity, template.NodeAffinity...)
+	submitArgs.PreferredNodeAffinity = append(submitArgs.PreferredNodeAffinity, template.PreferredNodeAffinity...)
+	submitArgs.EnvFromSecrets = append(submitArgs.EnvFromSecrets, template.EnvFromSecrets...)
+	submitArgs.SecretEnvironmentVariables = append(submitArgs.SecretEnvironmentVariables, template.SecretEnvironmentVariables...)
+	submitArgs.SecretMounts = append(submitArgs.SecretMounts, template.SecretMounts...)
+	submitArgs.ConfigMapMounts = append(submitArgs.ConfigMapMounts, template.ConfigMapMounts...)
 	submitArgs.WorkingDir = applyTemplateFieldForString(submitArgs.WorkingDir, template.WorkingDir, "working-dir")
 	submitArgs.NamePrefix = applyTemplateFieldForString(submitArgs.NamePrefix, template.JobNamePrefix, "job-name-prefix")
 	submitArgs.PreventPrivilegeEscalation = applyTemplateFieldForBool(submitArgs.PreventPrivilegeEscalation, template.PreventPrivilegeEscalation, "prevent-privilege-escalation")
@@ -72,6 +81,10 @@ func mergeGitSync(submitArgs *submitArgs, templateGitSync *templates.GitSyncTemp
 	submitArgs.GitSync.Revision = applyTemplateFieldForString(submitArgs.GitSync.Revision, templateGitSync.Revision, "git-sync.revision")
 	submitArgs.GitSync.Username = applyTemplateFieldForString(submitArgs.GitSync.Username, templateGitSync.Username, "git-sync.username")
 	submitArgs.GitSync.Password = applyTemplateFieldForString(submitArgs.GitSync.Password, templateGitSync.Password, "git-sync.password")
+	submitArgs.GitSync.Token = applyTemplateFieldForString(submitArgs.GitSync.Token, templateGitSync.Token, "git-sync.token")
+	submitArgs.GitSync.SSHKeyFile = applyTemplateFieldForString(submitArgs.GitSync.SSHKeyFile, templateGitSync.SSHKey, "git-sync.ssh-key")
+	submitArgs.GitSync.KnownHostsFile = applyTemplateFieldForString(submitArgs.GitSync.KnownHostsFile, templateGitSync.KnownHosts, "git-sync.known-hosts")
+	submitArgs.GitSync.Secret = applyTemplateFieldForString(submitArgs.GitSync.Secret, templateGitSync.Secret, "git-sync.secret")
 	submitArgs.GitSync.Image = applyTemplateFieldForString(submitArgs.GitSync.Image, templateGitSync.Image, "git-sync.image")
 	submitArgs.GitSync.Directory = applyTemplateFieldForString(submitArgs.GitSync.Directory, templateGitSync.Directory, "git-sync.target")
 }
@@ -10,7 +10,9 @@ import (
 	rsrch_cs "github.com/run-ai/researcher-service/server/pkg/runai/client"
 	"github.com/run-ai/runai-cli/cmd/flags"
 	"github.com/run-ai/runai-cli/cmd/job"
+	"github.com/run-ai/runai-cli/cmd/trainer"
 	"github.com/run-ai/runai-cli/cmd/util"
+	"github.com/run-ai/runai-cli/pkg/authentication"
 	"github.com/run-ai/runai-cli/pkg/authentication/assertion"
 	"github.com/run-ai/runai-cli/pkg/client"
 	"github.com/run-ai/runai-cli/pkg/rsrch_client"
@@ -26,6 +28,7 @@ type directCommand func(rsrch_server.Interface, context.Context, []rsrch_server.
 // NewSuspendCommand creates a new suspend command for cobra to suspend jobs.
 func NewSuspendCommand() *cobra.Command {
 	var isAll bool
+	var mine bool
 
 	var command = &cobra.Command{
 		Use:               "suspend JOB_NAME",
@@ -33,11 +36,12 @@ func NewSuspendCommand() *cobra.Command {
 		ValidArgsFunction: job.GenJobNames,
 		PreRun:            commandUtil.NamespacedRoleAssertion(assertion.AssertExecutorRole),
 		Run: func(cmd *cobra.Command, args []string) {
-			suspendWorkflowHelper(cmd, args, rsrch_server.Interface.SuspendJobs, "suspend", isAll)
+			suspendWorkflowHelper(cmd, args, rsrch_server.Interface.SuspendJobs, "suspend", isAll, mine)
 		},
 	}
 
 	command.Flags().BoolVarP(&isAll, "all", "A", false, "Suspend all jobs")
+	command.Flags().BoolVar(&mine, "mine", false, "With --all, suspend only the jobs submitted by the current user")
 
 	return command
 }
@@ -45,6 +49,7 @@ func NewSuspendCommand() *cobra.Command {
 // NewResumeCommand creates a new resume command for cobra to resume jobs.
 func NewResumeCommand() *cobra.Command {
 	var isAll bool
+	var mine bool
 
 	var command = &cobra.Command{
 		Use:               "resume JOB_NAME",
@@ -52,20 +57,25 @@ func NewResumeCommand() *cobra.Command {
 		ValidArgsFunction: job.GenJobNames,
 		PreRun:            commandUtil.NamespacedRoleAssertion(assertion.AssertExecutorRole),
 		Run: func(cmd *cobra.Command, args []string) {
-			suspendWorkflowHelper(cmd, args, rsrch_server.Interface.ResumeJobs, "resume", isAll)
+			suspendWorkflowHelper(cmd, args, rsrch_server.Interface.ResumeJobs, "resume", isAll, mine)
 		},
 	}
 
 	command.Flags().BoolVarP(&isAll, "all", "A", false, "Resume all jobs")
+	command.Flags().BoolVar(&mine, "mine", false, "With --all, resume only the jobs submitted by the current user")
 
 	return command
 }
 
-func suspendWorkflowHelper(cmd *cobra.Command, args []string, directCmd directCommand, cmdName string, isAll bool) {
+func suspendWorkflowHelper(cmd *cobra.Command, args []string, directCmd directCommand, cmdName string, isAll bool, mine bool) {
 	if !isAll && len(args) == 0 {
 		cmd.HelpFunc()(cmd, args)
 		os.Exit(1)
 	}
+	if mine && !isAll {
+		fmt.Println("--mine can be used only with --all")
+		os.Exit(1)
+	}
 
 	kubeClient, err := client.GetClient()
 	if err != nil {
@@ -87,15 +97,44 @@ func suspendWorkflowHelper(cmd *cobra.Command, args []string, directCmd directCo
 	projectName := util.ToProject(namespaceInfo.Namespace)
 	jobNamesToSuspend := args
 
-	if isAll {
+	if isAll && mine {
+		currentUser, err := authentication.GetCurrentUser()
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		jobNamesToSuspend, err = job.ListJobNames(kubeClient, namespaceInfo, trainer.JobListOptions{User: currentUser})
+		if err != nil {
+			log.Error(err)
+			os.Exit(1)
+		}
+		if len(jobNamesToSuspend) == 0 {
+			fmt.Printf("No jobs of user %s in project %s.\n", currentUser, projectName)
+			return
+		}
+	} else if isAll {
 		jobNamesToSuspend, err = job.ListJobNamesByNamespace(kubeClient, namespaceInfo)
 		if err != nil {
 			log.Error(err)
 			os.Exit(1)
 		}
 	}
-	jobs := make([]rsrch_server.ResourceID, 0, len(jobNamesToSuspend))
-	for _, jobName := range jobNamesToSuspend {
+	if err = runJobsAction(kubeClient, projectName, jobNamesToSuspend, directCmd, cmdName); err != nil {
+		log.Error(err)
+	}
+}
+
+// SuspendJobs suspends the jobs of the project, and prints whether each job was suspended
+func SuspendJobs(kubeClient *client.Client, projectName string, jobNames []string) error {
+	if !pkgUtil.CheckComponentVersion("runai-job-controller", ">=v0.1.8", kubeClient) {
+		return fmt.Errorf("runai job controller version should be >=0.1.8")
+	}
+	return runJobsAction(kubeClient, projectName, jobNames, rsrch_server.Interface.SuspendJobs, "suspend")
+}
+
+func runJobsAction(kubeClient *client.Client, projectName string, jobNames []string, directCmd directCommand, cmdName string) error {
+	jobs := make([]rsrch_server.ResourceID, 0, len(jobNames))
+	for _, jobName := range jobNames {
 		jobs = append(jobs, rsrch_server.ResourceID{
 			Name:    jobName,
 			Project: projectName,
@@ -104,8 +143,7 @@ func suspendWorkflowHelper(cmd *cobra.Command, args []string, directCmd directCo
 
 	clientSet, err := rsrch_cs.NewCliClientFromConfig(kubeClient.GetRestConfig())
 	if err != nil {
-		log.Errorf("Failed to create clientSet for in-house CLI job %s: %v", cmdName, err.Error())
-		return
+		return fmt.Errorf("Failed to create clientSet for in-house CLI job %s: %v", cmdName, err.Error())
 	}
 	cmdStatuses := directCmd(clientSet, context.TODO(), jobs)
 	for _, status := range cmdStatuses {
@@ -123,4 +161,5 @@ func suspendWorkflowHelper(cmd *cobra.Command, args []string, directCmd directCo
 			}
 		}
 	}
+	return nil
 }
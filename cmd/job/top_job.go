@@ -16,10 +16,13 @@ package job
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"reflect"
+	"time"
 
 	"github.com/run-ai/runai-cli/cmd/completion"
+	"github.com/run-ai/runai-cli/pkg/authentication"
 	"github.com/run-ai/runai-cli/pkg/authentication/assertion"
 	"github.com/run-ai/runai-cli/pkg/jobs"
 	commandUtil "github.com/run-ai/runai-cli/pkg/util/command"
@@ -81,6 +84,10 @@ var usageFormatters = map[string]ui.FormatFunction{
 // TopCommand top command
 func TopCommand() *cobra.Command {
 	var allNamespaces bool
+	var watch bool
+	var mine bool
+	var refreshInterval time.Duration
+	var output ui.OutputOpt
 	var command = &cobra.Command{
 		Use:               "jobs",
 		Aliases:           []string{"job"},
@@ -88,6 +95,10 @@ func TopCommand() *cobra.Command {
 		ValidArgsFunction: completion.NoArgs,
 		PreRun:            commandUtil.RoleAssertion(assertion.AssertViewerRole),
 		Run: func(cmd *cobra.Command, args []string) {
+			if err := validateWatchOutput(watch, output); err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
 
 			kubeClient, err := client.GetClient()
 			if err != nil {
@@ -103,50 +114,87 @@ func TopCommand() *cobra.Command {
 				os.Exit(1)
 			}
 
-			var (
-				jobs []trainer.TrainingJob
-			)
+			var listOptions trainer.JobListOptions
+			if mine {
+				if listOptions.User, err = authentication.GetCurrentUser(); err != nil {
+					fmt.Println(err)
+					os.Exit(1)
+				}
+			}
 
-			cmdUtil.PrintShowingJobsInNamespaceMessageByStatuses(namespaceInfo, v1.PodRunning)
+			if output.IsTable() {
+				cmdUtil.PrintShowingJobsInNamespaceMessageByStatuses(namespaceInfo, v1.PodRunning)
+			}
 
-			jobs, err = trainer.GetAllJobs(kubeClient, namespaceInfo, []v1.PodPhase{v1.PodRunning})
+			promClient, err := prom.BuildMetricsClient(kubeClient)
 			if err != nil {
-				log.Errorf("Failed due to %v", err)
-				os.Exit(1)
+				log.Errorf("Error while creating prometheus client: %v", err)
+			}
+
+			printTopJobs := func(w io.Writer, jobs []trainer.TrainingJob, _ []string) ([]string, error) {
+				jobs = trainer.MakeTrainingJobOrderdByGPUCount(trainer.MakeTrainingJobOrderdByName(jobs))
+				rowKeys := []string{}
+				for _, job := range jobs {
+					rowKeys = append(rowKeys, getJobRowKey(job.Project(), job.Name()))
+				}
+				// TODO(cheyang): Support different job describer, such as MPI job/tf job describer
+				return rowKeys, topTrainingJob(w, promClient, jobs, output)
 			}
 
-			jobs = trainer.MakeTrainingJobOrderdByGPUCount(trainer.MakeTrainingJobOrderdByName(jobs))
-			// TODO(cheyang): Support different job describer, such as MPI job/tf job describer
-			topTrainingJob(kubeClient, jobs)
+			source := newJobsSource(kubeClient, namespaceInfo, listOptions, []v1.PodPhase{v1.PodRunning})
+			if watch {
+				err = watchJobsTable(kubeClient, namespaceInfo.Namespace, refreshInterval, source, printTopJobs)
+			} else {
+				var jobs []trainer.TrainingJob
+				if jobs, err = source.list(); err != nil {
+					err = fmt.Errorf("Failed due to %v", err)
+				} else {
+					_, err = printTopJobs(os.Stdout, jobs, nil)
+				}
+			}
+			if err != nil {
+				log.Error(err)
+				os.Exit(1)
+			}
 		},
 	}
 
 	command.Flags().BoolVarP(&allNamespaces, "all-projects", "A", false, "show all projects.")
+	command.Flags().BoolVarP(&watch, "watch", "w", false, "keep the information updated as jobs change.")
+	command.Flags().BoolVar(&mine, "mine", false, "show only the jobs submitted by the current user.")
+	command.Flags().DurationVar(&refreshInterval, "refresh-interval", 10*time.Second, "how often to refresh the jobs metrics in watch mode.")
+	flags.AddOutputFlags(command.Flags(), &output)
 
 	return command
 }
 
-func topTrainingJob(client *client.Client, jobInfoList []trainer.TrainingJob) {
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-
-	promClient, err := prom.BuildMetricsClient(client)
-	if err != nil {
-		log.Errorf("Error while creating prometheus client: %v", err)
-	}
+func topTrainingJob(out io.Writer, promClient *prom.Client, jobInfoList []trainer.TrainingJob, output ui.OutputOpt) error {
 	rows, err := jobs.GetJobsMetrics(promClient, jobInfoList)
 	if err != nil {
 		log.Warnf("Error while reading jobs metrics: %v\n", err)
 	}
+
+	if !output.IsTable() {
+		return ui.PrintList(out, rows, ui.ListOpt{Kind: "job", NamePath: "{.info.name}"}, output)
+	}
+
+	hiddenFields := []string{"Info.Status"}
+	if output.IsWide() {
+		hiddenFields = []string{}
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
 	err = ui.CreateTable(types.JobView{}, ui.TableOpt{
 		DisplayOpt: ui.DisplayOpt{
 			HideAllByDefault: false,
-			Hide:             []string{"Info.Status"},
+			Hide:             hiddenFields,
 		},
-		Formatts: usageFormatters,
+		Formatts:  usageFormatters,
+		NoHeaders: output.NoHeaders,
 	}).Render(w, rows).Error()
 	if err != nil {
 		log.Errorf("Error while printing top jobs: %v", err)
 	}
 
-	_ = w.Flush()
+	return w.Flush()
 }
@@ -0,0 +1,202 @@
+package job
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/run-ai/runai-cli/cmd/constants"
+	"github.com/run-ai/runai-cli/cmd/flags"
+	"github.com/run-ai/runai-cli/cmd/util"
+	"github.com/run-ai/runai-cli/pkg/authentication/assertion"
+	"github.com/run-ai/runai-cli/pkg/client"
+	"github.com/run-ai/runai-cli/pkg/types"
+	commandUtil "github.com/run-ai/runai-cli/pkg/util/command"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+const (
+	WaitForRunning   = "running"
+	WaitForSucceeded = "succeeded"
+	WaitForFailed    = "failed"
+	WaitForCompleted = "completed"
+	WaitForDeleted   = "deleted"
+
+	// Exit codes of the wait command. Any other error exits with 1, like the rest of the commands.
+	WaitConditionNotMetExitCode = 2
+	WaitTimeoutExitCode         = 3
+)
+
+var waitConditions = []string{WaitForRunning, WaitForSucceeded, WaitForFailed, WaitForCompleted, WaitForDeleted}
+
+// NewWaitCommand creates a new wait command for cobra to block until a job reaches a given state.
+func NewWaitCommand() *cobra.Command {
+	var condition string
+	var timeout time.Duration
+
+	var command = &cobra.Command{
+		Use:   "wait JOB_NAME",
+		Short: "Wait for a job to reach a specific state.",
+		Long: fmt.Sprintf(`Wait for a job to reach a specific state.
+
+Exit codes:
+  0  the job reached the requested state
+  1  an error occurred
+  %d  the job reached a state from which the requested state can no longer be reached
+  %d  the timeout expired`, WaitConditionNotMetExitCode, WaitTimeoutExitCode),
+		Example: `  # Wait for a job to start running
+  runai wait my-job --for=running
+
+  # Wait up to an hour for a training job to finish successfully
+  runai wait my-job --for=succeeded --timeout=1h`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: GenJobNames,
+		PreRun:            commandUtil.RoleAssertion(assertion.AssertViewerRole),
+		Run: func(cmd *cobra.Command, args []string) {
+			if !isValidWaitCondition(condition) {
+				fmt.Printf("Invalid value for --for: %s, supported values are %s\n", condition, strings.Join(waitConditions, ", "))
+				os.Exit(1)
+			}
+
+			kubeClient, err := client.GetClient()
+			if err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+
+			namespaceInfo, err := flags.GetNamespaceToUseFromProjectFlag(cmd, kubeClient)
+			if err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+
+			name := args[0]
+			status, met, err := WaitForJob(kubeClient, name, namespaceInfo, condition, timeout)
+			if err == errWaitTimeout {
+				fmt.Printf("Timed out waiting for job %s to be %s, current status is %s\n", name, condition, status)
+				os.Exit(WaitTimeoutExitCode)
+			}
+			if err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+			if !met {
+				fmt.Printf("Job %s is %s and will not be %s\n", name, status, condition)
+				os.Exit(WaitConditionNotMetExitCode)
+			}
+			fmt.Printf("Job %s is %s\n", name, status)
+		},
+	}
+
+	command.Flags().StringVar(&condition, "for", WaitForCompleted, fmt.Sprintf("The state to wait for: %s.", strings.Join(waitConditions, "|")))
+	command.Flags().DurationVar(&timeout, "timeout", 0, "The maximum time to wait, e.g. 30s, 5m or 1h. Zero means wait forever.")
+
+	return command
+}
+
+var errWaitTimeout = fmt.Errorf("timed out waiting for the condition")
+
+// WaitForJob blocks until the job reaches the condition, or until it reaches a state from which the condition can no longer be met.
+// Instead of polling, it watches the objects of the job through informers and re-evaluates the job status from their caches whenever one of them changes.
+// It returns the last status of the job and whether the condition was met.
+func WaitForJob(kubeClient *client.Client, name string, namespaceInfo types.NamespaceInfo, condition string, timeout time.Duration) (string, bool, error) {
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+
+	changed := make(chan struct{}, 1)
+	jobInformers := startJobInformers(kubeClient, namespaceInfo.Namespace, name, newChangeNotifier(changed), stopCh)
+
+	var timeoutCh <-chan time.Time
+	if timeout > 0 {
+		timeoutCh = time.After(timeout)
+	}
+
+	syncTimeout := watchSyncTimeout
+	if timeout > 0 && timeout < syncTimeout {
+		syncTimeout = timeout
+	}
+	waitForJobInformersSync(jobInformers.synced(), syncTimeout)
+
+	firstEvaluation := true
+	var status string
+	for {
+		job, err := jobInformers.getJob(namespaceInfo.Namespace, name)
+		if err != nil {
+			return status, false, err
+		}
+		if job != nil {
+			status = GetJobRealStatus(job)
+		} else {
+			if firstEvaluation && condition != WaitForDeleted {
+				return "", false, util.GetJobDoesNotExistsInNamespaceError(name, namespaceInfo)
+			}
+			status = constants.Status.Deleted
+		}
+		firstEvaluation = false
+
+		log.Debugf("Job %s status is %s", name, status)
+		if done, met := checkWaitCondition(condition, status); done {
+			return status, met, nil
+		}
+
+		select {
+		case <-changed:
+		case <-timeoutCh:
+			return status, false, errWaitTimeout
+		}
+	}
+}
+
+// checkWaitCondition returns whether the wait is done for the given job status, and if so whether the condition was met
+func checkWaitCondition(condition, status string) (done bool, met bool) {
+	is := func(expected string) bool {
+		// legacy trainers report their status in upper case
+		return strings.EqualFold(status, expected)
+	}
+	deleted := is(constants.Status.Deleted)
+	// a preempted job is requeued, so it is not finished
+	finished := deleted || is(constants.Status.Succeeded) || is(constants.Status.Failed) || is(constants.Status.TimedOut)
+
+	switch condition {
+	case WaitForRunning:
+		if is(constants.Status.Running) {
+			return true, true
+		}
+		return finished, false
+	case WaitForSucceeded:
+		if is(constants.Status.Succeeded) {
+			return true, true
+		}
+		return finished, false
+	case WaitForFailed:
+		if is(constants.Status.Failed) || is(constants.Status.TimedOut) {
+			return true, true
+		}
+		return finished, false
+	case WaitForCompleted:
+		if is(constants.Status.Succeeded) || is(constants.Status.Failed) || is(constants.Status.TimedOut) {
+			return true, true
+		}
+		return finished, false
+	case WaitForDeleted:
+		return deleted, deleted
+	}
+	return true, false
+}
+
+// IsWaitConditionMet returns whether a job with the given status meets the condition
+func IsWaitConditionMet(condition, status string) bool {
+	_, met := checkWaitCondition(condition, status)
+	return met
+}
+
+func isValidWaitCondition(condition string) bool {
+	for _, waitCondition := range waitConditions {
+		if condition == waitCondition {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,40 @@
+package job
+
+import (
+	"testing"
+
+	"github.com/run-ai/runai-cli/cmd/constants"
+)
+
+func TestCheckWaitCondition(t *testing.T) {
+	tests := []struct {
+		condition string
+		status    string
+		done      bool
+		met       bool
+	}{
+		{condition: WaitForRunning, status: constants.Status.Pending, done: false, met: false},
+		{condition: WaitForRunning, status: constants.Status.Running, done: true, met: true},
+		{condition: WaitForRunning, status: "RUNNING", done: true, met: true},
+		{condition: WaitForRunning, status: constants.Status.Failed, done: true, met: false},
+		{condition: WaitForSucceeded, status: constants.Status.Running, done: false, met: false},
+		{condition: WaitForSucceeded, status: constants.Status.Succeeded, done: true, met: true},
+		{condition: WaitForSucceeded, status: constants.Status.Failed, done: true, met: false},
+		{condition: WaitForSucceeded, status: constants.Status.Deleted, done: true, met: false},
+		{condition: WaitForFailed, status: constants.Status.TimedOut, done: true, met: true},
+		{condition: WaitForFailed, status: constants.Status.Succeeded, done: true, met: false},
+		{condition: WaitForCompleted, status: constants.Status.Failed, done: true, met: true},
+		{condition: WaitForCompleted, status: constants.Status.Succeeded, done: true, met: true},
+		{condition: WaitForCompleted, status: constants.Status.Preempted, done: false, met: false},
+		{condition: WaitForRunning, status: constants.Status.Preempted, done: false, met: false},
+		{condition: WaitForDeleted, status: constants.Status.Succeeded, done: false, met: false},
+		{condition: WaitForDeleted, status: constants.Status.Deleted, done: true, met: true},
+	}
+
+	for _, tt := range tests {
+		done, met := checkWaitCondition(tt.condition, tt.status)
+		if done != tt.done || met != tt.met {
+			t.Errorf("wait for %s with status %s: expected done=%v met=%v, got done=%v met=%v", tt.condition, tt.status, tt.done, tt.met, done, met)
+		}
+	}
+}
@@ -0,0 +1,349 @@
+package job
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/run-ai/runai-cli/cmd/trainer"
+	"github.com/run-ai/runai-cli/pkg/client"
+	"github.com/run-ai/runai-cli/pkg/types"
+	"github.com/run-ai/runai-cli/pkg/ui"
+	"github.com/run-ai/runai-cli/pkg/workflow"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/crypto/ssh/terminal"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/tools/cache"
+)
+
+const (
+	// changes usually arrive in bursts (e.g. a job and all of its pods), wait for them to settle before redrawing
+	watchSettleDuration = 500 * time.Millisecond
+	clearScreen         = "\033[H\033[2J"
+	deletedRowMarker    = "(deleted)"
+	watchSyncTimeout    = 30 * time.Second
+)
+
+func validateWatchOutput(watch bool, output ui.OutputOpt) error {
+	if err := output.Validate(); err != nil {
+		return err
+	}
+	if watch && !output.IsTable() {
+		return fmt.Errorf("--watch is supported only with the default and wide outputs")
+	}
+	return nil
+}
+
+// jobsSource lists the jobs of a watched table, and gets a single job of it when the objects of the job change
+type jobsSource struct {
+	// list returns all the jobs of the table
+	list func() ([]trainer.TrainingJob, error)
+	// get returns the job, or nil when it is not a job of the table
+	get func(namespace, name string) (trainer.TrainingJob, error)
+}
+
+// newJobsSource returns a source of the jobs which match the options, and whose status is one of the statuses
+// when they are set
+func newJobsSource(kubeClient *client.Client, namespaceInfo types.NamespaceInfo, options trainer.JobListOptions, statuses []v1.PodPhase) jobsSource {
+	return jobsSource{
+		list: func() ([]trainer.TrainingJob, error) {
+			return trainer.GetJobs(kubeClient, namespaceInfo, options, statuses)
+		},
+		get: func(namespace, name string) (trainer.TrainingJob, error) {
+			job, err := trainer.SearchTrainingJob(kubeClient, name, "", types.NamespaceInfo{Namespace: namespace, ProjectName: namespaceInfo.ProjectName})
+			if err != nil {
+				return nil, err
+			}
+			if !options.MatchesJob(job) || (len(statuses) > 0 && !containsStatus(statuses, job.GetStatus())) {
+				return nil, nil
+			}
+			return job, nil
+		},
+	}
+}
+
+func containsStatus(statuses []v1.PodPhase, status string) bool {
+	for _, s := range statuses {
+		if string(s) == status {
+			return true
+		}
+	}
+	return false
+}
+
+// renderJobsTable renders the table of the jobs, and returns the keys of its rows, by getJobRowKey, in their order
+type renderJobsTable func(w io.Writer, jobs []trainer.TrainingJob, invalidJobs []string) ([]string, error)
+
+// getJobRowKey returns the key of the row of a job in a watched table
+func getJobRowKey(project, name string) string {
+	return fmt.Sprintf("%s/%s", project, name)
+}
+
+// watchJobsTable prints the table of the jobs of source, rendered by render, and prints it again whenever jobs or
+// their pods change, and every refreshInterval when it is positive. The jobs are listed once, and then only the jobs
+// whose objects changed are read again. The jobs which failed to be created are told by the job config maps in the
+// cache of their informer. It returns only when listing or rendering the jobs fails.
+func watchJobsTable(kubeClient *client.Client, namespace string, refreshInterval time.Duration, source jobsSource, render renderJobsTable) error {
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+
+	changes := newJobChanges()
+	synced := startJobInformers(kubeClient, namespace, "", changes, stopCh).synced()
+
+	// job config maps are watched as well, to show jobs which failed to be created
+	configMapsFactory := informers.NewSharedInformerFactoryWithOptions(kubeClient.GetClientset(), 0, informers.WithNamespace(namespace), informers.WithTweakListOptions(func(options *metav1.ListOptions) {
+		options.LabelSelector = workflow.BaseNameLabelSelectorName
+	}))
+	configMapsInformer := configMapsFactory.Core().V1().ConfigMaps().Informer()
+	configMapsInformer.AddEventHandler(changes)
+	configMapsFactory.Start(stopCh)
+	synced = append(synced, configMapsInformer.HasSynced)
+
+	// the informers add all the existing objects when they start, which are covered by listing the jobs
+	waitForJobInformersSync(synced, watchSyncTimeout)
+	changes.take()
+	jobs := newWatchedJobs(source)
+	if err := jobs.relist(); err != nil {
+		return err
+	}
+
+	var refreshCh <-chan time.Time
+	if refreshInterval > 0 {
+		ticker := time.NewTicker(refreshInterval)
+		defer ticker.Stop()
+		refreshCh = ticker.C
+	}
+
+	printer := newWatchPrinter(os.Stdout, terminal.IsTerminal(int(os.Stdout.Fd())))
+	for {
+		var table bytes.Buffer
+		rowKeys, err := render(&table, jobs.list(), jobs.invalidJobs(configMapsInformer.GetStore().List()))
+		if err != nil {
+			return err
+		}
+		printer.print(table.String(), rowKeys)
+
+		select {
+		case <-changes.changed:
+			time.Sleep(watchSettleDuration)
+			select {
+			case <-changes.changed:
+			default:
+			}
+		case <-refreshCh:
+		}
+
+		keys, relist := changes.take()
+		if relist {
+			if err := jobs.relist(); err != nil {
+				return err
+			}
+		} else {
+			jobs.refresh(keys)
+		}
+	}
+}
+
+// watchedJobs are the jobs of a watched table, by their namespace/name keys
+type watchedJobs struct {
+	source jobsSource
+	jobs   map[string]trainer.TrainingJob
+}
+
+func newWatchedJobs(source jobsSource) *watchedJobs {
+	return &watchedJobs{
+		source: source,
+		jobs:   map[string]trainer.TrainingJob{},
+	}
+}
+
+// relist replaces the jobs with all the jobs of the source
+func (wj *watchedJobs) relist() error {
+	jobs, err := wj.source.list()
+	if err != nil {
+		return err
+	}
+	wj.jobs = map[string]trainer.TrainingJob{}
+	for _, job := range jobs {
+		wj.jobs[fmt.Sprintf("%s/%s", job.Namespace(), job.Name())] = job
+	}
+	return nil
+}
+
+// refresh gets the jobs of the keys again. A job which can't be got is removed, as it is usually being deleted, and
+// it is added back on the next change of its objects otherwise.
+func (wj *watchedJobs) refresh(keys []string) {
+	for _, key := range keys {
+		namespace, name, err := cache.SplitMetaNamespaceKey(key)
+		if err != nil {
+			continue
+		}
+		job, err := wj.source.get(namespace, name)
+		if err != nil {
+			log.Debugf("Failed to get job %s: %v", key, err)
+		}
+		if job == nil {
+			delete(wj.jobs, key)
+			continue
+		}
+		wj.jobs[key] = job
+	}
+}
+
+func (wj *watchedJobs) list() []trainer.TrainingJob {
+	jobs := []trainer.TrainingJob{}
+	for _, job := range wj.jobs {
+		jobs = append(jobs, job)
+	}
+	return jobs
+}
+
+// invalidJobs returns the names of the jobs whose config maps exist without the jobs, after they should have been created
+func (wj *watchedJobs) invalidJobs(configMaps []interface{}) []string {
+	invalidJobs := []string{}
+	for _, obj := range configMaps {
+		configMap, ok := obj.(*v1.ConfigMap)
+		if !ok || configMap.Labels[workflow.BaseNameLabelSelectorName] == "" {
+			continue
+		}
+		if _, found := wj.jobs[fmt.Sprintf("%s/%s", configMap.Namespace, configMap.Name)]; !found && isJobCreationTimePass(configMap) {
+			invalidJobs = append(invalidJobs, configMap.Name)
+		}
+	}
+	return invalidJobs
+}
+
+// jobChanges is an event handler which collects the keys of the jobs whose objects changed, and notifies the changed
+// channel. The notification is not blocking, so bursts of events are coalesced into a single notification.
+type jobChanges struct {
+	lock    sync.Mutex
+	keys    map[string]bool
+	relist  bool
+	changed chan struct{}
+}
+
+func newJobChanges() *jobChanges {
+	return &jobChanges{
+		keys:    map[string]bool{},
+		changed: make(chan struct{}, 1),
+	}
+}
+
+func (jc *jobChanges) OnAdd(obj interface{}) {
+	jc.add(obj)
+}
+
+func (jc *jobChanges) OnUpdate(_, newObj interface{}) {
+	jc.add(newObj)
+}
+
+func (jc *jobChanges) OnDelete(obj interface{}) {
+	jc.add(obj)
+}
+
+// add collects the key of the job of the object, or marks the jobs to be listed again when the job of the object
+// can't be told
+func (jc *jobChanges) add(obj interface{}) {
+	jc.lock.Lock()
+	if key, found := getJobKeyOfObject(obj); found {
+		jc.keys[key] = true
+	} else {
+		jc.relist = true
+	}
+	jc.lock.Unlock()
+
+	select {
+	case jc.changed <- struct{}{}:
+	default:
+	}
+}
+
+// take returns the collected keys, and whether the jobs should be listed again, and clears them
+func (jc *jobChanges) take() ([]string, bool) {
+	jc.lock.Lock()
+	defer jc.lock.Unlock()
+
+	keys := []string{}
+	for key := range jc.keys {
+		keys = append(keys, key)
+	}
+	relist := jc.relist
+	jc.keys = map[string]bool{}
+	jc.relist = false
+	return keys, relist
+}
+
+// watchPrinter redraws the whole table in place on a terminal. Otherwise, e.g. when piped, it prints only the lines
+// which changed since the previous print, so the headers are printed once and then only new or updated rows. A row
+// whose job is gone is printed again marked as deleted.
+type watchPrinter struct {
+	out     io.Writer
+	inPlace bool
+	// the rows and the lines printed by the previous print by their keys, and the keys in the order they were printed
+	printedRows  map[string]string
+	printedLines map[string]string
+	printedKeys  []string
+}
+
+func newWatchPrinter(out io.Writer, inPlace bool) *watchPrinter {
+	return &watchPrinter{
+		out:          out,
+		inPlace:      inPlace,
+		printedRows:  map[string]string{},
+		printedLines: map[string]string{},
+	}
+}
+
+// print prints the table, whose last lines are the rows of rowKeys, and whose other lines are its headers
+func (wp *watchPrinter) print(table string, rowKeys []string) {
+	if wp.inPlace {
+		fmt.Fprint(wp.out, clearScreen+table)
+		return
+	}
+
+	lines := []string{}
+	for _, line := range strings.Split(strings.TrimRight(table, "\n"), "\n") {
+		if !isTableBorder(line) {
+			lines = append(lines, line)
+		}
+	}
+	headers := len(lines) - len(rowKeys)
+
+	currentRows := map[string]string{}
+	currentLines := map[string]string{}
+	currentKeys := []string{}
+	for i, line := range lines {
+		// the column widths may change between prints, so rows are compared without their padding
+		row := strings.Join(strings.Fields(line), " ")
+		key := row
+		if i >= headers && headers >= 0 {
+			key = rowKeys[i-headers]
+		}
+		currentRows[key] = row
+		currentLines[key] = line
+		currentKeys = append(currentKeys, key)
+		if printedRow, printed := wp.printedRows[key]; !printed || printedRow != row {
+			fmt.Fprintln(wp.out, line)
+		}
+	}
+
+	for _, key := range wp.printedKeys {
+		if _, found := currentRows[key]; !found {
+			fmt.Fprintf(wp.out, "%s  %s\n", strings.TrimRight(wp.printedLines[key], " "), deletedRowMarker)
+		}
+	}
+	wp.printedRows = currentRows
+	wp.printedLines = currentLines
+	wp.printedKeys = currentKeys
+}
+
+// isTableBorder returns true for the lines which underline the titles of ui tables
+func isTableBorder(line string) bool {
+	return strings.Trim(line, "─│ ") == ""
+}
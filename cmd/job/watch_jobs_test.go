@@ -0,0 +1,178 @@
+package job
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/run-ai/runai-cli/cmd/trainer"
+	cmdTypes "github.com/run-ai/runai-cli/pkg/types"
+	"github.com/run-ai/runai-cli/pkg/workflow"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestWatchPrinterAppendsChangedRows(t *testing.T) {
+	var out bytes.Buffer
+	printer := newWatchPrinter(&out, false)
+
+	printer.print("NAME  STATUS\njob1  Pending\njob2  Running\n", []string{"a/job1", "a/job2"})
+	printer.print("NAME      STATUS\njob1      Running\njob2      Running\nlong-job  Pending\n", []string{"a/job1", "a/job2", "a/long-job"})
+
+	expected := "NAME  STATUS\njob1  Pending\njob2  Running\njob1      Running\nlong-job  Pending\n"
+	if out.String() != expected {
+		t.Errorf("expected output:\n%s\ngot:\n%s", expected, out.String())
+	}
+}
+
+func TestWatchPrinterSkipsTableBorders(t *testing.T) {
+	var out bytes.Buffer
+	printer := newWatchPrinter(&out, false)
+
+	printer.print("NAME  STATUS\n────  ──────\njob1  Pending\n", []string{"a/job1"})
+	printer.print("NAME  STATUS\n────  ──────\njob1  Running\n", []string{"a/job1"})
+
+	expected := "NAME  STATUS\njob1  Pending\njob1  Running\n"
+	if out.String() != expected {
+		t.Errorf("expected output:\n%s\ngot:\n%s", expected, out.String())
+	}
+}
+
+func TestWatchPrinterMarksDeletedRows(t *testing.T) {
+	var out bytes.Buffer
+	printer := newWatchPrinter(&out, false)
+
+	printer.print("NAME  STATUS\njob1  Running\njob2  Running\n", []string{"a/job1", "a/job2"})
+	printer.print("NAME  STATUS\njob2  Succeeded\n", []string{"a/job2"})
+
+	expected := "NAME  STATUS\njob1  Running\njob2  Running\njob2  Succeeded\njob1  Running  " + deletedRowMarker + "\n"
+	if out.String() != expected {
+		t.Errorf("expected output:\n%s\ngot:\n%s", expected, out.String())
+	}
+}
+
+func TestWatchPrinterKeysRowsByProjectAndName(t *testing.T) {
+	var out bytes.Buffer
+	printer := newWatchPrinter(&out, false)
+
+	printer.print("NAME  PROJECT  STATUS\njob1  a        Running\njob1  b        Pending\n", []string{"a/job1", "b/job1"})
+	printer.print("NAME  PROJECT  STATUS\njob1  b        Running\n", []string{"b/job1"})
+
+	expected := "NAME  PROJECT  STATUS\njob1  a        Running\njob1  b        Pending\njob1  b        Running\njob1  a        Running  " + deletedRowMarker + "\n"
+	if out.String() != expected {
+		t.Errorf("expected output:\n%s\ngot:\n%s", expected, out.String())
+	}
+}
+
+func TestWatchPrinterRedrawsInPlace(t *testing.T) {
+	var out bytes.Buffer
+	printer := newWatchPrinter(&out, true)
+
+	printer.print("NAME  STATUS\njob1  Pending\n", []string{"a/job1"})
+	printer.print("NAME  STATUS\njob1  Running\n", []string{"a/job1"})
+
+	expected := clearScreen + "NAME  STATUS\njob1  Pending\n" + clearScreen + "NAME  STATUS\njob1  Running\n"
+	if out.String() != expected {
+		t.Errorf("expected output:\n%q\ngot:\n%q", expected, out.String())
+	}
+}
+
+func newTestJob(namespace, name, status string) trainer.TrainingJob {
+	return trainer.NewRunaiWorkload(nil, nil, metav1.NewTime(time.Now()), trainer.RunaiTrainType, name, true, nil, false,
+		v1.PodSpec{}, metav1.ObjectMeta{}, metav1.ObjectMeta{Name: name, Namespace: namespace}, namespace, cmdTypes.Resource{}, status, 1, 1, 0, 0)
+}
+
+func jobNames(jobs []trainer.TrainingJob) []string {
+	names := []string{}
+	for _, job := range jobs {
+		names = append(names, fmt.Sprintf("%s/%s:%s", job.Namespace(), job.Name(), job.GetStatus()))
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestWatchedJobsRefreshOnlyChangedJobs(t *testing.T) {
+	listed := 0
+	var got []string
+	cluster := map[string]trainer.TrainingJob{
+		"runai-a/job1": newTestJob("runai-a", "job1", "Pending"),
+		"runai-a/job2": newTestJob("runai-a", "job2", "Running"),
+	}
+	jobs := newWatchedJobs(jobsSource{
+		list: func() ([]trainer.TrainingJob, error) {
+			listed++
+			result := []trainer.TrainingJob{}
+			for _, job := range cluster {
+				result = append(result, job)
+			}
+			return result, nil
+		},
+		get: func(namespace, name string) (trainer.TrainingJob, error) {
+			got = append(got, name)
+			job, found := cluster[namespace+"/"+name]
+			if !found {
+				return nil, fmt.Errorf("job %s not found", name)
+			}
+			return job, nil
+		},
+	})
+	if err := jobs.relist(); err != nil {
+		t.Fatal(err)
+	}
+
+	cluster["runai-a/job1"] = newTestJob("runai-a", "job1", "Running")
+	delete(cluster, "runai-a/job2")
+	cluster["runai-b/job3"] = newTestJob("runai-b", "job3", "Pending")
+	jobs.refresh([]string{"runai-a/job1", "runai-a/job2", "runai-b/job3"})
+
+	expected := []string{"runai-a/job1:Running", "runai-b/job3:Pending"}
+	if names := jobNames(jobs.list()); fmt.Sprint(names) != fmt.Sprint(expected) {
+		t.Errorf("expected the jobs %v, got %v", expected, names)
+	}
+	if listed != 1 || len(got) != 3 {
+		t.Errorf("expected the jobs to be listed once and the changed jobs to be got, listed %d times and got %v", listed, got)
+	}
+}
+
+func TestWatchedJobsInvalidJobs(t *testing.T) {
+	jobs := newWatchedJobs(jobsSource{})
+	jobs.jobs["runai-a/job1"] = newTestJob("runai-a", "job1", "Running")
+	configMap := func(name string, age time.Duration) interface{} {
+		return &v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         "runai-a",
+			Labels:            map[string]string{workflow.BaseNameLabelSelectorName: name},
+			CreationTimestamp: metav1.NewTime(time.Now().Add(-age)),
+		}}
+	}
+
+	invalidJobs := jobs.invalidJobs([]interface{}{configMap("job1", time.Hour), configMap("job2", time.Hour), configMap("job3", time.Second)})
+	if fmt.Sprint(invalidJobs) != "[job2]" {
+		t.Errorf("expected only the job which was not created in time to be invalid, got %v", invalidJobs)
+	}
+}
+
+func TestJobChangesCollectsJobKeys(t *testing.T) {
+	changes := newJobChanges()
+	changes.OnAdd(&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "job1-0", Namespace: "runai-a", Labels: map[string]string{"release": "job1"}}})
+	changes.OnDelete(&v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "job1", Namespace: "runai-a"}})
+
+	select {
+	case <-changes.changed:
+	default:
+		t.Fatal("expected a change to be notified")
+	}
+	keys, relist := changes.take()
+	if fmt.Sprint(keys) != "[runai-a/job1]" || relist {
+		t.Errorf("expected the key of the changed job, got %v (relist %v)", keys, relist)
+	}
+
+	controller := true
+	changes.OnUpdate(nil, &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "deploy1-5d8f-x", Namespace: "runai-a",
+		OwnerReferences: []metav1.OwnerReference{{Kind: "ReplicaSet", Name: "deploy1-5d8f", Controller: &controller}}}})
+	if _, relist = changes.take(); !relist {
+		t.Errorf("expected the jobs to be listed again when the job of a change is unknown")
+	}
+}
@@ -0,0 +1,203 @@
+package logs
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/run-ai/runai-cli/cmd/job"
+	"github.com/run-ai/runai-cli/cmd/trainer"
+	"github.com/run-ai/runai-cli/pkg/podlogs"
+	log "github.com/sirupsen/logrus"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	describeFileName = "describe.txt"
+	eventsFileName   = "events.txt"
+)
+
+// logFile is the log of a container of a pod, or of its previous instance
+type logFile struct {
+	pod       string
+	container string
+	previous  bool
+}
+
+func (file logFile) name() string {
+	if file.previous {
+		return fmt.Sprintf("%s/%s.previous.log", file.pod, file.container)
+	}
+	return fmt.Sprintf("%s/%s.log", file.pod, file.container)
+}
+
+// getLogFiles returns the logs of all the containers of the pods, including the init containers, and the logs of the
+// previous instances of the containers which have been restarted
+func getLogFiles(pods []v1.Pod) []logFile {
+	files := []logFile{}
+	for _, pod := range pods {
+		restarted := map[string]bool{}
+		for _, status := range append(append([]v1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...) {
+			restarted[status.Name] = status.RestartCount > 0 || status.LastTerminationState.Terminated != nil
+		}
+		for _, container := range append(append([]v1.Container{}, pod.Spec.InitContainers...), pod.Spec.Containers...) {
+			files = append(files, logFile{pod: pod.Name, container: container.Name})
+			if restarted[container.Name] {
+				files = append(files, logFile{pod: pod.Name, container: container.Name, previous: true})
+			}
+		}
+	}
+	return files
+}
+
+// logsBundle is a directory or an archive of the logs of a job
+type logsBundle interface {
+	// writeFile writes a file, relative to the bundle, with the content which is written by write
+	writeFile(name string, write func(w io.Writer) error) error
+	close() error
+}
+
+type directoryBundle struct {
+	directory string
+}
+
+func (bundle *directoryBundle) writeFile(name string, write func(w io.Writer) error) error {
+	filePath := filepath.Join(bundle.directory, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		return err
+	}
+	file, err := os.Create(filePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	return write(file)
+}
+
+func (bundle *directoryBundle) close() error {
+	return nil
+}
+
+// archiveBundle is a gzipped tarball of the logs
+type archiveBundle struct {
+	file       *os.File
+	gzipWriter *gzip.Writer
+	tarWriter  *tar.Writer
+}
+
+func newArchiveBundle(archivePath string) (*archiveBundle, error) {
+	file, err := os.Create(archivePath)
+	if err != nil {
+		return nil, err
+	}
+	gzipWriter := gzip.NewWriter(file)
+	return &archiveBundle{file: file, gzipWriter: gzipWriter, tarWriter: tar.NewWriter(gzipWriter)}, nil
+}
+
+func (bundle *archiveBundle) writeFile(name string, write func(w io.Writer) error) error {
+	// the size of a tarball entry precedes its content, so the content is spooled to a temporary file first, as logs
+	// may be too large to be kept in memory
+	content, err := ioutil.TempFile("", "runai-logs-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(content.Name())
+	defer content.Close()
+
+	// an entry whose content failed to be written is skipped
+	if err := write(content); err != nil {
+		return err
+	}
+	size, err := content.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return err
+	}
+	if _, err := content.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	header := &tar.Header{Typeflag: tar.TypeReg, Name: name, Mode: 0644, Size: size, ModTime: time.Now()}
+	if err := bundle.tarWriter.WriteHeader(header); err != nil {
+		return err
+	}
+	_, err = io.Copy(bundle.tarWriter, content)
+	return err
+}
+
+func (bundle *archiveBundle) close() error {
+	if err := bundle.tarWriter.Close(); err != nil {
+		return err
+	}
+	if err := bundle.gzipWriter.Close(); err != nil {
+		return err
+	}
+	return bundle.file.Close()
+}
+
+// exportLogs writes the description and the events of the job, and the logs of all the containers of its pods, to
+// a directory or to an archive. Logs which can't be fetched are reported and skipped.
+func exportLogs(clientset kubernetes.Interface, trainingJob trainer.TrainingJob, pods []v1.Pod, logArgs *podlogs.OuterRequestArgs, outputDir, archivePath string) error {
+	var bundle logsBundle
+	if archivePath != "" {
+		archive, err := newArchiveBundle(archivePath)
+		if err != nil {
+			return err
+		}
+		bundle = archive
+	} else {
+		if err := os.MkdirAll(outputDir, 0755); err != nil {
+			return err
+		}
+		bundle = &directoryBundle{directory: outputDir}
+	}
+
+	err := bundle.writeFile(describeFileName, func(w io.Writer) error {
+		job.PrintJobDescription(w, clientset, trainingJob)
+		return nil
+	})
+	if err != nil {
+		bundle.close()
+		return err
+	}
+	err = bundle.writeFile(eventsFileName, func(w io.Writer) error {
+		job.PrintJobEvents(w, clientset, trainingJob)
+		return nil
+	})
+	if err != nil {
+		bundle.close()
+		return err
+	}
+
+	exported := 0
+	for _, file := range getLogFiles(pods) {
+		fileArgs := *logArgs
+		fileArgs.PodName = file.pod
+		fileArgs.Container = file.container
+		fileArgs.Previous = file.previous
+		podLog, err := podlogs.NewPodLog(&fileArgs)
+		if err != nil {
+			bundle.close()
+			return err
+		}
+		if err = bundle.writeFile(file.name(), podLog.WriteLogs); err != nil {
+			log.Warnf("Failed to export the logs of %s: %v", file.name(), err)
+			continue
+		}
+		exported++
+	}
+
+	if err = bundle.close(); err != nil {
+		return err
+	}
+	destination := outputDir
+	if archivePath != "" {
+		destination = archivePath
+	}
+	fmt.Printf("Exported the description, the events and %d logs of job %s to %s\n", exported, trainingJob.Name(), destination)
+	return nil
+}
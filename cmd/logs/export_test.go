@@ -0,0 +1,137 @@
+package logs
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGetLogFiles(t *testing.T) {
+	pods := []v1.Pod{{
+		ObjectMeta: metav1.ObjectMeta{Name: "train1-0"},
+		Spec: v1.PodSpec{
+			InitContainers: []v1.Container{{Name: "git-sync"}},
+			Containers:     []v1.Container{{Name: "train1"}, {Name: "sidecar"}},
+		},
+		Status: v1.PodStatus{
+			ContainerStatuses: []v1.ContainerStatus{
+				{Name: "train1", RestartCount: 2},
+				{Name: "sidecar", LastTerminationState: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{Reason: "OOMKilled"}}},
+			},
+		},
+	}}
+
+	names := []string{}
+	for _, file := range getLogFiles(pods) {
+		names = append(names, file.name())
+	}
+	expected := []string{
+		"train1-0/git-sync.log",
+		"train1-0/train1.log",
+		"train1-0/train1.previous.log",
+		"train1-0/sidecar.log",
+		"train1-0/sidecar.previous.log",
+	}
+	if !reflect.DeepEqual(names, expected) {
+		t.Errorf("expected %v, got %v", expected, names)
+	}
+}
+
+func writeString(content string) func(w io.Writer) error {
+	return func(w io.Writer) error {
+		_, err := io.WriteString(w, content)
+		return err
+	}
+}
+
+func TestDirectoryBundle(t *testing.T) {
+	directory, err := ioutil.TempDir("", "logs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(directory)
+
+	bundle := &directoryBundle{directory: directory}
+	if err = bundle.writeFile("train1-0/train1.log", writeString("epoch 1\n")); err != nil {
+		t.Fatal(err)
+	}
+	content, err := ioutil.ReadFile(filepath.Join(directory, "train1-0", "train1.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != "epoch 1\n" {
+		t.Errorf("expected the log to be written, got %q", content)
+	}
+}
+
+func TestArchiveBundle(t *testing.T) {
+	directory, err := ioutil.TempDir("", "logs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(directory)
+	archivePath := filepath.Join(directory, "logs.tar.gz")
+
+	bundle, err := newArchiveBundle(archivePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = bundle.writeFile(describeFileName, writeString("NAME: train1\n")); err != nil {
+		t.Fatal(err)
+	}
+	failure := errors.New("the container has not started")
+	err = bundle.writeFile("train1-0/train1.log", func(w io.Writer) error {
+		io.WriteString(w, "epoch 1\n")
+		return failure
+	})
+	if err != failure {
+		t.Errorf("expected the error of the log to be returned, got %v", err)
+	}
+	if err = bundle.writeFile("train1-1/train1.log", writeString("epoch 2\n")); err != nil {
+		t.Fatal(err)
+	}
+	if err = bundle.close(); err != nil {
+		t.Fatal(err)
+	}
+
+	file, err := os.Open(archivePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	gzipReader, err := gzip.NewReader(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tarReader := tar.NewReader(gzipReader)
+	files := map[string]string{}
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		content, err := ioutil.ReadAll(tarReader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		files[header.Name] = string(content)
+	}
+
+	// the log which failed to be written is skipped
+	expected := map[string]string{describeFileName: "NAME: train1\n", "train1-1/train1.log": "epoch 2\n"}
+	if !reflect.DeepEqual(files, expected) {
+		t.Errorf("expected %v, got %v", expected, files)
+	}
+}
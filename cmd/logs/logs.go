@@ -29,12 +29,20 @@ import (
 	"github.com/run-ai/runai-cli/pkg/client"
 	"github.com/run-ai/runai-cli/pkg/podlogs"
 	tlogs "github.com/run-ai/runai-cli/pkg/printer/base/logs"
+	"github.com/run-ai/runai-cli/pkg/ui"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
+	"golang.org/x/crypto/ssh/terminal"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
 )
 
 func NewLogsCommand() *cobra.Command {
 	var outerArgs = &podlogs.OuterRequestArgs{}
+	var allPods bool
+	var selector string
+	var outputDir, archivePath string
 	var command = &cobra.Command{
 		Use:    "logs JOB_NAME",
 		Short:  "Print the logs of a job.",
@@ -76,26 +84,63 @@ func NewLogsCommand() *cobra.Command {
 			outerArgs.Namespace = namespaceInfo.Namespace
 			outerArgs.RetryCount = 5
 			outerArgs.RetryTimeout = time.Millisecond
-			names := []string{}
-			for _, pod := range job.AllPods() {
-				names = append(names, pod.Name)
+			if outerArgs.AllContainers && outerArgs.Container != "" {
+				fmt.Println("--container can't be used together with --all-containers")
+				os.Exit(1)
+			}
+			if outputDir != "" || archivePath != "" {
+				exportJobLogs(clientset, job, outerArgs, selector, outputDir, archivePath)
+				return
 			}
+			if allPods || selector != "" {
+				if outerArgs.PodName != "" {
+					fmt.Println("--pod can't be used together with --all-pods or --selector")
+					os.Exit(1)
+				}
+				podSelector, err := labels.Parse(selector)
+				if err != nil {
+					fmt.Println(err)
+					os.Exit(1)
+				}
+				listPods := func() ([]string, error) {
+					job, err := trainer.SearchTrainingJob(kubeClient, name, "", namespaceInfo)
+					if err != nil {
+						return nil, err
+					}
+					return getPodNames(job, podSelector), nil
+				}
+				logPrinter := tlogs.NewMultiPodLogPrinter(outerArgs, listPods, os.Stdout, terminal.IsTerminal(int(os.Stdout.Fd())))
+				printLogs(logPrinter)
+				return
+			}
+
+			names := getPodNames(job, labels.Everything())
 			chiefPod := job.ChiefPod()
 			if len(names) > 1 && outerArgs.PodName == "" {
 				names = []string{chiefPod.ObjectMeta.Name}
 			}
+			if outerArgs.AllContainers {
+				podNames := names
+				if outerArgs.PodName != "" {
+					if !ui.Contains(names, outerArgs.PodName) {
+						fmt.Printf("pod %s is not found in job %s\n", outerArgs.PodName, name)
+						os.Exit(1)
+					}
+					podNames = []string{outerArgs.PodName}
+				}
+				listPods := func() ([]string, error) {
+					return podNames, nil
+				}
+				logPrinter := tlogs.NewMultiPodLogPrinter(outerArgs, listPods, os.Stdout, terminal.IsTerminal(int(os.Stdout.Fd())))
+				printLogs(logPrinter)
+				return
+			}
 			logPrinter, err := tlogs.NewPodLogPrinter(names, outerArgs)
 			if err != nil {
 				log.Errorf(err.Error())
 				os.Exit(1)
 			}
-			code, err := logPrinter.Print()
-			if err != nil {
-				log.Errorf("%s, %s", err.Error(), "please use \"runai describe job\" to get more information.")
-				os.Exit(1)
-			} else if code != 0 {
-				os.Exit(code)
-			}
+			printLogs(logPrinter)
 		},
 	}
 
@@ -110,7 +155,21 @@ func NewLogsCommand() *cobra.Command {
 	command.Flags().IntVarP(&outerArgs.Tail, "tail", "t", -1, "Return a specific number of log lines.")
 	completion.AddFlagDescrpition(command, "tail", "Specify number of log lines")
 
-	command.Flags().BoolVar(&outerArgs.Timestamps, "timestamps", false, "Include timestamps on each line in the log output.")
+	command.Flags().BoolVar(&outerArgs.Timestamps, "timestamps", false, "Include timestamps on each line in the log output. The logs of several pods are merged by their timestamps, unless they are followed.")
+
+	command.Flags().BoolVar(&allPods, "all-pods", false, "Print the logs of all the pods of the job, prefixed by the pod names. When following the logs, new pods of the job are followed as well.")
+	command.Flags().StringVarP(&selector, "selector", "l", "", "Print the logs of the pods of the job which match a label selector, prefixed by the pod names (e.g. -l key1=value1,key2=value2).")
+	completion.AddFlagDescrpition(command, "selector", "Specify a label selector of the pods, e.g. key1=value1,key2=value2")
+
+	command.Flags().StringVarP(&outerArgs.Container, "container", "c", "", "Print the logs of a specific container of the pods, e.g. an init container or a sidecar. Defaults to the main container.")
+	command.RegisterFlagCompletionFunc("container", job.GenContainerNames)
+
+	command.Flags().BoolVar(&outerArgs.AllContainers, "all-containers", false, "Print the logs of all the containers of the pods, including the init containers, prefixed by the container names.")
+
+	command.Flags().BoolVar(&outerArgs.Previous, "previous", false, "Print the logs of the previous instance of the containers, e.g. to find why a container has been restarted or has run out of memory.")
+
+	command.Flags().StringVar(&outputDir, "output-dir", "", "Export the logs of all the containers of the pods, including the logs of their previous instances, and the description and the events of the job, to files in a directory.")
+	command.Flags().StringVar(&archivePath, "archive", "", "Export the logs of all the containers of the pods, including the logs of their previous instances, and the description and the events of the job, to a .tar.gz archive.")
 
 	// command.Flags().StringVar(&printer.pod, "instance", "", "Only return logs after a specific date (RFC3339). Defaults to all logs. Only one of since-time / since may be used.")
 
@@ -118,3 +177,65 @@ func NewLogsCommand() *cobra.Command {
 
 	return command
 }
+
+type logPrinter interface {
+	Print() (int, error)
+}
+
+func printLogs(logPrinter logPrinter) {
+	code, err := logPrinter.Print()
+	if err != nil {
+		log.Errorf("%s, %s", err.Error(), "please use \"runai describe job\" to get more information.")
+		os.Exit(1)
+	} else if code != 0 {
+		os.Exit(code)
+	}
+}
+
+// exportJobLogs exports the logs of the pods of the job, all of them unless --pod or --selector are used
+func exportJobLogs(clientset kubernetes.Interface, job trainer.TrainingJob, outerArgs *podlogs.OuterRequestArgs, selector, outputDir, archivePath string) {
+	if outputDir != "" && archivePath != "" {
+		fmt.Println("--output-dir can't be used together with --archive")
+		os.Exit(1)
+	}
+	if outerArgs.Follow || outerArgs.Previous || outerArgs.Container != "" || outerArgs.AllContainers {
+		fmt.Println("--output-dir and --archive export the logs of all the containers and their previous instances, and can't be used together with --follow, --previous, --container or --all-containers")
+		os.Exit(1)
+	}
+	if outerArgs.PodName != "" && selector != "" {
+		fmt.Println("--pod can't be used together with --selector")
+		os.Exit(1)
+	}
+	podSelector, err := labels.Parse(selector)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	pods := []v1.Pod{}
+	for _, pod := range job.AllPods() {
+		if (outerArgs.PodName == "" || pod.Name == outerArgs.PodName) && podSelector.Matches(labels.Set(pod.Labels)) {
+			pods = append(pods, pod)
+		}
+	}
+	if outerArgs.PodName != "" && len(pods) == 0 {
+		fmt.Printf("pod %s is not found in job %s\n", outerArgs.PodName, job.Name())
+		os.Exit(1)
+	}
+
+	if err = exportLogs(clientset, job, pods, outerArgs, outputDir, archivePath); err != nil {
+		log.Error(err)
+		os.Exit(1)
+	}
+}
+
+// getPodNames returns the names of the pods of the job which match the selector
+func getPodNames(job trainer.TrainingJob, selector labels.Selector) []string {
+	names := []string{}
+	for _, pod := range job.AllPods() {
+		if selector.Matches(labels.Set(pod.Labels)) {
+			names = append(names, pod.Name)
+		}
+	}
+	return names
+}
@@ -18,6 +18,8 @@ var (
 	unhealthyGpusPath = ui.EnsureStringPaths(types.NodeView{}, []string{
 		"GPUs.Unhealthy",
 	})
+
+	nodeListOpt = ui.ListOpt{Kind: "node", NamePath: "{.info.name}"}
 )
 
 func GetNodeInfos(shouldQueryMetrics bool) (*[]nodes.NodeInfo, error) {
@@ -2,6 +2,7 @@ package node
 
 import (
 	"fmt"
+	"github.com/run-ai/runai-cli/cmd/flags"
 	"github.com/run-ai/runai-cli/pkg/authentication/assertion"
 	commandUtil "github.com/run-ai/runai-cli/pkg/util/command"
 	"os"
@@ -36,9 +37,18 @@ var (
 		"GPUs.Allocatable",
 		"GPUs.Free",
 	})
+
+	wideListNodeExtraFields = ui.EnsureStringPaths(types.NodeView{}, []string{
+		"CPUs.Capacity",
+		"Mem.Capacity",
+		"GPUs.Capacity",
+		"GPUs.Allocated",
+		"GPUMem.Capacity",
+	})
 )
 
 func ListCommand() *cobra.Command {
+	var output ui.OutputOpt
 
 	var command = &cobra.Command{
 		Use:     "nodes [...NODE_NAME]",
@@ -48,6 +58,10 @@ func ListCommand() *cobra.Command {
 		Example: listNodeExample,
 		PreRun:  commandUtil.RoleAssertion(assertion.AssertViewerRole),
 		Run: func(cmd *cobra.Command, args []string) {
+			if err := output.Validate(); err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
 
 			nodeInfos, err := GetNodeInfos(false)
 
@@ -56,19 +70,23 @@ func ListCommand() *cobra.Command {
 				os.Exit(1)
 			}
 
-			handleListSpecificNodes(nodeInfos, args...)
+			handleListSpecificNodes(nodeInfos, output, args...)
 
 		},
 	}
 
+	flags.AddOutputFlags(command.Flags(), &output)
+
 	return command
 }
 
-func handleListSpecificNodes(nodeInfos *[]nodes.NodeInfo, selectedNodeNames ...string) {
-	handleSpecificNodes(nodeInfos, listNodes, selectedNodeNames...)
+func handleListSpecificNodes(nodeInfos *[]nodes.NodeInfo, output ui.OutputOpt, selectedNodeNames ...string) {
+	handleSpecificNodes(nodeInfos, func(nodeInfos *[]nodes.NodeInfo) {
+		listNodes(nodeInfos, output)
+	}, selectedNodeNames...)
 }
 
-func listNodes(nodeInfos *[]nodes.NodeInfo) {
+func listNodes(nodeInfos *[]nodes.NodeInfo, output ui.OutputOpt) {
 	nodeViews := []types.NodeView{}
 	for _, nodeInfo := range *nodeInfos {
 
@@ -85,10 +103,23 @@ func listNodes(nodeInfos *[]nodes.NodeInfo) {
 		nodeViews = append(nodeViews, nodeView)
 	}
 
+	if !output.IsTable() {
+		if err := ui.PrintList(os.Stdout, nodeViews, nodeListOpt, output); err != nil {
+			fmt.Println(err)
+		}
+		return
+	}
+
+	showFields := showListNodeFields
+	if output.IsWide() {
+		showFields = append(showFields, wideListNodeExtraFields...)
+	}
+
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
 
 	err := ui.CreateTable(types.NodeView{}, ui.TableOpt{
-		DisplayOpt: ui.DisplayOpt{Show: showListNodeFields},
+		DisplayOpt: ui.DisplayOpt{Show: showFields},
+		NoHeaders:  output.NoHeaders,
 	}).Render(w, nodeViews).Error()
 
 	ui.End(w)
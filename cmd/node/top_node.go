@@ -16,6 +16,7 @@ package node
 
 import (
 	"fmt"
+	"github.com/run-ai/runai-cli/cmd/flags"
 	"github.com/run-ai/runai-cli/pkg/authentication/assertion"
 	commandUtil "github.com/run-ai/runai-cli/pkg/util/command"
 	"io"
@@ -64,6 +65,7 @@ var (
 )
 
 func TopCommand() *cobra.Command {
+	var output ui.OutputOpt
 
 	var command = &cobra.Command{
 		Use:     "nodes [...NODE_NAME]",
@@ -73,6 +75,10 @@ func TopCommand() *cobra.Command {
 		Args:    cobra.RangeArgs(0, 1),
 		PreRun:  commandUtil.RoleAssertion(assertion.AssertViewerRole),
 		Run: func(cmd *cobra.Command, args []string) {
+			if err := output.Validate(); err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
 
 			nodeInfos, err := GetNodeInfos(true)
 			if err != nil {
@@ -80,25 +86,25 @@ func TopCommand() *cobra.Command {
 				os.Exit(1)
 			}
 
-			handleTopSpecificNodes(nodeInfos, showDetails, args...)
+			handleTopSpecificNodes(nodeInfos, showDetails || output.IsWide(), output, args...)
 		},
 	}
 
-	command.Flags().BoolVarP(&showDetails, "details", "d", false, "Display details")
+	command.Flags().BoolVarP(&showDetails, "details", "d", false, "Display details, same as --output=wide")
+	flags.AddOutputFlags(command.Flags(), &output)
 	return command
 }
 
-func handleTopSpecificNodes(nodeInfos *[]nodes.NodeInfo, wide bool, selectedNodeNames ...string) {
+func handleTopSpecificNodes(nodeInfos *[]nodes.NodeInfo, wide bool, output ui.OutputOpt, selectedNodeNames ...string) {
 
 	handleSpecificNodes(nodeInfos, func(nodeInfos *[]nodes.NodeInfo) {
-		displayTopNodes(nodeInfos, wide, len(selectedNodeNames) == 0)
+		displayTopNodes(nodeInfos, wide, len(selectedNodeNames) == 0, output)
 	}, selectedNodeNames...)
 
 }
 
-func displayTopNodes(nodeInfos *[]nodes.NodeInfo, wide bool, showClusterData bool) {
+func displayTopNodes(nodeInfos *[]nodes.NodeInfo, wide bool, showClusterData bool, output ui.OutputOpt) {
 
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
 	clsData := types.ClusterNodesView{}
 	nodeViews := []types.NodeView{}
 	nodesToGpus := [][]types.GPU{}
@@ -123,17 +129,25 @@ func displayTopNodes(nodeInfos *[]nodes.NodeInfo, wide bool, showClusterData boo
 		nodeViews = append(nodeViews, nodeView)
 	}
 
+	if !output.IsTable() {
+		if err := ui.PrintList(os.Stdout, nodeViews, nodeListOpt, output); err != nil {
+			fmt.Println(err)
+		}
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
 	if wide {
-		displayTopNodeWide(w, nodeViews, nodesToGpus)
+		displayTopNodeWide(w, nodeViews, nodesToGpus, output.NoHeaders)
 	} else {
 		showUnhealthyGPUs := clsData.UnhealthyGPUs == 0
-		displayTopNodeTable(w, nodeViews, showUnhealthyGPUs)
+		displayTopNodeTable(w, nodeViews, showUnhealthyGPUs, output.NoHeaders)
 	}
 
 	_ = w.Flush()
 }
 
-func displayTopNodeWide(w io.Writer, nodeViews []types.NodeView, nodesToGPUs [][]types.GPU) {
+func displayTopNodeWide(w io.Writer, nodeViews []types.NodeView, nodesToGPUs [][]types.GPU, noHeaders bool) {
 
 	showFields := append(commonTopNodeFields, detailedTopNodeExtraFields...)
 
@@ -158,6 +172,7 @@ func displayTopNodeWide(w io.Writer, nodeViews []types.NodeView, nodesToGPUs [][
 				DisplayOpt: ui.DisplayOpt{
 					Hide: topNodeHiddenGpusFields,
 				},
+				NoHeaders: noHeaders,
 			}).
 				Render(w, nodeGPUs).
 				Error()
@@ -169,7 +184,7 @@ func displayTopNodeWide(w io.Writer, nodeViews []types.NodeView, nodesToGPUs [][
 	ui.End(w)
 }
 
-func displayTopNodeTable(w io.Writer, rows []types.NodeView, showUnhealthyGpus bool) {
+func displayTopNodeTable(w io.Writer, rows []types.NodeView, showUnhealthyGpus bool, noHeaders bool) {
 	hiddenFields := defaultHiddenFields
 	if !showUnhealthyGpus {
 		hiddenFields = append(hiddenFields, unhealthyGpusPath...)
@@ -181,6 +196,7 @@ func displayTopNodeTable(w io.Writer, rows []types.NodeView, showUnhealthyGpus b
 			Hide:             hiddenFields,
 			Show:             append(commonTopNodeFields, tableTopNodeFields...),
 		},
+		NoHeaders: noHeaders,
 	}).Render(w, rows).Error()
 
 	if err != nil {
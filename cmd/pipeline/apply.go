@@ -0,0 +1,368 @@
+package pipeline
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+
+	"github.com/run-ai/runai-cli/cmd/flags"
+	"github.com/run-ai/runai-cli/cmd/job"
+	"github.com/run-ai/runai-cli/pkg/authentication/assertion"
+	"github.com/run-ai/runai-cli/pkg/client"
+	"github.com/run-ai/runai-cli/pkg/config"
+	"github.com/run-ai/runai-cli/pkg/types"
+	commandUtil "github.com/run-ai/runai-cli/pkg/util/command"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	yaml "gopkg.in/yaml.v2"
+)
+
+const applyExamples = `
+# Submit the stages of a pipeline, each one once the stages it runs after finish
+runai pipeline apply pipeline.yaml
+
+# An example pipeline file
+name: mnist
+stages:
+- name: preprocess
+  file: preprocess.yaml
+- name: train
+  after: [preprocess]
+  spec:
+    image: gcr.io/run-ai-demo/quickstart
+    gpu: 1
+- name: evaluate
+  after: [train]
+  afterCondition: completed
+  file: evaluate.yaml
+`
+
+func newApplyCommand() *cobra.Command {
+	var command = &cobra.Command{
+		Use:   "apply PIPELINE_FILE",
+		Short: "Submit the jobs of a pipeline in the order of their dependencies.",
+		Long: fmt.Sprintf(`Submit the jobs of a pipeline in the order of their dependencies.
+The command runs until all the stages of the pipeline finish. The progress of the pipeline is kept in the cluster, so it
+can be checked using '%[1]s pipeline status' from any machine. If the command is stopped, applying the pipeline again
+resumes it from where it stopped. A pipeline which has finished is applied again once it is deleted using
+'%[1]s pipeline delete'.
+
+The job of each stage is named PIPELINE-STAGE and is specified in the format of '%[1]s submit --file'.
+A stage is submitted once the stages it runs after are succeeded, or completed when its afterCondition is completed.`, config.CLIName),
+		Example: applyExamples,
+		Args:    cobra.ExactArgs(1),
+		PreRun:  commandUtil.NamespacedRoleAssertion(assertion.AssertExecutorRole),
+		Run: commandUtil.WrapRunCommand(func(cmd *cobra.Command, args []string) error {
+			pipeline, err := loadPipelineFile(args[0])
+			if err != nil {
+				return err
+			}
+
+			kubeClient, err := client.GetClient()
+			if err != nil {
+				return err
+			}
+
+			namespaceInfo, err := flags.GetNamespaceToUseFromProjectFlag(cmd, kubeClient)
+			if err != nil {
+				return err
+			}
+
+			submitter := &stageSubmitter{
+				run:        runCLICommand,
+				globalArgs: getGlobalArgs(cmd),
+				project:    namespaceInfo.ProjectName,
+			}
+			return applyPipeline(kubeClient, namespaceInfo, pipeline, submitter.submit)
+		}),
+	}
+
+	return command
+}
+
+func applyPipeline(kubeClient *client.Client, namespaceInfo types.NamespaceInfo, pipeline *Pipeline, submit func(pipelineName string, stage Stage) (string, error)) error {
+	clientset := kubeClient.GetClientset()
+	configMap, err := getPipelineConfigMap(clientset, namespaceInfo.Namespace, pipeline.Name)
+	if err != nil {
+		return err
+	}
+
+	var state *State
+	if configMap == nil {
+		state = pipeline.newState()
+		if configMap, err = createPipelineConfigMap(clientset, namespaceInfo.Namespace, pipeline, state); err != nil {
+			return err
+		}
+		fmt.Printf("The pipeline '%s' has been created\n", pipeline.Name)
+	} else {
+		// the pipeline may be resumed from another machine, which should apply the same pipeline
+		if err = checkPipelineUnchanged(configMap, pipeline); err != nil {
+			return err
+		}
+		if pipeline, state, err = parsePipelineConfigMap(configMap); err != nil {
+			return err
+		}
+		fmt.Printf("Resuming the pipeline '%s'\n", pipeline.Name)
+	}
+
+	s := &scheduler{
+		pipeline: pipeline,
+		state:    state,
+		submit: func(stage Stage) (string, error) {
+			return submit(pipeline.Name, stage)
+		},
+		wait: func(jobName string) (string, error) {
+			status, _, err := job.WaitForJob(kubeClient, jobName, namespaceInfo, job.WaitForCompleted, 0)
+			return status, err
+		},
+		save: func(state *State) error {
+			updated, err := updatePipelineConfigMap(clientset, namespaceInfo.Namespace, configMap, state)
+			if err == nil {
+				configMap = updated
+			}
+			return err
+		},
+	}
+	s.run()
+
+	if err = printPipelineStatus(os.Stdout, state.Stages); err != nil {
+		return err
+	}
+	fmt.Println()
+	switch state.Status {
+	case pipelineSucceeded:
+		fmt.Printf("The pipeline '%s' has succeeded\n", pipeline.Name)
+		return nil
+	case pipelineFailed:
+		return fmt.Errorf("the pipeline '%s' has failed", pipeline.Name)
+	}
+	return fmt.Errorf("the pipeline '%s' did not finish, run '%s pipeline apply' again to resume it", pipeline.Name, config.CLIName)
+}
+
+// scheduler submits the stages of a pipeline once the stages they run after finish
+type scheduler struct {
+	pipeline *Pipeline
+	state    *State
+	lock     sync.Mutex
+
+	// submit submits the job of a stage and returns its name
+	submit func(stage Stage) (string, error)
+	// wait blocks until a job finishes and returns its status
+	wait func(jobName string) (string, error)
+	// save persists the state of the pipeline
+	save func(state *State) error
+}
+
+func (s *scheduler) run() {
+	done := map[string]chan struct{}{}
+	for _, stage := range s.pipeline.Stages {
+		done[stage.Name] = make(chan struct{})
+	}
+
+	var wg sync.WaitGroup
+	for _, stage := range s.pipeline.Stages {
+		wg.Add(1)
+		go func(stage Stage) {
+			defer wg.Done()
+			defer close(done[stage.Name])
+			for _, parent := range stage.After {
+				<-done[parent]
+			}
+			s.runStage(stage)
+		}(stage)
+	}
+	wg.Wait()
+
+	s.updatePipelineStatus()
+}
+
+func (s *scheduler) runStage(stage Stage) {
+	stageState := s.getStageState(stage.Name)
+	if isStageFinished(stageState.Status) {
+		return
+	}
+
+	if stageState.Status == stagePending {
+		ready, reason := s.checkDependencies(stage)
+		if !ready {
+			return
+		}
+		if reason != "" {
+			s.updateStageState(stage.Name, "", stageSkipped, reason)
+			return
+		}
+
+		jobName, err := s.submit(stage)
+		if err != nil {
+			s.updateStageState(stage.Name, "", stageSubmitFailed, err.Error())
+			return
+		}
+		fmt.Printf("The job '%s' of stage %s has been submitted\n", jobName, stage.Name)
+		stageState = s.updateStageState(stage.Name, jobName, stageSubmitted, "")
+	}
+
+	status, err := s.wait(stageState.Job)
+	if err != nil {
+		// the stage is left submitted, so that the job is waited for again when the pipeline is resumed
+		s.updateStageState(stage.Name, stageState.Job, stageSubmitted, err.Error())
+		return
+	}
+	s.updateStageState(stage.Name, stageState.Job, status, "")
+}
+
+// checkDependencies returns whether the stages the stage runs after are finished, and if so the reason the stage
+// should be skipped, or an empty string if it should be submitted
+func (s *scheduler) checkDependencies(stage Stage) (bool, string) {
+	for _, parent := range stage.After {
+		parentState := s.getStageState(parent)
+		if !isStageFinished(parentState.Status) {
+			return false, ""
+		}
+		if parentState.Status == stageSkipped || parentState.Status == stageSubmitFailed {
+			return true, fmt.Sprintf("stage %s was not run", parent)
+		}
+		if !job.IsWaitConditionMet(stage.AfterCondition, parentState.Status) {
+			return true, fmt.Sprintf("stage %s is %s", parent, parentState.Status)
+		}
+	}
+	return true, ""
+}
+
+func (s *scheduler) getStageState(name string) StageState {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for _, stageState := range s.state.Stages {
+		if stageState.Name == name {
+			return stageState
+		}
+	}
+	return StageState{Name: name, Status: stagePending}
+}
+
+func (s *scheduler) updateStageState(name, jobName, status, message string) StageState {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	stageState := StageState{Name: name, Job: jobName, Status: status, Message: message}
+	for i := range s.state.Stages {
+		if s.state.Stages[i].Name == name {
+			s.state.Stages[i] = stageState
+		}
+	}
+	s.saveLocked()
+	return stageState
+}
+
+func (s *scheduler) updatePipelineStatus() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	status := pipelineSucceeded
+	for _, stageState := range s.state.Stages {
+		if !isStageFinished(stageState.Status) {
+			status = pipelineRunning
+			break
+		}
+		if !job.IsWaitConditionMet(job.WaitForSucceeded, stageState.Status) {
+			status = pipelineFailed
+		}
+	}
+	s.state.Status = status
+	s.saveLocked()
+}
+
+func (s *scheduler) saveLocked() {
+	if err := s.save(s.state); err != nil {
+		log.Warnf("Failed to save the state of pipeline %s: %v", s.pipeline.Name, err)
+	}
+}
+
+// stageSubmitter submits the jobs of the stages by running the submit command of the stage type, with the global
+// flags the pipeline is applied with
+type stageSubmitter struct {
+	// run runs a command of the cli with the args
+	run        func(args []string) error
+	globalArgs []string
+	project    string
+}
+
+func (ss *stageSubmitter) submit(pipelineName string, stage Stage) (string, error) {
+	jobName := stageJobName(pipelineName, stage.Name)
+	specFile, err := writeStageSpecFile(pipelineName, stage)
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(specFile)
+
+	args := append([]string{stageSubmitCommands[stage.Type], "--file", specFile, "--name", jobName, "--project", ss.project}, ss.globalArgs...)
+	log.Debugf("Submitting stage %s: %s %s", stage.Name, config.CLIName, strings.Join(args, " "))
+	if err = ss.run(args); err != nil {
+		return "", err
+	}
+	return jobName, nil
+}
+
+// runCLICommand runs a command of the cli in a child process of the running executable, with its output forwarded.
+// The commands exit on failures, so they are not run in-process, and a failure is returned as an error.
+func runCLICommand(args []string) error {
+	executable, err := os.Executable()
+	if err != nil {
+		return err
+	}
+
+	command := exec.Command(executable, args...)
+	command.Stdout = os.Stdout
+	command.Stderr = os.Stderr
+	if err = command.Run(); err != nil {
+		return fmt.Errorf("'%s %s' failed: %v", config.CLIName, args[0], err)
+	}
+	return nil
+}
+
+// getGlobalArgs returns the global flags which are set on the command line, other than the project, which is set
+// explicitly for the stages
+func getGlobalArgs(cmd *cobra.Command) []string {
+	args := []string{}
+	cmd.Root().PersistentFlags().VisitAll(func(flag *pflag.Flag) {
+		if flag.Changed && flag.Name != flags.ProjectFlag {
+			args = append(args, fmt.Sprintf("--%s=%s", flag.Name, flag.Value.String()))
+		}
+	})
+	return args
+}
+
+// writeStageSpecFile writes the spec of the stage, labeled with the pipeline name, to a temporary file
+func writeStageSpecFile(pipelineName string, stage Stage) (string, error) {
+	spec := map[string]interface{}{}
+	for key, value := range stage.Spec {
+		spec[key] = value
+	}
+	labels := map[interface{}]interface{}{}
+	if specLabels, ok := spec["labels"].(map[interface{}]interface{}); ok {
+		for key, value := range specLabels {
+			labels[key] = value
+		}
+	}
+	labels[PipelineLabel] = pipelineName
+	spec["labels"] = labels
+
+	content, err := yaml.Marshal(spec)
+	if err != nil {
+		return "", err
+	}
+
+	file, err := ioutil.TempFile("", fmt.Sprintf("%s-*.yaml", stageJobName(pipelineName, stage.Name)))
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	if _, err = file.Write(content); err != nil {
+		os.Remove(file.Name())
+		return "", err
+	}
+	return file.Name(), nil
+}
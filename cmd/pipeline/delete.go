@@ -0,0 +1,78 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/run-ai/runai-cli/cmd/flags"
+	"github.com/run-ai/runai-cli/pkg/authentication/assertion"
+	"github.com/run-ai/runai-cli/pkg/client"
+	"github.com/run-ai/runai-cli/pkg/types"
+	commandUtil "github.com/run-ai/runai-cli/pkg/util/command"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newDeleteCommand() *cobra.Command {
+	var command = &cobra.Command{
+		Use:   "delete PIPELINE_NAME",
+		Short: "Delete a pipeline and the jobs of its stages, so it can be applied again from its first stage.",
+		Example: `  # Delete pipeline mnist, e.g. to run it again once it has finished
+  runai pipeline delete mnist`,
+		Args:   cobra.ExactArgs(1),
+		PreRun: commandUtil.NamespacedRoleAssertion(assertion.AssertExecutorRole),
+		Run: commandUtil.WrapRunCommand(func(cmd *cobra.Command, args []string) error {
+			kubeClient, err := client.GetClient()
+			if err != nil {
+				return err
+			}
+
+			namespaceInfo, err := flags.GetNamespaceToUseFromProjectFlag(cmd, kubeClient)
+			if err != nil {
+				return err
+			}
+
+			globalArgs := getGlobalArgs(cmd)
+			return deletePipeline(kubeClient, namespaceInfo, args[0], func(jobNames []string) error {
+				// the jobs are deleted by the delete command, which deletes them through the researcher service when it can
+				return runCLICommand(append(append([]string{"delete", "--project", namespaceInfo.ProjectName}, globalArgs...), jobNames...))
+			})
+		}),
+	}
+
+	return command
+}
+
+// deletePipeline deletes the jobs which have been submitted for the stages of the pipeline, and then the pipeline
+func deletePipeline(kubeClient *client.Client, namespaceInfo types.NamespaceInfo, name string, deleteJobs func(jobNames []string) error) error {
+	clientset := kubeClient.GetClientset()
+	configMap, err := getPipelineConfigMap(clientset, namespaceInfo.Namespace, name)
+	if err != nil {
+		return err
+	}
+	if configMap == nil {
+		return fmt.Errorf("the pipeline '%s' does not exist in project %s", name, namespaceInfo.ProjectName)
+	}
+	_, state, err := parsePipelineConfigMap(configMap)
+	if err != nil {
+		return err
+	}
+
+	jobNames := []string{}
+	for _, stageState := range state.Stages {
+		if stageState.Job != "" {
+			jobNames = append(jobNames, stageState.Job)
+		}
+	}
+	if len(jobNames) > 0 {
+		if err = deleteJobs(jobNames); err != nil {
+			return err
+		}
+	}
+
+	if err = clientset.CoreV1().ConfigMaps(namespaceInfo.Namespace).Delete(context.TODO(), configMap.Name, metav1.DeleteOptions{}); err != nil {
+		return err
+	}
+	fmt.Printf("The pipeline '%s' has been deleted\n", name)
+	return nil
+}
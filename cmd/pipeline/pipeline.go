@@ -0,0 +1,324 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/run-ai/runai-cli/cmd/job"
+	"github.com/run-ai/runai-cli/pkg/config"
+	"github.com/spf13/cobra"
+	yaml "gopkg.in/yaml.v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// PipelineLabel is the label of the pipeline config maps and of the jobs of the pipelines, its value is the name of the pipeline
+	PipelineLabel = "runai/pipeline"
+
+	configMapPrefix  = "runai-pipeline-"
+	specDataKey      = "pipeline"
+	stateDataKey     = "state"
+	runaiStageType   = "runai"
+	mpiStageType     = "mpi"
+	defaultCondition = job.WaitForSucceeded
+
+	// statuses of a stage, once its job is finished the stage status is the status of the job
+	stagePending      = "Pending"
+	stageSubmitted    = "Submitted"
+	stageSkipped      = "Skipped"
+	stageSubmitFailed = "SubmitFailed"
+
+	pipelineRunning   = "Running"
+	pipelineSucceeded = "Succeeded"
+	pipelineFailed    = "Failed"
+)
+
+var (
+	stageTypes          = []string{runaiStageType, mpiStageType}
+	stageConditions     = []string{job.WaitForSucceeded, job.WaitForCompleted}
+	stageSubmitCommands = map[string]string{runaiStageType: "submit", mpiStageType: "submit-mpi"}
+)
+
+// Pipeline is the content of a pipeline file, a set of jobs which are submitted once the jobs they depend on finish
+type Pipeline struct {
+	Name   string  `yaml:"name"`
+	Stages []Stage `yaml:"stages"`
+}
+
+// Stage is a job of a pipeline. The job is specified in the format of 'runai submit --file', either inline or in a
+// file whose path is relative to the pipeline file.
+type Stage struct {
+	Name           string                 `yaml:"name"`
+	Type           string                 `yaml:"type,omitempty"`
+	File           string                 `yaml:"file,omitempty"`
+	Spec           map[string]interface{} `yaml:"spec,omitempty"`
+	After          []string               `yaml:"after,omitempty"`
+	AfterCondition string                 `yaml:"afterCondition,omitempty"`
+}
+
+// State is the progress of a pipeline, which is kept in the config map of the pipeline
+type State struct {
+	Status string       `yaml:"status"`
+	Stages []StageState `yaml:"stages"`
+}
+
+// StageState is the progress of a stage of a pipeline
+type StageState struct {
+	Name    string `yaml:"name"`
+	Job     string `yaml:"job,omitempty"`
+	Status  string `yaml:"status"`
+	Message string `yaml:"message,omitempty"`
+}
+
+// NewPipelineCommand creates the pipeline command. The jobs of the stages are submitted, and deleted, by running the
+// submit and delete commands of the cli.
+func NewPipelineCommand() *cobra.Command {
+	var command = &cobra.Command{
+		Use:   "pipeline",
+		Short: "Submit jobs which depend on each other as a pipeline.",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) == 0 {
+				cmd.HelpFunc()(cmd, args)
+			}
+		},
+	}
+
+	command.AddCommand(newApplyCommand())
+	command.AddCommand(newStatusCommand())
+	command.AddCommand(newDeleteCommand())
+
+	return command
+}
+
+// loadPipelineFile reads a pipeline file, and loads the job specification files of its stages
+func loadPipelineFile(fileName string) (*Pipeline, error) {
+	content, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+
+	pipeline := &Pipeline{}
+	if err = yaml.UnmarshalStrict(content, pipeline); err != nil {
+		return nil, fmt.Errorf("could not parse the pipeline file %s: %v", fileName, err)
+	}
+
+	for i := range pipeline.Stages {
+		stage := &pipeline.Stages[i]
+		if stage.File == "" {
+			continue
+		}
+		if stage.Spec != nil {
+			return nil, fmt.Errorf("either a file or a spec should be set for stage %s", stage.Name)
+		}
+
+		specFile := stage.File
+		if !filepath.IsAbs(specFile) {
+			specFile = filepath.Join(filepath.Dir(fileName), specFile)
+		}
+		specContent, err := ioutil.ReadFile(specFile)
+		if err != nil {
+			return nil, err
+		}
+		if err = yaml.Unmarshal(specContent, &stage.Spec); err != nil {
+			return nil, fmt.Errorf("could not parse the job specification file %s of stage %s: %v", specFile, stage.Name, err)
+		}
+		stage.File = ""
+	}
+
+	if err = pipeline.validate(); err != nil {
+		return nil, err
+	}
+	return pipeline, nil
+}
+
+// validate checks the pipeline and sets the default values of its stages
+func (p *Pipeline) validate() error {
+	if errs := validation.IsDNS1035Label(p.Name); len(errs) > 0 {
+		return fmt.Errorf("invalid pipeline name '%s', pipeline names must consist of lower case alphanumeric characters or '-' and start with an alphabetic character", p.Name)
+	}
+	if len(p.Stages) == 0 {
+		return fmt.Errorf("the pipeline %s has no stages", p.Name)
+	}
+
+	stages := map[string]bool{}
+	for i := range p.Stages {
+		stage := &p.Stages[i]
+		if stages[stage.Name] {
+			return fmt.Errorf("the stage %s is defined more than once", stage.Name)
+		}
+		stages[stage.Name] = true
+
+		if errs := validation.IsDNS1035Label(stageJobName(p.Name, stage.Name)); len(errs) > 0 {
+			return fmt.Errorf("invalid stage name '%s', the job of the stage is named %s, which must consist of lower case alphanumeric characters or '-' and be at most 63 characters", stage.Name, stageJobName(p.Name, stage.Name))
+		}
+		if stage.Spec == nil {
+			return fmt.Errorf("either a file or a spec should be set for stage %s", stage.Name)
+		}
+		if stage.Type == "" {
+			stage.Type = runaiStageType
+		}
+		if !contains(stageTypes, stage.Type) {
+			return fmt.Errorf("invalid type %s of stage %s, supported types are %s", stage.Type, stage.Name, strings.Join(stageTypes, ", "))
+		}
+		if stage.AfterCondition == "" {
+			stage.AfterCondition = defaultCondition
+		}
+		if !contains(stageConditions, stage.AfterCondition) {
+			return fmt.Errorf("invalid afterCondition %s of stage %s, supported values are %s", stage.AfterCondition, stage.Name, strings.Join(stageConditions, ", "))
+		}
+	}
+
+	for _, stage := range p.Stages {
+		for _, parent := range stage.After {
+			if !stages[parent] {
+				return fmt.Errorf("the stage %s runs after %s, which is not a stage of the pipeline", stage.Name, parent)
+			}
+		}
+	}
+	return p.validateAcyclic()
+}
+
+// validateAcyclic returns an error if stages depend on each other in a cycle
+func (p *Pipeline) validateAcyclic() error {
+	const (
+		visiting = iota + 1
+		visited
+	)
+	after := map[string][]string{}
+	for _, stage := range p.Stages {
+		after[stage.Name] = stage.After
+	}
+
+	marks := map[string]int{}
+	var visit func(name string, path []string) error
+	visit = func(name string, path []string) error {
+		path = append(path, name)
+		switch marks[name] {
+		case visiting:
+			return fmt.Errorf("the stages depend on each other in a cycle: %s", strings.Join(path, " -> "))
+		case visited:
+			return nil
+		}
+		marks[name] = visiting
+		for _, parent := range after[name] {
+			if err := visit(parent, path); err != nil {
+				return err
+			}
+		}
+		marks[name] = visited
+		return nil
+	}
+
+	for _, stage := range p.Stages {
+		if err := visit(stage.Name, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *Pipeline) newState() *State {
+	state := &State{Status: pipelineRunning}
+	for _, stage := range p.Stages {
+		state.Stages = append(state.Stages, StageState{Name: stage.Name, Status: stagePending})
+	}
+	return state
+}
+
+func stageJobName(pipelineName, stageName string) string {
+	return fmt.Sprintf("%s-%s", pipelineName, stageName)
+}
+
+func configMapName(pipelineName string) string {
+	return configMapPrefix + pipelineName
+}
+
+// isStageFinished returns whether the stage will not change anymore
+func isStageFinished(status string) bool {
+	return status != stagePending && status != stageSubmitted
+}
+
+// getPipelineConfigMap returns the config map of the pipeline, or nil if the pipeline does not exist
+func getPipelineConfigMap(clientset kubernetes.Interface, namespace, name string) (*corev1.ConfigMap, error) {
+	configMaps, err := clientset.CoreV1().ConfigMaps(namespace).List(context.TODO(), metav1.ListOptions{LabelSelector: fmt.Sprintf("%s=%s", PipelineLabel, name)})
+	if err != nil {
+		return nil, err
+	}
+	if len(configMaps.Items) == 0 {
+		return nil, nil
+	}
+	return &configMaps.Items[0], nil
+}
+
+func parsePipelineConfigMap(configMap *corev1.ConfigMap) (*Pipeline, *State, error) {
+	pipeline := &Pipeline{}
+	if err := yaml.Unmarshal([]byte(configMap.Data[specDataKey]), pipeline); err != nil {
+		return nil, nil, fmt.Errorf("could not parse the pipeline of config map %s: %v", configMap.Name, err)
+	}
+	state := &State{}
+	if err := yaml.Unmarshal([]byte(configMap.Data[stateDataKey]), state); err != nil {
+		return nil, nil, fmt.Errorf("could not parse the pipeline state of config map %s: %v", configMap.Name, err)
+	}
+	return pipeline, state, nil
+}
+
+// checkPipelineUnchanged returns an error when the pipeline differs from the pipeline stored in its config map
+func checkPipelineUnchanged(configMap *corev1.ConfigMap, pipeline *Pipeline) error {
+	spec, err := yaml.Marshal(pipeline)
+	if err != nil {
+		return err
+	}
+	if string(spec) != configMap.Data[specDataKey] {
+		return fmt.Errorf("the pipeline '%s' has already been applied with different stages, run '%s pipeline delete %s' to delete it before applying it again", pipeline.Name, config.CLIName, pipeline.Name)
+	}
+	return nil
+}
+
+func createPipelineConfigMap(clientset kubernetes.Interface, namespace string, pipeline *Pipeline, state *State) (*corev1.ConfigMap, error) {
+	spec, err := yaml.Marshal(pipeline)
+	if err != nil {
+		return nil, err
+	}
+	stateContent, err := yaml.Marshal(state)
+	if err != nil {
+		return nil, err
+	}
+
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   configMapName(pipeline.Name),
+			Labels: map[string]string{PipelineLabel: pipeline.Name},
+		},
+		Data: map[string]string{
+			specDataKey:  string(spec),
+			stateDataKey: string(stateContent),
+		},
+	}
+	return clientset.CoreV1().ConfigMaps(namespace).Create(context.TODO(), configMap, metav1.CreateOptions{})
+}
+
+func updatePipelineConfigMap(clientset kubernetes.Interface, namespace string, configMap *corev1.ConfigMap, state *State) (*corev1.ConfigMap, error) {
+	stateContent, err := yaml.Marshal(state)
+	if err != nil {
+		return nil, err
+	}
+
+	configMap = configMap.DeepCopy()
+	configMap.Data[stateDataKey] = string(stateContent)
+	return clientset.CoreV1().ConfigMaps(namespace).Update(context.TODO(), configMap, metav1.UpdateOptions{})
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,358 @@
+package pipeline
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/magiconair/properties/assert"
+	"github.com/run-ai/runai-cli/cmd/constants"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+const pipelineYaml = `
+name: mnist
+stages:
+- name: preprocess
+  file: preprocess.yaml
+- name: train
+  after: [preprocess]
+  spec:
+    image: gcr.io/run-ai-demo/quickstart
+    gpu: 1
+- name: evaluate
+  type: mpi
+  after: [train]
+  afterCondition: completed
+  spec:
+    image: gcr.io/run-ai-demo/quickstart
+`
+
+func newTestPipeline(stages ...Stage) *Pipeline {
+	for i := range stages {
+		stages[i].Spec = map[string]interface{}{"image": "ubuntu"}
+	}
+	return &Pipeline{Name: "mnist", Stages: stages}
+}
+
+func TestLoadPipelineFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "pipeline")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	if err = ioutil.WriteFile(filepath.Join(dir, "pipeline.yaml"), []byte(pipelineYaml), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err = ioutil.WriteFile(filepath.Join(dir, "preprocess.yaml"), []byte("image: ubuntu\ncpu: \"2\"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	pipeline, err := loadPipelineFile(filepath.Join(dir, "pipeline.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, len(pipeline.Stages), 3)
+	assert.Equal(t, pipeline.Stages[0].Spec, map[string]interface{}{"image": "ubuntu", "cpu": "2"})
+	assert.Equal(t, pipeline.Stages[0].File, "")
+	assert.Equal(t, pipeline.Stages[0].Type, runaiStageType)
+	assert.Equal(t, pipeline.Stages[1].AfterCondition, defaultCondition)
+	assert.Equal(t, pipeline.Stages[2].Type, mpiStageType)
+	assert.Equal(t, pipeline.Stages[2].AfterCondition, "completed")
+}
+
+func TestValidatePipeline(t *testing.T) {
+	tests := []struct {
+		name     string
+		pipeline *Pipeline
+		err      string
+	}{
+		{
+			name:     "valid",
+			pipeline: newTestPipeline(Stage{Name: "a"}, Stage{Name: "b", After: []string{"a"}}),
+		},
+		{
+			name:     "invalid name",
+			pipeline: &Pipeline{Name: "Mnist", Stages: newTestPipeline(Stage{Name: "a"}).Stages},
+			err:      "invalid pipeline name",
+		},
+		{
+			name:     "no stages",
+			pipeline: &Pipeline{Name: "mnist"},
+			err:      "has no stages",
+		},
+		{
+			name:     "duplicate stage",
+			pipeline: newTestPipeline(Stage{Name: "a"}, Stage{Name: "a"}),
+			err:      "defined more than once",
+		},
+		{
+			name:     "unknown stage",
+			pipeline: newTestPipeline(Stage{Name: "a", After: []string{"b"}}),
+			err:      "not a stage of the pipeline",
+		},
+		{
+			name:     "cycle",
+			pipeline: newTestPipeline(Stage{Name: "a", After: []string{"c"}}, Stage{Name: "b", After: []string{"a"}}, Stage{Name: "c", After: []string{"b"}}),
+			err:      "cycle: a -> c -> b -> a",
+		},
+		{
+			name:     "invalid condition",
+			pipeline: newTestPipeline(Stage{Name: "a", AfterCondition: "running"}),
+			err:      "invalid afterCondition",
+		},
+		{
+			name:     "invalid type",
+			pipeline: newTestPipeline(Stage{Name: "a", Type: "tf"}),
+			err:      "invalid type",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.pipeline.validate()
+			if test.err == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), test.err) {
+				t.Errorf("expected an error containing '%s', got: %v", test.err, err)
+			}
+		})
+	}
+}
+
+type fakeJobs struct {
+	lock      sync.Mutex
+	submitted []string
+	statuses  map[string]string
+}
+
+func newTestScheduler(pipeline *Pipeline, state *State, jobs *fakeJobs) *scheduler {
+	return &scheduler{
+		pipeline: pipeline,
+		state:    state,
+		submit: func(stage Stage) (string, error) {
+			jobs.lock.Lock()
+			defer jobs.lock.Unlock()
+			if jobs.statuses[stage.Name] == "" {
+				return "", fmt.Errorf("quota exceeded")
+			}
+			jobs.submitted = append(jobs.submitted, stage.Name)
+			return stageJobName(pipeline.Name, stage.Name), nil
+		},
+		wait: func(jobName string) (string, error) {
+			return jobs.statuses[strings.TrimPrefix(jobName, pipeline.Name+"-")], nil
+		},
+		save: func(state *State) error {
+			return nil
+		},
+	}
+}
+
+func TestSchedulerRunsStagesInOrder(t *testing.T) {
+	pipeline := newTestPipeline(
+		Stage{Name: "evaluate", After: []string{"train"}},
+		Stage{Name: "train", After: []string{"preprocess"}},
+		Stage{Name: "preprocess"},
+	)
+	if err := pipeline.validate(); err != nil {
+		t.Fatal(err)
+	}
+	state := pipeline.newState()
+	jobs := &fakeJobs{statuses: map[string]string{
+		"preprocess": constants.Status.Succeeded,
+		"train":      constants.Status.Succeeded,
+		"evaluate":   constants.Status.Succeeded,
+	}}
+
+	newTestScheduler(pipeline, state, jobs).run()
+
+	assert.Equal(t, jobs.submitted, []string{"preprocess", "train", "evaluate"})
+	assert.Equal(t, state.Status, pipelineSucceeded)
+	assert.Equal(t, state.Stages[0], StageState{Name: "evaluate", Job: "mnist-evaluate", Status: constants.Status.Succeeded})
+}
+
+func TestSchedulerSkipsStagesOfFailedStages(t *testing.T) {
+	pipeline := newTestPipeline(
+		Stage{Name: "preprocess"},
+		Stage{Name: "train", After: []string{"preprocess"}},
+		Stage{Name: "evaluate", After: []string{"train"}},
+		Stage{Name: "report", After: []string{"preprocess"}, AfterCondition: "completed"},
+		Stage{Name: "cleanup"},
+	)
+	if err := pipeline.validate(); err != nil {
+		t.Fatal(err)
+	}
+	state := pipeline.newState()
+	jobs := &fakeJobs{statuses: map[string]string{
+		"preprocess": constants.Status.Failed,
+		"train":      constants.Status.Succeeded,
+		"evaluate":   constants.Status.Succeeded,
+		"report":     constants.Status.Succeeded,
+	}}
+
+	newTestScheduler(pipeline, state, jobs).run()
+
+	assert.Equal(t, state.Status, pipelineFailed)
+	assert.Equal(t, state.Stages[0].Status, constants.Status.Failed)
+	assert.Equal(t, state.Stages[1], StageState{Name: "train", Status: stageSkipped, Message: "stage preprocess is Failed"})
+	assert.Equal(t, state.Stages[2], StageState{Name: "evaluate", Status: stageSkipped, Message: "stage train was not run"})
+	assert.Equal(t, state.Stages[3].Status, constants.Status.Succeeded)
+	assert.Equal(t, state.Stages[4], StageState{Name: "cleanup", Status: stageSubmitFailed, Message: "quota exceeded"})
+}
+
+func TestSchedulerResumesPipeline(t *testing.T) {
+	pipeline := newTestPipeline(Stage{Name: "preprocess"}, Stage{Name: "train", After: []string{"preprocess"}})
+	if err := pipeline.validate(); err != nil {
+		t.Fatal(err)
+	}
+	state := &State{
+		Status: pipelineRunning,
+		Stages: []StageState{
+			{Name: "preprocess", Job: "mnist-preprocess", Status: stageSubmitted},
+			{Name: "train", Status: stagePending},
+		},
+	}
+	jobs := &fakeJobs{statuses: map[string]string{
+		"preprocess": constants.Status.Succeeded,
+		"train":      constants.Status.Succeeded,
+	}}
+
+	newTestScheduler(pipeline, state, jobs).run()
+
+	// the job of the submitted stage is waited for, without submitting it again
+	assert.Equal(t, jobs.submitted, []string{"train"})
+	assert.Equal(t, state.Status, pipelineSucceeded)
+}
+
+func TestPipelineConfigMap(t *testing.T) {
+	pipeline := newTestPipeline(Stage{Name: "preprocess"}, Stage{Name: "train", After: []string{"preprocess"}})
+	if err := pipeline.validate(); err != nil {
+		t.Fatal(err)
+	}
+	clientset := fake.NewSimpleClientset()
+
+	configMap, err := createPipelineConfigMap(clientset, "runai-team-a", pipeline, pipeline.newState())
+	if err != nil {
+		t.Fatal(err)
+	}
+	state := pipeline.newState()
+	state.Stages[0] = StageState{Name: "preprocess", Job: "mnist-preprocess", Status: stageSubmitted}
+	if _, err = updatePipelineConfigMap(clientset, "runai-team-a", configMap, state); err != nil {
+		t.Fatal(err)
+	}
+
+	configMap, err = getPipelineConfigMap(clientset, "runai-team-a", "mnist")
+	if err != nil {
+		t.Fatal(err)
+	}
+	storedPipeline, storedState, err := parsePipelineConfigMap(configMap)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, configMap.Name, "runai-pipeline-mnist")
+	assert.Equal(t, storedPipeline.Stages[1].After, []string{"preprocess"})
+	assert.Equal(t, storedState, state)
+
+	missing, err := getPipelineConfigMap(clientset, "runai-team-a", "cifar")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if missing != nil {
+		t.Errorf("expected no config map for a pipeline which does not exist")
+	}
+}
+
+func TestCheckPipelineUnchanged(t *testing.T) {
+	pipeline := newTestPipeline(Stage{Name: "preprocess"}, Stage{Name: "train", After: []string{"preprocess"}})
+	if err := pipeline.validate(); err != nil {
+		t.Fatal(err)
+	}
+	configMap, err := createPipelineConfigMap(fake.NewSimpleClientset(), "runai-team-a", pipeline, pipeline.newState())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = checkPipelineUnchanged(configMap, pipeline); err != nil {
+		t.Errorf("expected the same pipeline to be resumed, got %v", err)
+	}
+
+	pipeline.Stages[1].Spec["gpu"] = 2
+	err = checkPipelineUnchanged(configMap, pipeline)
+	if err == nil || !strings.Contains(err.Error(), "pipeline delete mnist") {
+		t.Errorf("expected an error for a changed pipeline, got %v", err)
+	}
+}
+
+func TestStageSubmitterRunsSubmitCommand(t *testing.T) {
+	var submittedArgs []string
+	submitter := &stageSubmitter{
+		run: func(args []string) error {
+			submittedArgs = args
+			return nil
+		},
+		globalArgs: []string{"--loglevel=debug"},
+		project:    "team-a",
+	}
+	jobName, err := submitter.submit("mnist", Stage{Name: "train", Type: mpiStageType, Spec: map[string]interface{}{"image": "ubuntu"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, jobName, "mnist-train")
+	assert.Equal(t, len(submittedArgs), 8)
+	assert.Equal(t, submittedArgs[0], "submit-mpi")
+	assert.Equal(t, submittedArgs[3:], []string{"--name", "mnist-train", "--project", "team-a", "--loglevel=debug"})
+
+	submitter.run = func(args []string) error {
+		return fmt.Errorf("'runai %s' failed: exit status 1", args[0])
+	}
+	_, err = submitter.submit("mnist", Stage{Name: "train", Type: mpiStageType, Spec: map[string]interface{}{"image": "ubuntu"}})
+	if err == nil {
+		t.Errorf("expected a failed submit command to fail the submission")
+	}
+}
+
+// TestRunCLICommandHelper is run by TestRunCLICommandFailure as the child process of the cli
+func TestRunCLICommandHelper(t *testing.T) {
+	if os.Getenv("RUNAI_TEST_CLI_EXIT") == "" {
+		return
+	}
+	os.Exit(1)
+}
+
+func TestRunCLICommandFailure(t *testing.T) {
+	os.Setenv("RUNAI_TEST_CLI_EXIT", "1")
+	defer os.Unsetenv("RUNAI_TEST_CLI_EXIT")
+
+	if err := runCLICommand([]string{"-test.run=TestRunCLICommandHelper"}); err == nil {
+		t.Errorf("expected a command which exits with 1 to fail")
+	}
+	os.Unsetenv("RUNAI_TEST_CLI_EXIT")
+	if err := runCLICommand([]string{"-test.run=TestRunCLICommandHelper"}); err != nil {
+		t.Errorf("expected a command which succeeds not to fail, got %v", err)
+	}
+}
+
+func TestGetGlobalArgs(t *testing.T) {
+	root := &cobra.Command{Use: "runai"}
+	root.PersistentFlags().StringP("project", "p", "", "")
+	root.PersistentFlags().String("loglevel", "info", "")
+	apply := &cobra.Command{Use: "apply", Run: func(cmd *cobra.Command, args []string) {}}
+	root.AddCommand(apply)
+	root.SetArgs([]string{"apply", "--loglevel", "debug", "-p", "team-a"})
+	if err := root.Execute(); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, getGlobalArgs(apply), []string{"--loglevel=debug"})
+}
@@ -0,0 +1,169 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/run-ai/runai-cli/cmd/flags"
+	"github.com/run-ai/runai-cli/cmd/job"
+	"github.com/run-ai/runai-cli/cmd/trainer"
+	"github.com/run-ai/runai-cli/pkg/authentication/assertion"
+	"github.com/run-ai/runai-cli/pkg/client"
+	"github.com/run-ai/runai-cli/pkg/config"
+	"github.com/run-ai/runai-cli/pkg/types"
+	"github.com/run-ai/runai-cli/pkg/ui"
+	"github.com/run-ai/runai-cli/pkg/util"
+	commandUtil "github.com/run-ai/runai-cli/pkg/util/command"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// pipelineView is a row of the pipelines table
+type pipelineView struct {
+	Name     string `title:"NAME"`
+	Status   string `title:"STATUS"`
+	Finished string `title:"FINISHED STAGES"`
+	Age      string `title:"AGE"`
+}
+
+// stageView is a row of the stages table of a pipeline
+type stageView struct {
+	Name    string `title:"STAGE"`
+	Job     string `title:"JOB" def:"-"`
+	Status  string `title:"STATUS"`
+	Message string `title:"MESSAGE" def:"-"`
+}
+
+func newStatusCommand() *cobra.Command {
+	var command = &cobra.Command{
+		Use:   "status [PIPELINE_NAME]",
+		Short: "Display the status of a pipeline, or of all the pipelines of the project.",
+		Example: `  # Display the status of all the pipelines of the project
+  runai pipeline status
+
+  # Display the status of the stages of pipeline mnist
+  runai pipeline status mnist`,
+		Args:   cobra.MaximumNArgs(1),
+		PreRun: commandUtil.RoleAssertion(assertion.AssertViewerRole),
+		Run: commandUtil.WrapRunCommand(func(cmd *cobra.Command, args []string) error {
+			kubeClient, err := client.GetClient()
+			if err != nil {
+				return err
+			}
+
+			namespaceInfo, err := flags.GetNamespaceToUseFromProjectFlag(cmd, kubeClient)
+			if err != nil {
+				return err
+			}
+
+			if len(args) == 0 {
+				return displayPipelines(kubeClient, namespaceInfo)
+			}
+			return displayPipeline(kubeClient, namespaceInfo, args[0])
+		}),
+	}
+
+	return command
+}
+
+func displayPipelines(kubeClient *client.Client, namespaceInfo types.NamespaceInfo) error {
+	configMaps, err := kubeClient.GetClientset().CoreV1().ConfigMaps(namespaceInfo.Namespace).List(context.TODO(), metav1.ListOptions{LabelSelector: PipelineLabel})
+	if err != nil {
+		return err
+	}
+	if len(configMaps.Items) == 0 {
+		fmt.Printf("No pipelines found in project %s\n", namespaceInfo.ProjectName)
+		return nil
+	}
+
+	views := []pipelineView{}
+	for _, configMap := range configMaps.Items {
+		_, state, err := parsePipelineConfigMap(&configMap)
+		if err != nil {
+			log.Debug(err)
+			continue
+		}
+		finished := 0
+		for _, stageState := range state.Stages {
+			if isStageFinished(stageState.Status) {
+				finished++
+			}
+		}
+		views = append(views, pipelineView{
+			Name:     configMap.Labels[PipelineLabel],
+			Status:   state.Status,
+			Finished: fmt.Sprintf("%d/%d", finished, len(state.Stages)),
+			Age:      util.ShortHumanDuration(time.Since(configMap.CreationTimestamp.Time)),
+		})
+	}
+	sort.Slice(views, func(i, j int) bool {
+		return views[i].Name < views[j].Name
+	})
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	if err = ui.CreateTable(pipelineView{}, ui.TableOpt{}).Render(w, views).Error(); err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
+func displayPipeline(kubeClient *client.Client, namespaceInfo types.NamespaceInfo, name string) error {
+	configMap, err := getPipelineConfigMap(kubeClient.GetClientset(), namespaceInfo.Namespace, name)
+	if err != nil {
+		return err
+	}
+	if configMap == nil {
+		return fmt.Errorf("the pipeline %s does not exist in project %s", name, namespaceInfo.ProjectName)
+	}
+	pipeline, state, err := parsePipelineConfigMap(configMap)
+	if err != nil {
+		return err
+	}
+
+	// the stages which are running are displayed with the current status of their jobs
+	stages := make([]StageState, len(state.Stages))
+	for i, stageState := range state.Stages {
+		stages[i] = stageState
+		if stageState.Status == stageSubmitted && stageState.Job != "" {
+			stageJob, err := trainer.SearchTrainingJob(kubeClient, stageState.Job, "", namespaceInfo)
+			if err != nil {
+				log.Debugf("Failed to get the job %s of stage %s: %v", stageState.Job, stageState.Name, err)
+				continue
+			}
+			stages[i].Status = job.GetJobRealStatus(stageJob)
+		}
+	}
+
+	fmt.Printf("PIPELINE: %s\nSTATUS: %s\n\n", pipeline.Name, state.Status)
+	if err = printPipelineStatus(os.Stdout, stages); err != nil {
+		return err
+	}
+	if state.Status == pipelineRunning {
+		fmt.Printf("\nThe stages are submitted by '%s pipeline apply', which should be running for the pipeline to progress.\n", config.CLIName)
+	}
+	return nil
+}
+
+func printPipelineStatus(out io.Writer, stages []StageState) error {
+	views := []stageView{}
+	for _, stageState := range stages {
+		views = append(views, stageView{
+			Name:    stageState.Name,
+			Job:     stageState.Job,
+			Status:  stageState.Status,
+			Message: stageState.Message,
+		})
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	if err := ui.CreateTable(stageView{}, ui.TableOpt{}).Render(w, views).Error(); err != nil {
+		return err
+	}
+	return w.Flush()
+}
@@ -0,0 +1,260 @@
+package portforward
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/run-ai/runai-cli/cmd/exec"
+	"github.com/run-ai/runai-cli/cmd/job"
+	"github.com/run-ai/runai-cli/pkg/authentication/assertion"
+	"github.com/run-ai/runai-cli/pkg/client"
+	commandUtil "github.com/run-ai/runai-cli/pkg/util/command"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/transport/spdy"
+)
+
+const (
+	// DefaultPodRunningTimeout is the time to wait for the pod of the job to run, when connecting and reconnecting
+	DefaultPodRunningTimeout = time.Minute * 5
+	watchRetryInterval       = time.Second * 5
+
+	portForwardExamples = `
+# Forward local port 8888 to port 8888 of job jupyter1
+runai port-forward jupyter1 8888
+
+# Forward local port 8080 to port 80, and a random local port to port 6006 of a specific pod of job train1
+runai port-forward train1 8080:80 :6006 --pod train1-0-x7k2p`
+)
+
+func NewPortForwardCommand() *cobra.Command {
+	var podName string
+	var addresses []string
+
+	var command = &cobra.Command{
+		Use:   "port-forward JOB_NAME [LOCAL_PORT:]REMOTE_PORT [...]",
+		Short: "Forward local ports to a running job.",
+		Long: `Forward local ports to the chief pod of a running job, or to another pod with --pod. The ports are forwarded
+until the command is interrupted, and reconnected when the pod restarts. When the local port is empty or 0, a random
+local port is used.`,
+		Example:           portForwardExamples,
+		ValidArgsFunction: job.GenJobNames,
+		Args:              cobra.MinimumNArgs(2),
+		PreRun:            commandUtil.NamespacedRoleAssertion(assertion.AssertExecutorRole),
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := PortForward(cmd, args[0], podName, args[1:], addresses); err != nil {
+				log.Error(err)
+				os.Exit(1)
+			}
+		},
+	}
+
+	job.AddPodNameFlag(command, &podName)
+	command.Flags().StringSliceVar(&addresses, "address", []string{"localhost"}, "Addresses to listen on (comma separated).")
+
+	return command
+}
+
+// parsePorts validates ports in the format of [LOCAL_PORT:]REMOTE_PORT, and returns them in the format of the client-go
+// port forwarder
+func parsePorts(ports []string) ([]string, error) {
+	parsedPorts := []string{}
+	for _, port := range ports {
+		parts := strings.Split(port, ":")
+		if len(parts) > 2 {
+			return nil, fmt.Errorf("invalid port %s, the format is [LOCAL_PORT:]REMOTE_PORT", port)
+		}
+		localPort, remotePort := parts[0], parts[len(parts)-1]
+		if len(parts) == 2 && localPort == "" {
+			localPort = "0"
+		}
+		for _, value := range []string{localPort, remotePort} {
+			if _, err := strconv.ParseUint(value, 10, 16); err != nil {
+				return nil, fmt.Errorf("invalid port %s, the format is [LOCAL_PORT:]REMOTE_PORT", port)
+			}
+		}
+		if remotePort == "0" {
+			return nil, fmt.Errorf("invalid port %s, the remote port must be set", port)
+		}
+		parsedPorts = append(parsedPorts, fmt.Sprintf("%s:%s", localPort, remotePort))
+	}
+	return parsedPorts, nil
+}
+
+// PortForward forwards local ports to a pod of a running job, in the format of [LOCAL_PORT:]REMOTE_PORT. When the pod
+// restarts, the same local ports are forwarded to the new pod. It returns when it is interrupted.
+func PortForward(cmd *cobra.Command, jobName, podName string, ports []string, addresses []string) error {
+	ports, err := parsePorts(ports)
+	if err != nil {
+		return err
+	}
+	kubeClient, err := client.GetClient()
+	if err != nil {
+		return err
+	}
+
+	interrupted := make(chan struct{})
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signals)
+	go func() {
+		<-signals
+		close(interrupted)
+	}()
+
+	for connected := false; ; connected = true {
+		pod, err := exec.WaitForPodToStartRunning(cmd, kubeClient, jobName, podName, DefaultPodRunningTimeout)
+		if err != nil {
+			return err
+		}
+
+		forwardedPorts, err := forwardPorts(kubeClient, pod, ports, addresses, interrupted)
+		if err != nil && !connected {
+			return err
+		}
+		if err != nil {
+			log.Warnf("Failed to forward ports to pod %s: %v", pod.Name, err)
+			select {
+			case <-interrupted:
+			case <-time.After(watchRetryInterval):
+			}
+		} else {
+			// reconnect to the same local ports, including the random ones
+			ports = forwardedPorts
+		}
+
+		select {
+		case <-interrupted:
+			return nil
+		default:
+			fmt.Printf("Lost the connection to pod %s, reconnecting\n", pod.Name)
+		}
+	}
+}
+
+// forwardPorts forwards the ports to the pod until the pod restarts or the forwarding is interrupted, and returns the
+// forwarded ports
+func forwardPorts(kubeClient *client.Client, pod *v1.Pod, ports []string, addresses []string, interrupted <-chan struct{}) ([]string, error) {
+	transport, upgrader, err := spdy.RoundTripperFor(kubeClient.GetRestConfig())
+	if err != nil {
+		return nil, err
+	}
+	url := kubeClient.GetClientset().CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(pod.Namespace).
+		Name(pod.Name).
+		SubResource("portforward").
+		URL()
+	dialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, http.MethodPost, url)
+
+	stop := make(chan struct{})
+	ready := make(chan struct{})
+	forwarder, err := portforward.NewOnAddresses(dialer, addresses, ports, stop, ready, ioutil.Discard, os.Stderr)
+	if err != nil {
+		return nil, err
+	}
+
+	forwardErrors := make(chan error, 1)
+	go func() {
+		forwardErrors <- forwarder.ForwardPorts()
+	}()
+	select {
+	case <-ready:
+	case err = <-forwardErrors:
+		return nil, err
+	}
+
+	forwarded, err := forwarder.GetPorts()
+	if err != nil {
+		close(stop)
+		return nil, err
+	}
+	forwardedPorts := []string{}
+	for _, port := range forwarded {
+		fmt.Printf("Forwarding http://%s:%d to port %d of pod %s\n", addresses[0], port.Local, port.Remote, pod.Name)
+		forwardedPorts = append(forwardedPorts, fmt.Sprintf("%d:%d", port.Local, port.Remote))
+	}
+
+	watchDone := make(chan struct{})
+	go func() {
+		defer close(stop)
+		select {
+		case <-interrupted:
+		case <-waitForPodRestart(kubeClient.GetClientset(), pod, watchDone):
+		case <-watchDone:
+		}
+	}()
+	err = <-forwardErrors
+	close(watchDone)
+	return forwardedPorts, err
+}
+
+// waitForPodRestart returns a channel which is closed when the pod stops running, is deleted or one of its containers
+// restarts. The pod is watched until done is closed.
+func waitForPodRestart(clientset kubernetes.Interface, pod *v1.Pod, done <-chan struct{}) <-chan struct{} {
+	restarted := make(chan struct{})
+	restarts := getRestarts(pod)
+	options := metav1.ListOptions{FieldSelector: fields.OneTermEqualSelector("metadata.name", pod.Name).String()}
+
+	go func() {
+		for {
+			watcher, err := clientset.CoreV1().Pods(pod.Namespace).Watch(context.TODO(), options)
+			if err != nil {
+				log.Debugf("Failed to watch pod %s: %v", pod.Name, err)
+				select {
+				case <-done:
+					return
+				case <-time.After(watchRetryInterval):
+					continue
+				}
+			}
+
+			for watching := true; watching; {
+				select {
+				case <-done:
+					watcher.Stop()
+					return
+				case event, ok := <-watcher.ResultChan():
+					if !ok {
+						// the watch has expired, and is renewed
+						watching = false
+						break
+					}
+					currentPod, isPod := event.Object.(*v1.Pod)
+					if event.Type == watch.Deleted || (isPod && isPodRestarted(currentPod, restarts)) {
+						watcher.Stop()
+						close(restarted)
+						return
+					}
+				}
+			}
+		}
+	}()
+	return restarted
+}
+
+func isPodRestarted(pod *v1.Pod, restarts int32) bool {
+	return pod.DeletionTimestamp != nil || pod.Status.Phase != v1.PodRunning || getRestarts(pod) > restarts
+}
+
+func getRestarts(pod *v1.Pod) int32 {
+	restarts := int32(0)
+	for _, status := range pod.Status.ContainerStatuses {
+		restarts += status.RestartCount
+	}
+	return restarts
+}
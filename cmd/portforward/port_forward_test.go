@@ -0,0 +1,42 @@
+package portforward
+
+import (
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+func TestParsePorts(t *testing.T) {
+	ports, err := parsePorts([]string{"8888", "8080:80", ":6006", "0:22"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"8888:8888", "8080:80", "0:6006", "0:22"}
+	if !reflect.DeepEqual(ports, expected) {
+		t.Errorf("expected the ports %v, got %v", expected, ports)
+	}
+
+	for _, port := range []string{"http", "8080:", "1:2:3", "70000", "8080:0"} {
+		if _, err := parsePorts([]string{port}); err == nil {
+			t.Errorf("expected an error for port %s", port)
+		}
+	}
+}
+
+func TestIsPodRestarted(t *testing.T) {
+	pod := &v1.Pod{Status: v1.PodStatus{
+		Phase:             v1.PodRunning,
+		ContainerStatuses: []v1.ContainerStatus{{RestartCount: 1}, {RestartCount: 2}},
+	}}
+	if isPodRestarted(pod, 3) {
+		t.Errorf("expected a running pod not to be restarted")
+	}
+	if !isPodRestarted(pod, 2) {
+		t.Errorf("expected a pod with more container restarts to be restarted")
+	}
+	pod.Status.Phase = v1.PodFailed
+	if !isPodRestarted(pod, 3) {
+		t.Errorf("expected a failed pod to be restarted")
+	}
+}
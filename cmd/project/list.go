@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"reflect"
 	"sort"
 	"strings"
 	"text/tabwriter"
@@ -11,9 +12,11 @@ import (
 
 	"github.com/run-ai/runai-cli/cmd/completion"
 	"github.com/run-ai/runai-cli/cmd/constants"
+	"github.com/run-ai/runai-cli/cmd/flags"
 	"github.com/run-ai/runai-cli/pkg/authentication/assertion"
 	"github.com/run-ai/runai-cli/pkg/client"
 	"github.com/run-ai/runai-cli/pkg/rsrch_client"
+	"github.com/run-ai/runai-cli/pkg/types"
 	log "github.com/sirupsen/logrus"
 	restclient "k8s.io/client-go/rest"
 
@@ -25,7 +28,47 @@ import (
 	rsrch_cs "github.com/run-ai/researcher-service/server/pkg/runai/client"
 )
 
-func runListCommand(cmd *cobra.Command, args []string) error {
+var (
+	projectListHiddenFields = ui.EnsureStringPaths(types.ProjectView{}, []string{
+		"Default",
+	})
+
+	projectListFormatters = map[string]ui.FormatFunction{
+		"projectname": func(value, model interface{}) (string, error) {
+			projectView := model.(types.ProjectView)
+			if projectView.Default {
+				return fmt.Sprintf("%s (default)", projectView.Name), nil
+			}
+			return projectView.Name, nil
+		},
+		"deservedgpus": func(value, model interface{}) (string, error) {
+			deservedGPUs := model.(types.ProjectView).DeservedGPUs
+			if deservedGPUs == 0 {
+				return "", nil
+			}
+			return fmt.Sprintf("%v", deservedGPUs), nil
+		},
+		"timelimit": func(value, model interface{}) (string, error) {
+			timeLimit := model.(types.ProjectView).InteractiveTimeLimit
+			if timeLimit == 0 {
+				return "", nil
+			}
+			return timeLimit.String(), nil
+		},
+		"affinity": func(value, model interface{}) (string, error) {
+			affinity, ok := value.([]string)
+			if !ok {
+				return "", fmt.Errorf("[AFFINITY Format]:: expecting []string, got: %s", reflect.ValueOf(value).Type().Name())
+			}
+			return strings.Join(affinity, ";"), nil
+		},
+	}
+)
+
+func runListCommand(cmd *cobra.Command, args []string, output ui.OutputOpt) error {
+	if err := output.Validate(); err != nil {
+		return err
+	}
 
 	//
 	//   obtain default project of this session
@@ -50,9 +93,7 @@ func runListCommand(cmd *cobra.Command, args []string) error {
 	//
 	projectsArray := getSortedProjects(projects)
 
-	printProjects(projectsArray, defaultProject)
-
-	return nil
+	return printProjects(projectsArray, defaultProject, output)
 }
 
 //
@@ -109,51 +150,45 @@ func getSortedProjects(projects map[string]*rsrch_server.Project) []*rsrch_serve
 	return projectsArray
 }
 
-func printProjects(infos []*rsrch_server.Project, defaultProject string) {
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-
-	ui.Line(w, "PROJECT", "DEPARTMENT", "DESERVED GPUs", "INT LIMIT", "INT AFFINITY", "TRAIN AFFINITY")
-
+func printProjects(infos []*rsrch_server.Project, defaultProject string, output ui.OutputOpt) error {
+	projectViews := []types.ProjectView{}
 	for _, info := range infos {
+		projectViews = append(projectViews, types.ProjectView{
+			Name:                    info.Name,
+			Default:                 info.Name == defaultProject,
+			Department:              info.DepartmentName,
+			DeservedGPUs:            float64(info.DeservedGpus),
+			InteractiveTimeLimit:    time.Duration(info.InteractiveJobTimeLimitSecs) * time.Second,
+			InteractiveNodeAffinity: info.InteractiveNodeAffinity,
+			TrainNodeAffinity:       info.TrainNodeAffinity,
+		})
+	}
 
-		deservedInfo := "-"
-		if info.DeservedGpus != 0 {
-			deservedInfo = fmt.Sprintf("%v", info.DeservedGpus)
-		}
-
-		interactiveJobTimeLimitFmt := "-"
-		if info.InteractiveJobTimeLimitSecs != 0 {
-			t := time.Duration(info.InteractiveJobTimeLimitSecs * 1000 * 1000 * 1000)
-			interactiveJobTimeLimitFmt = t.String()
-		}
-
-		isDefault := info.Name == defaultProject
-
-		name := info.Name
-		if isDefault {
-			name += " (default)"
-		}
-
-		departmentName := "-"
-		if info.DepartmentName != "" {
-			departmentName = info.DepartmentName
-		}
-
-		ui.Line(w, name, departmentName, deservedInfo, interactiveJobTimeLimitFmt,
-			strings.Join(info.InteractiveNodeAffinity, ";"),
-			strings.Join(info.TrainNodeAffinity, ";"))
+	if !output.IsTable() {
+		return ui.PrintList(os.Stdout, projectViews, ui.ListOpt{Kind: "project", NamePath: "{.name}"}, output)
 	}
 
-	_ = w.Flush()
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	err := ui.CreateTable(types.ProjectView{}, ui.TableOpt{
+		DisplayOpt: ui.DisplayOpt{Hide: projectListHiddenFields},
+		Formatts:   projectListFormatters,
+		NoHeaders:  output.NoHeaders,
+	}).Render(w, projectViews).Error()
+	if err != nil {
+		return err
+	}
+	return w.Flush()
 }
 
 func listCommandDEPRECATED() *cobra.Command {
 
 	var command = &cobra.Command{
-		Use:        "list",
-		Short:      fmt.Sprint("List all available projects."),
-		PreRun:     commandUtil.RoleAssertion(assertion.AssertViewerRole),
-		Run:        commandUtil.WrapRunCommand(runListCommand),
+		Use:    "list",
+		Short:  fmt.Sprint("List all available projects."),
+		PreRun: commandUtil.RoleAssertion(assertion.AssertViewerRole),
+		Run: commandUtil.WrapRunCommand(func(cmd *cobra.Command, args []string) error {
+			return runListCommand(cmd, args, ui.OutputOpt{})
+		}),
 		Deprecated: "Please use: 'runai list project' instead",
 	}
 
@@ -161,6 +196,7 @@ func listCommandDEPRECATED() *cobra.Command {
 }
 
 func ListCommand() *cobra.Command {
+	var output ui.OutputOpt
 
 	var command = &cobra.Command{
 		Use:               "projects [--include-deleted]",
@@ -168,8 +204,12 @@ func ListCommand() *cobra.Command {
 		Short:             "List all available projects",
 		ValidArgsFunction: completion.NoArgs,
 		PreRun:            commandUtil.RoleAssertion(assertion.AssertViewerRole),
-		Run:               commandUtil.WrapRunCommand(runListCommand),
+		Run: commandUtil.WrapRunCommand(func(cmd *cobra.Command, args []string) error {
+			return runListCommand(cmd, args, output)
+		}),
 	}
 
+	flags.AddOutputFlags(command.Flags(), &output)
+
 	return command
 }
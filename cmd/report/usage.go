@@ -0,0 +1,249 @@
+package report
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/run-ai/runai-cli/cmd/completion"
+	"github.com/run-ai/runai-cli/cmd/flags"
+	"github.com/run-ai/runai-cli/cmd/trainer"
+	"github.com/run-ai/runai-cli/pkg/authentication/assertion"
+	"github.com/run-ai/runai-cli/pkg/client"
+	"github.com/run-ai/runai-cli/pkg/jobs"
+	prom "github.com/run-ai/runai-cli/pkg/prometheus"
+	"github.com/run-ai/runai-cli/pkg/types"
+	"github.com/run-ai/runai-cli/pkg/ui"
+	commandUtil "github.com/run-ai/runai-cli/pkg/util/command"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	csvOutput = "csv"
+	day       = 24 * time.Hour
+)
+
+const usageExamples = `
+# Display the gpu hours of the users of the default project over the last 30 days
+runai report usage --since 30d
+
+# Display the gpu hours and the cost of the jobs of all the projects over the last week, as csv
+runai report usage --since 7d -A --by job --gpu-hour-cost A100=2.5 --gpu-hour-cost default=1 -o csv
+`
+
+var usageFormatters = map[string]ui.FormatFunction{
+	"hours": func(value, model interface{}) (string, error) {
+		return fmt.Sprintf("%.2f", value), nil
+	},
+	"cost": func(value, model interface{}) (string, error) {
+		return fmt.Sprintf("%.2f", value), nil
+	},
+}
+
+func newUsageCommand() *cobra.Command {
+	var allNamespaces bool
+	var since string
+	var by string
+	var gpuHourCosts []string
+	var output ui.OutputOpt
+	var command = &cobra.Command{
+		Use:   "usage",
+		Short: "Display the gpu hours, the utilization and the cost of the gpus used by the jobs.",
+		Long: `Display the gpu hours, the average utilization and the idle gpu hours of the gpus allocated to the jobs, grouped by
+user, project, job or node type. Gpus are idle when their utilization is below 1%.
+When costs of gpu hours are set, the cost of the gpu hours is displayed as well. The cost of the 'default' type is used
+for gpus whose type has no cost of its own.
+The usage of jobs which no longer exist is reported by the labels of their metrics, which may have no user, node type
+or gpu type. Such jobs are grouped under <unknown> and cost as the 'default' type, and are counted in the deleted jobs
+of every row.`,
+		Example:           usageExamples,
+		ValidArgsFunction: completion.NoArgs,
+		PreRun:            commandUtil.RoleAssertion(assertion.AssertViewerRole),
+		Run: commandUtil.WrapRunCommand(func(cmd *cobra.Command, args []string) error {
+			if output.Format != csvOutput {
+				if err := output.Validate(); err != nil {
+					return err
+				}
+			}
+			if !ui.Contains(jobs.UsageGroupings, by) {
+				return fmt.Errorf("invalid grouping %s, supported values are %s", by, strings.Join(jobs.UsageGroupings, ", "))
+			}
+			sinceDuration, err := parseSince(since)
+			if err != nil {
+				return err
+			}
+			costs, err := jobs.ParseGPUHourCosts(gpuHourCosts)
+			if err != nil {
+				return err
+			}
+
+			kubeClient, err := client.GetClient()
+			if err != nil {
+				return err
+			}
+
+			namespaceInfo, err := flags.GetNamespaceToUseFromProjectFlagIncludingAll(cmd, kubeClient, allNamespaces)
+			if err != nil {
+				return err
+			}
+
+			promClient, err := prom.BuildMetricsClient(kubeClient)
+			if err != nil {
+				return fmt.Errorf("error while creating prometheus client: %v", err)
+			}
+			if promClient == nil {
+				return fmt.Errorf("prometheus was not found in the cluster, the usage report requires prometheus")
+			}
+
+			jobsByPodGroup, err := getUsageJobs(kubeClient, namespaceInfo)
+			if err != nil {
+				return err
+			}
+
+			options := jobs.UsageReportOptions{Since: sinceDuration, By: by, GPUHourCosts: costs}
+			if namespaceInfo.ProjectName != types.AllProjects {
+				options.Project = namespaceInfo.ProjectName
+			}
+			views, err := jobs.GetUsageReport(promClient, jobsByPodGroup, options, time.Now())
+			if err != nil {
+				return err
+			}
+
+			return printUsage(os.Stdout, views, options, output)
+		}),
+	}
+
+	command.Flags().BoolVarP(&allNamespaces, "all-projects", "A", false, "show all projects.")
+	command.Flags().StringVar(&since, "since", "30d", "the window of the report, e.g. 12h or 30d.")
+	command.Flags().StringVar(&by, "by", jobs.UsageByUser, fmt.Sprintf("group the usage by one of: %s.", strings.Join(jobs.UsageGroupings, "|")))
+	command.Flags().StringArrayVar(&gpuHourCosts, "gpu-hour-cost", []string{}, "the cost of a gpu hour of a gpu type in the format of TYPE=COST, e.g. A100=2.5. The cost of the 'default' type is used for other types.")
+	command.Flags().StringVarP(&output.Format, "output", "o", "", fmt.Sprintf("Output format. One of: csv|json|yaml|name|%sTEMPLATE|%sHEADER:FIELD_PATH,...", ui.JsonPathOutputPrefix, ui.CustomColumnsOutputPrefix))
+	command.Flags().BoolVar(&output.NoHeaders, "no-headers", false, "Don't print headers in the table, csv and custom-columns outputs.")
+
+	return command
+}
+
+// parseSince parses the window of the report, which is a duration or a number of days, e.g. 30d
+func parseSince(since string) (time.Duration, error) {
+	var duration time.Duration
+	var err error
+	if strings.HasSuffix(since, "d") {
+		var days float64
+		days, err = strconv.ParseFloat(strings.TrimSuffix(since, "d"), 64)
+		duration = time.Duration(days * float64(day))
+	} else {
+		duration, err = time.ParseDuration(since)
+	}
+	if err != nil || duration <= 0 {
+		return 0, fmt.Errorf("invalid value %s of --since, the value must be a positive duration, e.g. 12h or 30d", since)
+	}
+	return duration, nil
+}
+
+// getUsageJobs returns the jobs of the project by the uuids of their pod groups
+func getUsageJobs(kubeClient *client.Client, namespaceInfo types.NamespaceInfo) (map[string]jobs.UsageJobInfo, error) {
+	trainingJobs, err := trainer.GetAllJobs(kubeClient, namespaceInfo, nil)
+	if err != nil {
+		return nil, err
+	}
+	nodeList, err := kubeClient.GetClientset().CoreV1().Nodes().List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	jobsByPodGroup := map[string]jobs.UsageJobInfo{}
+	for _, job := range trainingJobs {
+		if podGroupUUID := job.GetPodGroupUUID(); podGroupUUID != "" {
+			jobsByPodGroup[podGroupUUID] = jobs.NewUsageJobInfo(job, nodeList.Items)
+		}
+	}
+	return jobsByPodGroup, nil
+}
+
+// getUsageColumns returns the fields of the usage view which are displayed, the grouping columns and the usage
+func getUsageColumns(options jobs.UsageReportOptions) []string {
+	columns := []string{}
+	switch options.By {
+	case jobs.UsageByProject:
+		columns = append(columns, "Project")
+	case jobs.UsageByJob:
+		columns = append(columns, "Project", "Job")
+	case jobs.UsageByNodeType:
+		columns = append(columns, "NodeType")
+	default:
+		columns = append(columns, "User")
+	}
+	columns = append(columns, "GPUHours", "AvgUtilization", "IdleGPUHours")
+	if len(options.GPUHourCosts) > 0 {
+		columns = append(columns, "Cost")
+	}
+	return append(columns, "DeletedJobs")
+}
+
+func printUsage(out io.Writer, views []types.UsageView, options jobs.UsageReportOptions, output ui.OutputOpt) error {
+	columns := getUsageColumns(options)
+	switch {
+	case output.Format == csvOutput:
+		return printUsageCSV(out, views, columns, output.NoHeaders)
+	case !output.IsTable():
+		return ui.PrintList(out, views, ui.ListOpt{Kind: "usage", NamePath: "{.user}{.project}{.job}{.nodeType}"}, output)
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	err := ui.CreateTable(types.UsageView{}, ui.TableOpt{
+		DisplayOpt: ui.DisplayOpt{
+			HideAllByDefault: true,
+			Show:             columns,
+		},
+		Formatts:  usageFormatters,
+		NoHeaders: output.NoHeaders,
+	}).Render(w, views).Error()
+	if err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
+func printUsageCSV(out io.Writer, views []types.UsageView, columns []string, noHeaders bool) error {
+	w := csv.NewWriter(out)
+	if !noHeaders {
+		headers := map[string]string{
+			"User": "user", "Project": "project", "Job": "job", "NodeType": "node type", "GPUHours": "gpu hours",
+			"AvgUtilization": "average utilization", "IdleGPUHours": "idle gpu hours", "Cost": "cost", "DeletedJobs": "deleted jobs",
+		}
+		record := []string{}
+		for _, column := range columns {
+			record = append(record, headers[column])
+		}
+		if err := w.Write(record); err != nil {
+			return err
+		}
+	}
+
+	for _, view := range views {
+		values := map[string]string{
+			"User": view.User, "Project": view.Project, "Job": view.Job, "NodeType": view.NodeType,
+			"GPUHours":       strconv.FormatFloat(view.GPUHours, 'f', 2, 64),
+			"AvgUtilization": strconv.FormatFloat(view.AvgUtilization, 'f', 1, 64),
+			"IdleGPUHours":   strconv.FormatFloat(view.IdleGPUHours, 'f', 2, 64),
+			"Cost":           strconv.FormatFloat(view.Cost, 'f', 2, 64),
+			"DeletedJobs":    strconv.Itoa(view.DeletedJobs),
+		}
+		record := []string{}
+		for _, column := range columns {
+			record = append(record, values[column])
+		}
+		if err := w.Write(record); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
@@ -0,0 +1,53 @@
+package report
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/run-ai/runai-cli/pkg/jobs"
+	"github.com/run-ai/runai-cli/pkg/types"
+)
+
+func TestParseSince(t *testing.T) {
+	tests := []struct {
+		since    string
+		expected time.Duration
+	}{
+		{since: "30d", expected: 30 * 24 * time.Hour},
+		{since: "1.5d", expected: 36 * time.Hour},
+		{since: "12h", expected: 12 * time.Hour},
+	}
+
+	for _, test := range tests {
+		duration, err := parseSince(test.since)
+		if err != nil || duration != test.expected {
+			t.Errorf("expected %s to be parsed as %v, got %v (%v)", test.since, test.expected, duration, err)
+		}
+	}
+	for _, since := range []string{"d", "month", "-2d", "0h"} {
+		if _, err := parseSince(since); err == nil {
+			t.Errorf("expected %s to be invalid", since)
+		}
+	}
+}
+
+func TestPrintUsageCSV(t *testing.T) {
+	views := []types.UsageView{
+		{Project: "team-a", Job: "train1", GPUHours: 12.5, AvgUtilization: 40, IdleGPUHours: 2.25, Cost: 31.25},
+		{Project: "team-b", Job: "train,2", GPUHours: 1, DeletedJobs: 1},
+	}
+	options := jobs.UsageReportOptions{By: jobs.UsageByJob, GPUHourCosts: map[string]float64{jobs.DefaultGPUType: 2.5}}
+
+	var out bytes.Buffer
+	if err := printUsageCSV(&out, views, getUsageColumns(options), false); err != nil {
+		t.Fatal(err)
+	}
+	expected := `project,job,gpu hours,average utilization,idle gpu hours,cost,deleted jobs
+team-a,train1,12.50,40.0,2.25,31.25,0
+team-b,"train,2",1.00,0.0,0.00,0.00,1
+`
+	if out.String() != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, out.String())
+	}
+}
@@ -2,11 +2,13 @@ package resource
 
 import (
 	"github.com/run-ai/runai-cli/cmd/cluster"
+	"github.com/run-ai/runai-cli/cmd/flags"
 	"github.com/run-ai/runai-cli/cmd/job"
 	"github.com/run-ai/runai-cli/cmd/node"
 	"github.com/run-ai/runai-cli/cmd/project"
 	"github.com/run-ai/runai-cli/cmd/template"
 	"github.com/run-ai/runai-cli/pkg/authentication/assertion"
+	"github.com/run-ai/runai-cli/pkg/ui"
 	commandUtil "github.com/run-ai/runai-cli/pkg/util/command"
 	"github.com/spf13/cobra"
 )
@@ -35,6 +37,8 @@ runai list templates
 
 func NewListCommand() *cobra.Command {
 	var allNamespaces bool
+	var watch bool
+	var output ui.OutputOpt
 
 	var command = &cobra.Command{
 		Use:     "list",
@@ -42,11 +46,13 @@ func NewListCommand() *cobra.Command {
 		Example: listExample,
 		PreRun:  commandUtil.RoleAssertion(assertion.AssertViewerRole),
 		Run: func(cmd *cobra.Command, args []string) {
-			job.RunJobList(cmd, args, allNamespaces)
+			job.RunJobList(cmd, args, allNamespaces, watch, output, job.JobListFilter{})
 		},
 	}
 
 	command.Flags().BoolVarP(&allNamespaces, "all-projects", "A", false, "list jobs from all projects")
+	command.Flags().BoolVarP(&watch, "watch", "w", false, "keep the job list updated as jobs change")
+	flags.AddOutputFlags(command.Flags(), &output)
 
 	// create subcommands
 	command.AddCommand(node.ListCommand())
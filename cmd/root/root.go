@@ -27,11 +27,16 @@ import (
 	"github.com/run-ai/runai-cli/cmd/attach"
 	"github.com/run-ai/runai-cli/cmd/exec"
 	"github.com/run-ai/runai-cli/cmd/global"
+	"github.com/run-ai/runai-cli/cmd/idle"
+	"github.com/run-ai/runai-cli/cmd/job"
 	deleteJob "github.com/run-ai/runai-cli/cmd/job/delete"
 	submitJob "github.com/run-ai/runai-cli/cmd/job/submit"
 	suspendJob "github.com/run-ai/runai-cli/cmd/job/suspend"
 	"github.com/run-ai/runai-cli/cmd/logs"
+	"github.com/run-ai/runai-cli/cmd/pipeline"
+	"github.com/run-ai/runai-cli/cmd/portforward"
 	"github.com/run-ai/runai-cli/cmd/project"
+	"github.com/run-ai/runai-cli/cmd/report"
 	"github.com/run-ai/runai-cli/cmd/template"
 	"github.com/run-ai/runai-cli/pkg/config"
 	"github.com/run-ai/runai-cli/pkg/util"
@@ -66,11 +71,17 @@ func NewCommand() *cobra.Command {
 
 	command.AddCommand(submitJob.NewRunaiJobCommand())
 	command.AddCommand(submitJob.NewRunaiSubmitMPIJobCommand())
+	command.AddCommand(submitJob.NewResubmitCommand())
 	command.AddCommand(resource.NewListCommand())
 	command.AddCommand(logs.NewLogsCommand())
 	command.AddCommand(deleteJob.NewDeleteCommand())
 	command.AddCommand(suspendJob.NewSuspendCommand())
 	command.AddCommand(suspendJob.NewResumeCommand())
+	command.AddCommand(job.NewWaitCommand())
+	command.AddCommand(job.NewHistoryCommand())
+	command.AddCommand(report.NewReportCommand())
+	command.AddCommand(idle.NewIdleCommand())
+	command.AddCommand(pipeline.NewPipelineCommand())
 	command.AddCommand(resource.GetCommand())
 	command.AddCommand(resource.NewTopCommand())
 	command.AddCommand(resource.NewDescribeCommand())
@@ -79,6 +90,8 @@ func NewCommand() *cobra.Command {
 	command.AddCommand(raCmd.NewUpdateCommand())
 	command.AddCommand(exec.NewBashCommand())
 	command.AddCommand(exec.NewExecCommand())
+	command.AddCommand(exec.NewCopyCommand())
+	command.AddCommand(portforward.NewPortForwardCommand())
 	command.AddCommand(attach.NewAttachCommand())
 	command.AddCommand(template.NewTemplateCommand())
 	command.AddCommand(project.NewProjectCommand())
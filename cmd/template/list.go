@@ -3,6 +3,7 @@ package template
 import (
 	"fmt"
 	"github.com/run-ai/runai-cli/cmd/completion"
+	"github.com/run-ai/runai-cli/cmd/flags"
 	"github.com/run-ai/runai-cli/pkg/authentication/assertion"
 	commandUtil "github.com/run-ai/runai-cli/pkg/util/command"
 	"os"
@@ -10,39 +11,68 @@ import (
 
 	"github.com/run-ai/runai-cli/pkg/client"
 	"github.com/run-ai/runai-cli/pkg/templates"
+	"github.com/run-ai/runai-cli/pkg/types"
 	"github.com/run-ai/runai-cli/pkg/ui"
 	"github.com/spf13/cobra"
 )
 
+var (
+	templateListHiddenFields = ui.EnsureStringPaths(types.TemplateView{}, []string{
+		"Admin",
+	})
+
+	templateListFormatters = map[string]ui.FormatFunction{
+		"templatename": func(value, model interface{}) (string, error) {
+			templateView := model.(types.TemplateView)
+			if templateView.Admin {
+				return fmt.Sprintf("%s (Admin)", templateView.Name), nil
+			}
+			return templateView.Name, nil
+		},
+	}
+)
+
 func ListCommand() *cobra.Command {
+	var output ui.OutputOpt
 	var command = &cobra.Command{
 		Use:     "templates",
 		Aliases: []string{"template"},
 		Short:   "List all templates.",
 		PreRun:  commandUtil.RoleAssertion(assertion.AssertViewerRole),
 		Run: func(cmd *cobra.Command, args []string) {
-			listAllTemplates()
+			listAllTemplates(output)
 		},
 	}
 
+	flags.AddOutputFlags(command.Flags(), &output)
+
 	return command
 }
 
-func PrintTemplates(templates []templates.Template) {
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	labelField := []string{"NAME", "DESCRIPTION"}
-
-	ui.Line(w, labelField...)
-
+func PrintTemplates(templates []templates.Template, output ui.OutputOpt) error {
+	templateViews := []types.TemplateView{}
 	for _, config := range templates {
-		configName := config.Name
-		if config.IsAdmin {
-			configName = fmt.Sprintf("%s (Admin)", config.Name)
-		}
-		ui.Line(w, configName, config.Description)
+		templateViews = append(templateViews, types.TemplateView{
+			Name:        config.Name,
+			Admin:       config.IsAdmin,
+			Description: config.Description,
+		})
+	}
+
+	if !output.IsTable() {
+		return ui.PrintList(os.Stdout, templateViews, ui.ListOpt{Kind: "template", NamePath: "{.name}"}, output)
 	}
 
-	w.Flush()
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	err := ui.CreateTable(types.TemplateView{}, ui.TableOpt{
+		DisplayOpt: ui.DisplayOpt{Hide: templateListHiddenFields},
+		Formatts:   templateListFormatters,
+		NoHeaders:  output.NoHeaders,
+	}).Render(w, templateViews).Error()
+	if err != nil {
+		return err
+	}
+	return w.Flush()
 }
 
 func ListCommandDEPRECATED() *cobra.Command {
@@ -52,7 +82,7 @@ func ListCommandDEPRECATED() *cobra.Command {
 		ValidArgsFunction: completion.NoArgs,
 		PreRun: commandUtil.RoleAssertion(assertion.AssertViewerRole),
 		Run: func(cmd *cobra.Command, args []string) {
-			listAllTemplates()
+			listAllTemplates(ui.OutputOpt{})
 		},
 		Deprecated: "Please see usage of `runai list templates` for more information",
 	}
@@ -60,7 +90,11 @@ func ListCommandDEPRECATED() *cobra.Command {
 	return command
 }
 
-func listAllTemplates() {
+func listAllTemplates(output ui.OutputOpt) {
+	if err := output.Validate(); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
 
 	configs, err := PrepareTemplateList()
 	if err != nil {
@@ -68,7 +102,10 @@ func listAllTemplates() {
 		os.Exit(1)
 	}
 
-	PrintTemplates(configs)
+	if err = PrintTemplates(configs, output); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
 }
 
 func PrepareTemplateList() ([]templates.Template, error) {
@@ -319,6 +319,11 @@ func (rj *RunaiWorkload) Project() string {
 	return rj.podMetadata.Labels["project"]
 }
 
+// Labels returns the labels of the controller of the job, or of its pod when it has no controller
+func (rj *RunaiWorkload) Labels() map[string]string {
+	return rj.jobMetadata.Labels
+}
+
 func (rj *RunaiWorkload) User() string {
 
 	if userFromAnnotation, exists := rj.jobMetadata.Annotations[userFieldName]; exists && userFromAnnotation != "" {
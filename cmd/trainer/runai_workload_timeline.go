@@ -0,0 +1,106 @@
+package trainer
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TimelineEntry is a step in the history of a job
+type TimelineEntry struct {
+	Time time.Time
+	// Source is the object of the step, e.g. job/train1, pod/train1-0 or container/train1-0/train1
+	Source  string
+	Message string
+}
+
+// CreationTimestamp returns the time the job has been created
+func (rj *RunaiWorkload) CreationTimestamp() metav1.Time {
+	return rj.creationTimestamp
+}
+
+// Timeline returns the steps in the history of the job which are recorded in the job and its pods: the creation of
+// the job and its pods, the scheduling of the pods, and the state transitions of their containers
+func (rj *RunaiWorkload) Timeline() []TimelineEntry {
+	entries := []TimelineEntry{}
+	if !rj.creationTimestamp.IsZero() {
+		entries = append(entries, TimelineEntry{Time: rj.creationTimestamp.Time, Source: "job/" + rj.Name(), Message: "Created"})
+	}
+	entries = append(entries, GetPodsTimeline(rj.pods)...)
+	SortTimeline(entries)
+	return entries
+}
+
+// GetPodsTimeline returns the steps in the history of the pods, and of their containers
+func GetPodsTimeline(pods []v1.Pod) []TimelineEntry {
+	entries := []TimelineEntry{}
+	for _, pod := range pods {
+		source := "pod/" + pod.Name
+		entries = append(entries, TimelineEntry{Time: pod.CreationTimestamp.Time, Source: source, Message: "Created"})
+		for _, condition := range pod.Status.Conditions {
+			if condition.Type == v1.PodScheduled && condition.Status == v1.ConditionTrue {
+				message := "Scheduled"
+				if pod.Spec.NodeName != "" {
+					message = fmt.Sprintf("Scheduled on node %s", pod.Spec.NodeName)
+				}
+				entries = append(entries, TimelineEntry{Time: condition.LastTransitionTime.Time, Source: source, Message: message})
+			}
+		}
+
+		statuses := append(append([]v1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+		for _, status := range statuses {
+			containerSource := fmt.Sprintf("container/%s/%s", pod.Name, status.Name)
+			if terminated := status.LastTerminationState.Terminated; terminated != nil {
+				// the restarts are shown on the latest instance of the container
+				restarts := int32(0)
+				if status.State.Running == nil && status.State.Terminated == nil {
+					restarts = status.RestartCount
+				}
+				entries = append(entries, getTerminatedTimeline(containerSource, terminated, restarts)...)
+			}
+			if running := status.State.Running; running != nil {
+				message := "Started"
+				if status.RestartCount > 0 {
+					message = fmt.Sprintf("Started, restarts: %d", status.RestartCount)
+				}
+				entries = append(entries, TimelineEntry{Time: running.StartedAt.Time, Source: containerSource, Message: message})
+			}
+			if terminated := status.State.Terminated; terminated != nil {
+				entries = append(entries, getTerminatedTimeline(containerSource, terminated, status.RestartCount)...)
+			}
+		}
+
+		if pod.DeletionTimestamp != nil {
+			entries = append(entries, TimelineEntry{Time: pod.DeletionTimestamp.Time, Source: source, Message: "Deleted"})
+		}
+	}
+	return entries
+}
+
+// getTerminatedTimeline returns the start and the termination of an instance of a container, with the reason of the
+// termination, e.g. OOMKilled, and the exit code
+func getTerminatedTimeline(source string, terminated *v1.ContainerStateTerminated, restarts int32) []TimelineEntry {
+	entries := []TimelineEntry{}
+	if !terminated.StartedAt.IsZero() {
+		entries = append(entries, TimelineEntry{Time: terminated.StartedAt.Time, Source: source, Message: "Started"})
+	}
+	reason := terminated.Reason
+	if reason == "" {
+		reason = "Terminated"
+	}
+	message := fmt.Sprintf("%s, exit code: %d", reason, terminated.ExitCode)
+	if restarts > 0 {
+		message = fmt.Sprintf("%s, restarts: %d", message, restarts)
+	}
+	return append(entries, TimelineEntry{Time: terminated.FinishedAt.Time, Source: source, Message: message})
+}
+
+// SortTimeline sorts the entries by their times, keeping the order of entries with the same time
+func SortTimeline(entries []TimelineEntry) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Time.Before(entries[j].Time)
+	})
+}
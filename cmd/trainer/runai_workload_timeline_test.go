@@ -0,0 +1,67 @@
+package trainer
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGetPodsTimeline(t *testing.T) {
+	start := time.Date(2021, 3, 1, 10, 0, 0, 0, time.UTC)
+	at := func(minutes int) metav1.Time {
+		return metav1.NewTime(start.Add(time.Duration(minutes) * time.Minute))
+	}
+	pods := []v1.Pod{{
+		ObjectMeta: metav1.ObjectMeta{Name: "train1-0", CreationTimestamp: at(0)},
+		Spec:       v1.PodSpec{NodeName: "dgx-1"},
+		Status: v1.PodStatus{
+			Conditions: []v1.PodCondition{
+				{Type: v1.PodScheduled, Status: v1.ConditionTrue, LastTransitionTime: at(2)},
+				{Type: v1.PodReady, Status: v1.ConditionTrue, LastTransitionTime: at(9)},
+			},
+			ContainerStatuses: []v1.ContainerStatus{{
+				Name:                 "train1",
+				RestartCount:         1,
+				LastTerminationState: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{Reason: "OOMKilled", ExitCode: 137, StartedAt: at(3), FinishedAt: at(8)}},
+				State:                v1.ContainerState{Running: &v1.ContainerStateRunning{StartedAt: at(9)}},
+			}},
+		},
+	}}
+
+	entries := GetPodsTimeline(pods)
+	SortTimeline(entries)
+	expected := []TimelineEntry{
+		{Time: start, Source: "pod/train1-0", Message: "Created"},
+		{Time: at(2).Time, Source: "pod/train1-0", Message: "Scheduled on node dgx-1"},
+		{Time: at(3).Time, Source: "container/train1-0/train1", Message: "Started"},
+		{Time: at(8).Time, Source: "container/train1-0/train1", Message: "OOMKilled, exit code: 137"},
+		{Time: at(9).Time, Source: "container/train1-0/train1", Message: "Started, restarts: 1"},
+	}
+	if !reflect.DeepEqual(entries, expected) {
+		t.Errorf("expected %v, got %v", expected, entries)
+	}
+}
+
+func TestGetPodsTimelineOfCrashingContainer(t *testing.T) {
+	finished := metav1.NewTime(time.Date(2021, 3, 1, 10, 5, 0, 0, time.UTC))
+	pods := []v1.Pod{{
+		ObjectMeta: metav1.ObjectMeta{Name: "train1-0"},
+		Status: v1.PodStatus{
+			ContainerStatuses: []v1.ContainerStatus{{
+				Name:                 "train1",
+				RestartCount:         4,
+				LastTerminationState: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{ExitCode: 1, FinishedAt: finished}},
+				State:                v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+			}},
+		},
+	}}
+
+	entries := GetPodsTimeline(pods)
+	last := entries[len(entries)-1]
+	if last.Message != "Terminated, exit code: 1, restarts: 4" {
+		t.Errorf("expected the restarts on the last termination, got %s", last.Message)
+	}
+}
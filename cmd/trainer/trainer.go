@@ -15,11 +15,15 @@
 package trainer
 
 import (
+	"fmt"
 	"sort"
+	"strings"
 
 	"github.com/run-ai/runai-cli/pkg/client"
 	"github.com/run-ai/runai-cli/pkg/types"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/validation"
 )
 
 const (
@@ -153,26 +157,123 @@ func contains(s []v1.PodPhase, searchterm string) bool {
 	return false
 }
 
+// JobListOptions narrows the jobs listed by the trainers. The label selector, with the user label when the user is a
+// valid label value, is passed to the list calls of the job objects, and the node name to the list calls of their pods,
+// so only the matching objects are fetched from the cluster. The node name and the user are matched against the listed
+// jobs as well, as the user label is not set on the jobs of users whose names are not valid label values.
+type JobListOptions struct {
+	// LabelSelector selects the jobs and their pods by their labels
+	LabelSelector string
+	// NodeName keeps only the jobs with a pod on the node
+	NodeName string
+	// JobType keeps only the jobs of the type, one of JobTypes
+	JobType string
+	// User keeps only the jobs submitted by the user
+	User string
+}
+
+// JobTypes are the job types which jobs can be listed by
+var JobTypes = []string{RunaiTrainType, RunaiInteractiveType, RunaiInferenceType, MPIJobType}
+
 // GetAllJobs and filter them by `namespaceInfo` and optionaly filters out pod `filterStatus`
 func GetAllJobs(kubeClient *client.Client, namespaceInfo types.NamespaceInfo, filterStatus []v1.PodPhase) (jobs []TrainingJob, err error) {
+	return GetJobs(kubeClient, namespaceInfo, JobListOptions{}, filterStatus)
+}
+
+// GetJobs returns the jobs of `namespaceInfo` which match `options`, and optionaly filters out pod `filterStatus`
+func GetJobs(kubeClient *client.Client, namespaceInfo types.NamespaceInfo, options JobListOptions, filterStatus []v1.PodPhase) (jobs []TrainingJob, err error) {
+	listOptions := options
+	listOptions.LabelSelector = options.labelSelectorWithUser()
 	trainers := NewTrainers(kubeClient)
 	for _, trainer := range trainers {
-		if !trainer.IsEnabled() {
+		if !trainer.IsEnabled() || !options.matchesTrainer(trainer) {
 			continue
 		}
-		trainingJobs, err := trainer.ListTrainingJobs(namespaceInfo.Namespace)
+		trainingJobs, err := trainer.ListTrainingJobs(namespaceInfo.Namespace, listOptions)
 		if err != nil {
 			return nil, err
 		}
-		if len(filterStatus) != 0 {
-			for _, job := range trainingJobs {
-				if filterStatus != nil && contains(filterStatus, job.GetStatus()) {
-					jobs = append(jobs, job)
-				}
+		for _, job := range trainingJobs {
+			if !options.matchesJob(job) {
+				continue
 			}
-		} else {
-			jobs = append(jobs, trainingJobs...)
+			if len(filterStatus) != 0 && !contains(filterStatus, job.GetStatus()) {
+				continue
+			}
+			jobs = append(jobs, job)
 		}
 	}
 	return jobs, nil
 }
+
+// labelSelectorWithUser returns the label selector, which selects also the user label of the jobs when the user is
+// a valid label value, as the charts set the label only then
+func (options JobListOptions) labelSelectorWithUser() string {
+	if options.User == "" || len(validation.IsValidLabelValue(options.User)) > 0 {
+		return options.LabelSelector
+	}
+	userSelector := fmt.Sprintf("%s=%s", userFieldName, options.User)
+	if options.LabelSelector == "" {
+		return userSelector
+	}
+	return fmt.Sprintf("%s,%s", options.LabelSelector, userSelector)
+}
+
+// MPI jobs are listed only by the mpi trainer, and are either of the MPI type or of none of the other types
+func (options JobListOptions) matchesTrainer(trainer Trainer) bool {
+	if options.JobType == "" {
+		return true
+	}
+	return strings.EqualFold(options.JobType, MPIJobType) == (trainer.Type() == MpiTrainerType)
+}
+
+func (options JobListOptions) matchesJob(job TrainingJob) bool {
+	if options.User != "" && job.User() != options.User {
+		return false
+	}
+	if options.JobType != "" && !strings.EqualFold(options.JobType, MPIJobType) && !matchesJobType(options.JobType, job.Trainer()) {
+		return false
+	}
+
+	if options.NodeName == "" {
+		return true
+	}
+	for _, pod := range job.AllPods() {
+		if pod.Spec.NodeName == options.NodeName {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchesJob returns whether a job, which was not listed using the options, e.g. a job which was searched by its name,
+// matches the options
+func (options JobListOptions) MatchesJob(job TrainingJob) bool {
+	if options.JobType != "" {
+		_, isMPIJob := job.(*MPIJob)
+		if strings.EqualFold(options.JobType, MPIJobType) != isMPIJob {
+			return false
+		}
+	}
+	if options.LabelSelector != "" {
+		selector, err := labels.Parse(options.LabelSelector)
+		labeledJob, isLabeled := job.(labeledJob)
+		if err != nil || !isLabeled || !selector.Matches(labels.Set(labeledJob.Labels())) {
+			return false
+		}
+	}
+	return options.matchesJob(job)
+}
+
+// labeledJob is a job whose labels are the labels of the object which the label selector of the options selects it by
+type labeledJob interface {
+	Labels() map[string]string
+}
+
+// preemptible interactive jobs are interactive jobs as well
+func matchesJobType(jobType, trainerType string) bool {
+	if strings.EqualFold(jobType, RunaiInteractiveType) && trainerType == RunaiPreemptibleInteractiveType {
+		return true
+	}
+	return strings.EqualFold(jobType, trainerType)
+}
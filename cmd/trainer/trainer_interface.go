@@ -114,7 +114,7 @@ type Trainer interface {
 	// Get the type of trainer
 	Type() string
 
-	ListTrainingJobs(namespace string) ([]TrainingJob, error)
+	ListTrainingJobs(namespace string, options JobListOptions) ([]TrainingJob, error)
 
 	// Returns whether the trainer is enabled
 	IsEnabled() bool
@@ -42,7 +42,11 @@ var (
 	allMPIjobs []MPIJob
 )
 
-const MpiTrainerType = "mpijob"
+const (
+	MpiTrainerType = "mpijob"
+	// MPIJobType is the type which mpi jobs are listed by, as their trainer type is the type of their workers
+	MPIJobType = "MPI"
+)
 
 // MPI Job Information
 type MPIJob struct {
@@ -529,6 +533,13 @@ func (tt *MPIJobTrainer) getJobType(mpijob *mpi.MPIJob) string {
 	return RunaiTrainType
 }
 
+// NewMPITrainingJobOfPods returns the training job of mpiJob named name, made of its pods among pods which were already
+// fetched, e.g. from the cache of an informer. The launcher job of the mpijob is not looked up, so its image is not known.
+func NewMPITrainingJobOfPods(name string, mpiJob mpi.MPIJob, pods []v1.Pod) TrainingJob {
+	job, _ := (&MPIJobTrainer{}).getTrainingJobInfo(name, mpiJob.Namespace, mpiJob, pods, nil)
+	return job
+}
+
 // Get the training job from Cache
 func (tt *MPIJobTrainer) getTrainingJobInfo(name string, ns string, mpiJob mpi.MPIJob, allPods []v1.Pod, allJobs []batchv1.Job) (TrainingJob, error) {
 
@@ -733,10 +744,10 @@ func (tt *MPIJobTrainer) getMpiJobs(namespace string, listOptions metav1.ListOpt
 /**
 * List Training jobs
  */
-func (tt *MPIJobTrainer) ListTrainingJobs(namespace string) (jobs []TrainingJob, err error) {
+func (tt *MPIJobTrainer) ListTrainingJobs(namespace string, options JobListOptions) (jobs []TrainingJob, err error) {
 	jobs = []TrainingJob{}
 
-	mpiJobs, err := tt.getMpiJobs(namespace, metav1.ListOptions{})
+	mpiJobs, err := tt.getMpiJobs(namespace, metav1.ListOptions{LabelSelector: options.LabelSelector})
 
 	if err != nil {
 		return []TrainingJob{}, err
@@ -790,6 +801,11 @@ func (mj *MPIJob) Project() string {
 	return mj.mpijob.ObjectMeta.Labels["project"]
 }
 
+// Labels returns the labels of the mpijob
+func (mj *MPIJob) Labels() map[string]string {
+	return mj.mpijob.ObjectMeta.Labels
+}
+
 func (mj *MPIJob) User() string {
 	// Username stored as annotation to support special characters that label values are not allowed to have
 	if userFromAnnotation, exists := mj.mpijob.ObjectMeta.Annotations["user"]; exists && userFromAnnotation != "" {
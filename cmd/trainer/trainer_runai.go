@@ -16,6 +16,7 @@ import (
 	v1 "k8s.io/api/core/v1"
 	extensionsv1 "k8s.io/api/extensions/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
 )
@@ -47,6 +48,19 @@ func fieldSelectorByName(name string) string {
 	return fmt.Sprintf("metadata.name=%s", name)
 }
 
+// runaiPodsListOptions selects the pods of the runai scheduler, optionally only the ones with the labels and only the
+// ones on the node
+func runaiPodsListOptions(labelSelector, nodeName string) metav1.ListOptions {
+	fieldSelector := fmt.Sprintf("spec.schedulerName=%s", constants.SchedulerName)
+	if nodeName != "" {
+		fieldSelector = fmt.Sprintf("%s,spec.nodeName=%s", fieldSelector, nodeName)
+	}
+	return metav1.ListOptions{
+		FieldSelector: fieldSelector,
+		LabelSelector: labelSelector,
+	}
+}
+
 func (rt *RunaiTrainer) IsSupported(name, ns string) bool {
 	runaiJobList, err := rt.client.BatchV1().Jobs(ns).List(context.TODO(), metav1.ListOptions{
 		FieldSelector: fieldSelectorByName(name),
@@ -239,20 +253,64 @@ func (rt *RunaiTrainer) getRunaiTrainingJob(podSpecJob cmdTypes.PodTemplateJob,
 
 	filteredPods := rt.getPodsOfJob(podSpecJob)
 
-	lastCreatedPod := getLastCreatedPod(filteredPods)
+	serviceUrls, err := rt.getServiceUrlsByLastCreatedPod(getLastCreatedPod(filteredPods), namespace)
+	if err != nil {
+		return nil, err
+	}
+	return newRunaiTrainingJob(podSpecJob, filteredPods, serviceUrls), nil
+}
+
+// NewRunaiTrainingJobOfPods returns the training job of podSpecJob, made of its pods among pods which were already
+// fetched, e.g. from the cache of an informer. The service urls of the job are not looked up. It returns nil when the
+// job is not of the runai scheduler.
+func NewRunaiTrainingJobOfPods(podSpecJob cmdTypes.PodTemplateJob, pods []v1.Pod) TrainingJob {
+	if podSpecJob.Template.Spec.SchedulerName != constants.SchedulerName {
+		return nil
+	}
+	return newRunaiTrainingJob(podSpecJob, filterPodsOfJob(podSpecJob, pods), []string{})
+}
+
+func newRunaiTrainingJob(podSpecJob cmdTypes.PodTemplateJob, pods []v1.Pod, serviceUrls []string) TrainingJob {
+	lastCreatedPod := getLastCreatedPod(pods)
 	ownerResource := cmdTypes.Resource{
 		Uid:          string(podSpecJob.UID),
 		ResourceType: podSpecJob.Type,
 		Name:         podSpecJob.Name,
 	}
 
-	jobType := rt.getJobType(&podSpecJob)
+	jobType := getRunaiJobType(&podSpecJob)
 	status := getTrainingStatus(podSpecJob.ObjectMeta.Annotations, lastCreatedPod, podSpecJob.ExtraStatus)
-	serviceUrls, err := rt.getServiceUrlsByLastCreatedPod(lastCreatedPod, namespace)
-	if err != nil {
-		return nil, err
+	return NewRunaiWorkload(pods, lastCreatedPod, podSpecJob.CreationTimestamp, jobType, podSpecJob.Name, podSpecJob.Labels["app"] == "runaijob", serviceUrls, false, podSpecJob.Template.Spec, podSpecJob.Template.ObjectMeta, podSpecJob.ObjectMeta, podSpecJob.Namespace, ownerResource, status, podSpecJob.Parallelism, podSpecJob.Completions, podSpecJob.Failed, podSpecJob.Succeeded)
+}
+
+// filterPodsOfJob returns the pods of podSpecJob among pods, like getPodsOfJob does with the pods it lists. The pods of
+// a deployment are owned by its replica sets, so they are told by the selector of the deployment.
+func filterPodsOfJob(podSpecJob cmdTypes.PodTemplateJob, pods []v1.Pod) []v1.Pod {
+	var selector labels.Selector = labels.Nothing()
+	if podSpecJob.Type == cmdTypes.ResourceTypeDeployment && podSpecJob.Selector != nil {
+		selector = labels.SelectorFromSet(podSpecJob.Selector.MatchLabels)
 	}
-	return NewRunaiWorkload(filteredPods, lastCreatedPod, podSpecJob.CreationTimestamp, jobType, podSpecJob.Name, podSpecJob.Labels["app"] == "runaijob", serviceUrls, false, podSpecJob.Template.Spec, podSpecJob.Template.ObjectMeta, podSpecJob.ObjectMeta, podSpecJob.Namespace, ownerResource, status, podSpecJob.Parallelism, podSpecJob.Completions, podSpecJob.Failed, podSpecJob.Succeeded), nil
+
+	var filteredPods []v1.Pod
+	for _, pod := range pods {
+		if pod.Namespace != podSpecJob.Namespace || len(pod.OwnerReferences) == 0 {
+			continue
+		}
+		owner := pod.OwnerReferences[0]
+		if owner.UID == podSpecJob.UID || (owner.Kind == "ReplicaSet" && selector.Matches(labels.Set(pod.Labels))) {
+			filteredPods = append(filteredPods, pod)
+		}
+	}
+
+	if len(filteredPods) == 0 {
+		for _, pod := range pods {
+			if pod.Namespace == podSpecJob.Namespace && pod.Name == podSpecJob.Name && len(pod.OwnerReferences) == 0 {
+				return []v1.Pod{pod}
+			}
+		}
+		return []v1.Pod{}
+	}
+	return filteredPods
 }
 
 func (rt *RunaiTrainer) getPodsOfJob(podSpecJob cmdTypes.PodTemplateJob) []v1.Pod {
@@ -383,7 +441,7 @@ func (rt *RunaiTrainer) IsEnabled() bool {
 	return true
 }
 
-func (rt *RunaiTrainer) ListTrainingJobs(namespace string) ([]TrainingJob, error) {
+func (rt *RunaiTrainer) ListTrainingJobs(namespace string, options JobListOptions) ([]TrainingJob, error) {
 	services, err := rt.getServicesInNamespace(namespace)
 	if err != nil {
 		return []TrainingJob{}, err
@@ -406,35 +464,36 @@ func (rt *RunaiTrainer) ListTrainingJobs(namespace string) ([]TrainingJob, error
 
 	runaiJobs := []TrainingJob{}
 
-	jobPodMap, err := rt.getPodJobMap(namespace)
+	jobPodMap, err := rt.getPodJobMap(namespace, options)
 	if err != nil {
 		return nil, err
 	}
 
 	// Get all different job stypes to one general job type with pod spec
 	jobsForListCommand := []*cmdTypes.PodTemplateJob{}
-	runaiJobList, err := rt.client.BatchV1().Jobs(namespace).List(context.TODO(), metav1.ListOptions{})
+	listOptions := metav1.ListOptions{LabelSelector: options.LabelSelector}
+	runaiJobList, err := rt.client.BatchV1().Jobs(namespace).List(context.TODO(), listOptions)
 
 	for _, job := range runaiJobList.Items {
 		podTemplateJob := cmdTypes.PodTemplateJobFromJob(job)
 		jobsForListCommand = append(jobsForListCommand, podTemplateJob)
 	}
 
-	runaiStatefulSetsList, err := rt.client.AppsV1().StatefulSets(namespace).List(context.TODO(), metav1.ListOptions{})
+	runaiStatefulSetsList, err := rt.client.AppsV1().StatefulSets(namespace).List(context.TODO(), listOptions)
 
 	for _, statefulSet := range runaiStatefulSetsList.Items {
 		podTemplateJob := cmdTypes.PodTemplateJobFromStatefulSet(statefulSet)
 		jobsForListCommand = append(jobsForListCommand, podTemplateJob)
 	}
 
-	deploymentJobs, err := rt.client.AppsV1().Deployments(namespace).List(context.TODO(), metav1.ListOptions{})
+	deploymentJobs, err := rt.client.AppsV1().Deployments(namespace).List(context.TODO(), listOptions)
 
 	for _, deployment := range deploymentJobs.Items {
 		podTemplateJob := cmdTypes.PodTemplateJobFromDeployment(deployment)
 		jobsForListCommand = append(jobsForListCommand, podTemplateJob)
 	}
 
-	runaijobs, err := rt.runaijobClient.RunV1().RunaiJobs(namespace).List(metav1.ListOptions{})
+	runaijobs, err := rt.runaijobClient.RunV1().RunaiJobs(namespace).List(listOptions)
 
 	for _, runaijob := range runaijobs.Items {
 		scheme.Scheme.Default(&runaijob)
@@ -442,7 +501,7 @@ func (rt *RunaiTrainer) ListTrainingJobs(namespace string) ([]TrainingJob, error
 		jobsForListCommand = append(jobsForListCommand, podTemplateJob)
 	}
 
-	orpahnedPods, err := rt.getPodsWithoutOwner(namespace)
+	orpahnedPods, err := rt.getPodsWithoutOwner(namespace, options)
 	for _, pod := range orpahnedPods {
 		podTemplate := cmdTypes.PodTemplateJobFromPod(pod)
 		jobsForListCommand = append(jobsForListCommand, podTemplate)
@@ -456,6 +515,13 @@ func (rt *RunaiTrainer) ListTrainingJobs(namespace string) ([]TrainingJob, error
 		var jobInfo *RunaiJobInfo
 		if jobPodMap[job.UID] != nil {
 			jobInfo = jobPodMap[job.UID]
+			// the job is selected by its pods on the node, but is made of all its pods
+			if options.NodeName != "" && job.Type != cmdTypes.ResourceTypePod {
+				jobInfo.pods = rt.getPodsOfJob(*job)
+			}
+		} else if options.NodeName != "" {
+			// the job has no pods on the node
+			continue
 		} else {
 			// Create the job even if it does not have any pods currently
 			jobInfo = &RunaiJobInfo{}
@@ -478,7 +544,7 @@ func (rt *RunaiTrainer) ListTrainingJobs(namespace string) ([]TrainingJob, error
 		if job.Labels["app"] == "runaijob" {
 			jobInfo.createdByCLI = true
 		}
-		jobInfo.jobType = rt.getJobType(job)
+		jobInfo.jobType = getRunaiJobType(job)
 		jobInfo.status = job.ExtraStatus
 		jobInfo.parallelism = job.Parallelism
 		jobInfo.completions = job.Completions
@@ -487,6 +553,10 @@ func (rt *RunaiTrainer) ListTrainingJobs(namespace string) ([]TrainingJob, error
 	}
 
 	for _, jobInfo := range jobPodMap {
+		// the jobs of the pods whose controllers were not selected by the label selector are not listed
+		if options.LabelSelector != "" && jobInfo.deleted {
+			continue
+		}
 		lastCreatedPod := getLastCreatedPod(jobInfo.pods)
 
 		serviceUrls := []string{}
@@ -504,11 +574,10 @@ func (rt *RunaiTrainer) ListTrainingJobs(namespace string) ([]TrainingJob, error
 	return runaiJobs, nil
 }
 
-func (rt *RunaiTrainer) getPodJobMap(namespace string) (map[types.UID]*RunaiJobInfo, error) {
-	// Get all pods running with runai scheduler
-	runaiPods, err := rt.client.CoreV1().Pods(namespace).List(context.TODO(), metav1.ListOptions{
-		FieldSelector: fmt.Sprintf("spec.schedulerName=%s", constants.SchedulerName),
-	})
+func (rt *RunaiTrainer) getPodJobMap(namespace string, options JobListOptions) (map[types.UID]*RunaiJobInfo, error) {
+	// Get all pods running with runai scheduler, or only the ones on the node. The pods are not selected by the label
+	// selector, as the labels of the jobs are not necessarily set on their pods
+	runaiPods, err := rt.client.CoreV1().Pods(namespace).List(context.TODO(), runaiPodsListOptions("", options.NodeName))
 
 	if err != nil {
 		return nil, err
@@ -552,10 +621,8 @@ func (rt *RunaiTrainer) getPodJobMap(namespace string) (map[types.UID]*RunaiJobI
 	return jobPodMap, nil
 }
 
-func (rt *RunaiTrainer) getPodsWithoutOwner(namespace string) ([]v1.Pod, error) {
-	runaiPods, err := rt.client.CoreV1().Pods(namespace).List(context.TODO(), metav1.ListOptions{
-		FieldSelector: fmt.Sprintf("spec.schedulerName=%s", constants.SchedulerName),
-	})
+func (rt *RunaiTrainer) getPodsWithoutOwner(namespace string, options JobListOptions) ([]v1.Pod, error) {
+	runaiPods, err := rt.client.CoreV1().Pods(namespace).List(context.TODO(), runaiPodsListOptions(options.LabelSelector, options.NodeName))
 	if err != nil {
 		return nil, err
 	}
@@ -591,7 +658,7 @@ func getPodTopOwner(pod v1.Pod, replicasetByUid map[types.UID]appsv1.ReplicaSet)
 	return controller, uid
 }
 
-func (rt *RunaiTrainer) getJobType(job *cmdTypes.PodTemplateJob) string {
+func getRunaiJobType(job *cmdTypes.PodTemplateJob) string {
 	switch job.Labels[priorityClassNameLabel] {
 	case priorityClassInteractivePreemptible:
 		return RunaiPreemptibleInteractiveType
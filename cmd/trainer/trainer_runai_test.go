@@ -151,7 +151,7 @@ func TestJobInclusionInResourcesListCommand(t *testing.T) {
 	objects := []runtime.Object{pod, job}
 	kubeClient, runaiclient := getClientWithObject(objects)
 	trainer := RunaiTrainer{runaijobClient: runaiclient, client: kubeClient.GetClientset()}
-	jobs, _ := trainer.ListTrainingJobs(NAMESPACE)
+	jobs, _ := trainer.ListTrainingJobs(NAMESPACE, JobListOptions{})
 
 	trainJob := jobs[0]
 	resources := trainJob.Resources()
@@ -181,7 +181,7 @@ func TestDontListNonRunaiJobs(t *testing.T) {
 	kubeClient, runaiclient := getClientWithObject(objects)
 	trainer := RunaiTrainer{runaijobClient: runaiclient, client: kubeClient.GetClientset()}
 
-	jobs, _ := trainer.ListTrainingJobs(NAMESPACE)
+	jobs, _ := trainer.ListTrainingJobs(NAMESPACE, JobListOptions{})
 
 	if len(jobs) != 0 {
 		t.Errorf("Got too many resources from list command")
@@ -317,7 +317,7 @@ func TestStatefulsetJobIsInteractive(t *testing.T) {
 	kubeClient, runaiclient := getClientWithObject(objects)
 	trainer := RunaiTrainer{runaijobClient: runaiclient, client: kubeClient.GetClientset()}
 
-	jobs, _ := trainer.ListTrainingJobs(NAMESPACE)
+	jobs, _ := trainer.ListTrainingJobs(NAMESPACE, JobListOptions{})
 
 	jobType := jobs[0].Trainer()
 	if jobType != "Interactive" {
@@ -332,7 +332,7 @@ func TestJobIsNotInteractive(t *testing.T) {
 	kubeClient, runaiclient := getClientWithObject(objects)
 	trainer := RunaiTrainer{runaijobClient: runaiclient, client: kubeClient.GetClientset()}
 
-	jobs, _ := trainer.ListTrainingJobs(NAMESPACE)
+	jobs, _ := trainer.ListTrainingJobs(NAMESPACE, JobListOptions{})
 
 	jobType := jobs[0].Trainer()
 	if jobType != "Train" {
@@ -369,3 +369,138 @@ func createPodOwnedBy(podName string, labelSelector map[string]string, ownerUUID
 		},
 	}
 }
+
+func TestListJobsByLabelSelector(t *testing.T) {
+	job := getRunaiJob()
+	job.Labels = map[string]string{"team": "a"}
+	jobPod := createPodOwnedBy("pod1", job.Spec.Selector.MatchLabels, string(job.UID), string(cmdTypes.ResourceTypeJob), job.Name)
+
+	statefulSet := getRunaiStatefulSet()
+	statefulSet.Name = "other-job"
+	statefulSet.UID = "id2"
+	statefulSetPod := createPodOwnedBy("pod2", nil, string(statefulSet.UID), string(cmdTypes.ResourceTypeStatefulSet), statefulSet.Name)
+
+	objects := []runtime.Object{job, jobPod, statefulSet, statefulSetPod}
+	kubeClient, runaiclient := getClientWithObject(objects)
+	trainer := RunaiTrainer{runaijobClient: runaiclient, client: kubeClient.GetClientset()}
+
+	jobs, _ := trainer.ListTrainingJobs(NAMESPACE, JobListOptions{LabelSelector: "team=a"})
+
+	if len(jobs) != 1 || jobs[0].Name() != job.Name {
+		t.Fatalf("Expected only the labeled job to be listed, got %d jobs", len(jobs))
+	}
+	// the labels of the jobs are not set on their pods, so the pods should not be selected by them
+	if len(jobs[0].AllPods()) != 1 {
+		t.Errorf("Expected the pod of the labeled job to be listed with it")
+	}
+}
+
+func TestListJobsWithAllTheirPods(t *testing.T) {
+	job := getRunaiJob()
+	pod1 := createPodOwnedBy("pod1", job.Spec.Selector.MatchLabels, string(job.UID), string(cmdTypes.ResourceTypeJob), job.Name)
+	pod1.Spec.NodeName = "node1"
+	pod2 := createPodOwnedBy("pod2", job.Spec.Selector.MatchLabels, string(job.UID), string(cmdTypes.ResourceTypeJob), job.Name)
+	pod2.Spec.NodeName = "node2"
+
+	objects := []runtime.Object{job, pod1, pod2}
+	kubeClient, runaiclient := getClientWithObject(objects)
+	trainer := RunaiTrainer{runaijobClient: runaiclient, client: kubeClient.GetClientset()}
+	options := JobListOptions{NodeName: "node1"}
+
+	jobs, _ := trainer.ListTrainingJobs(NAMESPACE, options)
+
+	if len(jobs) != 1 || !options.matchesJob(jobs[0]) {
+		t.Fatalf("Expected the job with a pod on the node to be listed")
+	}
+	// the job is selected by its pod on the node, but is made of all its pods
+	if len(jobs[0].AllPods()) != 2 {
+		t.Errorf("Expected all the pods of the job to be listed with it, got %d pods", len(jobs[0].AllPods()))
+	}
+}
+
+func TestJobListOptionsMatchJob(t *testing.T) {
+	onNode := func(nodeNames ...string) []v1.Pod {
+		pods := []v1.Pod{}
+		for _, nodeName := range nodeNames {
+			pods = append(pods, v1.Pod{Spec: v1.PodSpec{NodeName: nodeName}})
+		}
+		return pods
+	}
+	withUser := func(user string) metav1.ObjectMeta {
+		return metav1.ObjectMeta{Annotations: map[string]string{userFieldName: user}}
+	}
+
+	tests := []struct {
+		name    string
+		options JobListOptions
+		job     *RunaiWorkload
+		matches bool
+	}{
+		{name: "no options", options: JobListOptions{}, job: &RunaiWorkload{trainerType: RunaiTrainType}, matches: true},
+		{name: "type", options: JobListOptions{JobType: "train"}, job: &RunaiWorkload{trainerType: RunaiTrainType}, matches: true},
+		{name: "other type", options: JobListOptions{JobType: RunaiInferenceType}, job: &RunaiWorkload{trainerType: RunaiTrainType}, matches: false},
+		{name: "preemptible interactive", options: JobListOptions{JobType: RunaiInteractiveType}, job: &RunaiWorkload{trainerType: RunaiPreemptibleInteractiveType}, matches: true},
+		{name: "node", options: JobListOptions{NodeName: "node1"}, job: &RunaiWorkload{pods: onNode("node1")}, matches: true},
+		{name: "node of one of the pods", options: JobListOptions{NodeName: "node1"}, job: &RunaiWorkload{pods: onNode("node2", "node1")}, matches: true},
+		{name: "other node", options: JobListOptions{NodeName: "node1"}, job: &RunaiWorkload{pods: onNode("node2")}, matches: false},
+		{name: "no pods", options: JobListOptions{NodeName: "node1"}, job: &RunaiWorkload{}, matches: false},
+		{name: "user", options: JobListOptions{User: "alice"}, job: &RunaiWorkload{jobMetadata: withUser("alice")}, matches: true},
+		{name: "other user", options: JobListOptions{User: "alice"}, job: &RunaiWorkload{jobMetadata: withUser("bob")}, matches: false},
+	}
+
+	for _, tt := range tests {
+		if matches := tt.options.matchesJob(tt.job); matches != tt.matches {
+			t.Errorf("%s: expected match to be %v, got %v", tt.name, tt.matches, matches)
+		}
+	}
+}
+
+func TestJobListOptionsMatchesSearchedJob(t *testing.T) {
+	labeled := &RunaiWorkload{trainerType: RunaiTrainType, jobMetadata: metav1.ObjectMeta{Labels: map[string]string{"team": "a"}}}
+
+	tests := []struct {
+		name    string
+		options JobListOptions
+		job     TrainingJob
+		matches bool
+	}{
+		{name: "label selector", options: JobListOptions{LabelSelector: "team=a"}, job: labeled, matches: true},
+		{name: "other labels", options: JobListOptions{LabelSelector: "team=b"}, job: labeled, matches: false},
+		{name: "mpi type", options: JobListOptions{JobType: MPIJobType}, job: labeled, matches: false},
+		{name: "mpi job", options: JobListOptions{JobType: MPIJobType}, job: &MPIJob{}, matches: true},
+		{name: "mpi job of another type", options: JobListOptions{JobType: RunaiTrainType}, job: &MPIJob{trainerType: RunaiTrainType}, matches: false},
+	}
+
+	for _, tt := range tests {
+		if matches := tt.options.MatchesJob(tt.job); matches != tt.matches {
+			t.Errorf("%s: expected match to be %v, got %v", tt.name, tt.matches, matches)
+		}
+	}
+}
+
+func TestJobListOptionsLabelSelectorWithUser(t *testing.T) {
+	tests := []struct {
+		name     string
+		options  JobListOptions
+		selector string
+	}{
+		{name: "no user", options: JobListOptions{LabelSelector: "team=a"}, selector: "team=a"},
+		{name: "user", options: JobListOptions{User: "alice"}, selector: "user=alice"},
+		{name: "user and labels", options: JobListOptions{LabelSelector: "team=a", User: "alice"}, selector: "team=a,user=alice"},
+		{name: "user which is not a label value", options: JobListOptions{LabelSelector: "team=a", User: "alice@example.com"}, selector: "team=a"},
+	}
+
+	for _, tt := range tests {
+		if selector := tt.options.labelSelectorWithUser(); selector != tt.selector {
+			t.Errorf("%s: expected the label selector %s, got %s", tt.name, tt.selector, selector)
+		}
+	}
+}
+
+func TestRunaiPodsListOptionsOfNode(t *testing.T) {
+	options := runaiPodsListOptions("team=a", "node1")
+
+	if options.FieldSelector != "spec.schedulerName=runai-scheduler,spec.nodeName=node1" || options.LabelSelector != "team=a" {
+		t.Errorf("Expected the pods of the runai scheduler on the node, got %v", options)
+	}
+}
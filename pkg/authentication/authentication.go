@@ -12,6 +12,7 @@ import (
 	"github.com/run-ai/runai-cli/pkg/authentication/types"
 	log "github.com/sirupsen/logrus"
 	"golang.org/x/oauth2"
+	"os/user"
 )
 
 func GetCurrentAuthenticateUser() (string, error) {
@@ -27,6 +28,19 @@ func GetCurrentAuthenticateUser() (string, error) {
 	return token.Email, nil
 }
 
+// GetCurrentUser returns the user which jobs are submitted by: the email of the authenticated user,
+// or the OS user when the user is not authenticated
+func GetCurrentUser() (string, error) {
+	if authenticatedUser, err := GetCurrentAuthenticateUser(); err == nil && authenticatedUser != "" {
+		return authenticatedUser, nil
+	}
+	osUser, err := user.Current()
+	if err != nil {
+		return "", err
+	}
+	return osUser.Username, nil
+}
+
 func GetCurrentAuthenticateUserSubject() (string, string, error) {
 	idToken, err := kubeconfig.GetCurrentUserIdToken()
 	if err != nil {
@@ -0,0 +1,108 @@
+package jobs
+
+import (
+	"strings"
+	"time"
+
+	"github.com/run-ai/runai-cli/cmd/trainer"
+	prom "github.com/run-ai/runai-cli/pkg/prometheus"
+	"github.com/run-ai/runai-cli/pkg/types"
+	v1 "k8s.io/api/core/v1"
+)
+
+// idleLookback is the minimal window of the gpu utilization history which the idle time of the jobs is computed over
+const idleLookback = 24 * time.Hour
+
+// GetIdleJobs returns the running jobs whose allocated gpus have been utilized below 1% for at least `idleFor`.
+// The gpu utilization history of each job is joined with its running pods, so gpus are idle only since the pods
+// started, and only since the first sample of the history. Jobs without utilization metrics are not reported, as
+// their gpus may not be idle.
+func GetIdleJobs(client prom.RangeQueryClient, trainingJobs []trainer.TrainingJob, idleFor time.Duration, now time.Time) ([]types.IdleJobView, error) {
+	window := idleFor
+	if window < idleLookback {
+		window = idleLookback
+	}
+	step := GetUsageStep(window)
+	start := now.Add(-window)
+	utilizations, err := client.QueryRange(jobPQs[gpuUtilizationPQ], start, now, step)
+	if err != nil {
+		return nil, err
+	}
+
+	utilizationsByPodGroup := map[string]map[int64]float64{}
+	for _, series := range utilizations.Result {
+		utilizationsByPodGroup[series.Metric[prometheusJobLabelID]] = getSeriesValues(series)
+	}
+
+	views := []types.IdleJobView{}
+	for _, job := range trainingJobs {
+		if job.CurrentAllocatedGPUs() == 0 {
+			continue
+		}
+		runningSince := getRunningSince(job.AllPods())
+		values := utilizationsByPodGroup[job.GetPodGroupUUID()]
+		if runningSince == nil || len(values) == 0 {
+			continue
+		}
+
+		var lastActive *time.Time
+		firstSample := now
+		for timestamp, utilization := range values {
+			sampleTime := time.Unix(timestamp, 0)
+			if sampleTime.Before(firstSample) {
+				firstSample = sampleTime
+			}
+			if utilization >= idleUtilizationThreshold && (lastActive == nil || sampleTime.After(*lastActive)) {
+				lastActive = &sampleTime
+			}
+		}
+
+		// the gpus are known to be idle since the last time they were active, the pods started or the first sample of
+		// the history, as the series may cover only a part of the window, e.g. when the metrics were not collected
+		idleSince := *runningSince
+		if lastActive != nil && lastActive.After(idleSince) {
+			idleSince = *lastActive
+		}
+		if idleSince.Before(firstSample) {
+			idleSince = firstSample
+		}
+		if now.Sub(idleSince) < idleFor {
+			continue
+		}
+
+		views = append(views, types.IdleJobView{
+			Name:          job.Name(),
+			Project:       job.Project(),
+			User:          job.User(),
+			Type:          job.Trainer(),
+			Node:          getIdleJobNode(job),
+			AllocatedGPUs: job.CurrentAllocatedGPUs(),
+			IdleFor:       now.Sub(idleSince),
+			LastActive:    lastActive,
+		})
+	}
+	return views, nil
+}
+
+// getRunningSince returns the time the running pods hold their gpus since, or nil if no pod is running
+func getRunningSince(pods []v1.Pod) *time.Time {
+	var runningSince *time.Time
+	for _, pod := range pods {
+		if pod.Status.Phase != v1.PodRunning || pod.Status.StartTime == nil {
+			continue
+		}
+		if runningSince == nil || pod.Status.StartTime.Time.Before(*runningSince) {
+			startTime := pod.Status.StartTime.Time
+			runningSince = &startTime
+		}
+	}
+	return runningSince
+}
+
+func getIdleJobNode(job trainer.TrainingJob) string {
+	nodeName := job.HostIPOfChief()
+	if strings.Contains(nodeName, ", ") {
+		return "<multiple>"
+	}
+	return nodeName
+}
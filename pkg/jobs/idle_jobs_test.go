@@ -0,0 +1,109 @@
+package jobs
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/run-ai/runai-cli/cmd/trainer"
+	cmdutil "github.com/run-ai/runai-cli/cmd/util"
+	prom "github.com/run-ai/runai-cli/pkg/prometheus"
+	cmdTypes "github.com/run-ai/runai-cli/pkg/types"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+)
+
+// interactiveJob returns a job with a gpu, whose pod runs since `startTime`
+func interactiveJob(name, podGroupUUID string, startTime time.Time) trainer.TrainingJob {
+	pod := v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name + "-0", Namespace: NAMESPACE},
+		Status:     v1.PodStatus{Phase: v1.PodRunning, HostIP: "test_node", StartTime: &metav1.Time{Time: startTime}},
+	}
+	podSpec := v1.PodSpec{Containers: []v1.Container{{Resources: v1.ResourceRequirements{Limits: v1.ResourceList{
+		cmdutil.NVIDIAGPUResourceName: resource.MustParse("1"),
+	}}}}}
+	podMetadata := metav1.ObjectMeta{Labels: map[string]string{"project": "test_project", "user": "test_user"}}
+	jobMetadata := metav1.ObjectMeta{Name: name, Namespace: NAMESPACE, UID: k8stypes.UID(podGroupUUID)}
+	return trainer.NewRunaiWorkload([]v1.Pod{pod}, &pod, metav1.NewTime(startTime), trainer.RunaiInteractiveType, name, true, nil, false,
+		podSpec, podMetadata, jobMetadata, NAMESPACE, cmdTypes.Resource{}, "Running", 1, 1, 0, 0)
+}
+
+// utilizationSeries returns the gpu utilization of a pod group at the times
+func utilizationSeries(podGroupUUID string, values map[time.Time]string) prom.RangeMetricResult {
+	series := prom.RangeMetricResult{Metric: map[string]string{prometheusJobLabelID: podGroupUUID}}
+	for timestamp, value := range values {
+		series.Values = append(series.Values, []prom.MetricValue{float64(timestamp.Unix()), value})
+	}
+	return series
+}
+
+var _ = Describe("Idle Jobs", func() {
+	var (
+		now          time.Time
+		client       *fakeRangeQueryClient
+		trainingJobs []trainer.TrainingJob
+	)
+	BeforeEach(func() {
+		now = time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC)
+		trainingJobs = []trainer.TrainingJob{
+			interactiveJob("idle", "id1", now.Add(-5*time.Hour)),
+			interactiveJob("active", "id2", now.Add(-5*time.Hour)),
+			interactiveJob("started", "id3", now.Add(-time.Hour)),
+			interactiveJob("no-metrics", "id4", now.Add(-5*time.Hour)),
+			interactiveJob("partial-metrics", "id5", now.Add(-5*time.Hour)),
+		}
+		client = &fakeRangeQueryClient{utilizations: []prom.RangeMetricResult{
+			utilizationSeries("id1", map[time.Time]string{
+				now.Add(-4 * time.Hour): "80",
+				now.Add(-3 * time.Hour): "0",
+				now.Add(-2 * time.Hour): "0",
+				now.Add(-time.Hour):     "0.5",
+			}),
+			utilizationSeries("id2", map[time.Time]string{
+				now.Add(-3 * time.Hour):    "0",
+				now.Add(-20 * time.Minute): "50",
+				now.Add(-10 * time.Minute): "0",
+			}),
+			utilizationSeries("id3", map[time.Time]string{
+				now.Add(-90 * time.Minute): "0",
+				now.Add(-30 * time.Minute): "0",
+			}),
+			utilizationSeries("id5", map[time.Time]string{
+				now.Add(-90 * time.Minute): "0",
+				now.Add(-30 * time.Minute): "0",
+			}),
+		}}
+	})
+
+	It("reports the jobs whose gpus are idle for longer than the threshold", func() {
+		views, err := GetIdleJobs(client, trainingJobs, 2*time.Hour, now)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(views).To(HaveLen(1))
+		Expect(views[0].Name).To(Equal("idle"))
+		Expect(views[0].Project).To(Equal("test_project"))
+		Expect(views[0].AllocatedGPUs).To(Equal(1.0))
+		Expect(views[0].IdleFor).To(Equal(4 * time.Hour))
+		Expect(*views[0].LastActive).To(BeTemporally("==", now.Add(-4*time.Hour)))
+	})
+
+	It("counts the idle time only since the pods started", func() {
+		views, err := GetIdleJobs(client, trainingJobs, 30*time.Minute, now)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(views).To(HaveLen(3))
+		Expect(views[1].Name).To(Equal("started"))
+		Expect(views[1].IdleFor).To(Equal(time.Hour))
+		Expect(views[1].LastActive).To(BeNil())
+	})
+
+	It("counts the idle time only since the first sample of the history", func() {
+		views, err := GetIdleJobs(client, trainingJobs, 30*time.Minute, now)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(views).To(HaveLen(3))
+		Expect(views[2].Name).To(Equal("partial-metrics"))
+		Expect(views[2].IdleFor).To(Equal(90 * time.Minute))
+		Expect(views[2].LastActive).To(BeNil())
+	})
+})
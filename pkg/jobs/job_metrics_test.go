@@ -100,7 +100,7 @@ var _ = Describe("Job Information Collection", func() {
 				objects := []runtime.Object{pod, job}
 				client, runaiclient := util.GetClientWithObject(objects)
 				jobTrainer := trainer.NewRunaiTrainerWithClients(client, runaiclient)
-				jobs, err := jobTrainer.ListTrainingJobs(NAMESPACE)
+				jobs, err := jobTrainer.ListTrainingJobs(NAMESPACE, trainer.JobListOptions{})
 				if err != nil {
 					Fail(fmt.Sprintf("%v", err))
 				}
@@ -117,7 +117,7 @@ var _ = Describe("Job Information Collection", func() {
 				objects := []runtime.Object{pod, job}
 				client, runaiclient := util.GetClientWithObject(objects)
 				jobTrainer := trainer.NewRunaiTrainerWithClients(client, runaiclient)
-				jobs, err := jobTrainer.ListTrainingJobs(NAMESPACE)
+				jobs, err := jobTrainer.ListTrainingJobs(NAMESPACE, trainer.JobListOptions{})
 				if err != nil {
 					Fail(fmt.Sprintf("%v", err))
 				}
@@ -130,7 +130,7 @@ var _ = Describe("Job Information Collection", func() {
 				objects := []runtime.Object{pod, job}
 				client, runaiclient := util.GetClientWithObject(objects)
 				jobTrainer := trainer.NewRunaiTrainerWithClients(client, runaiclient)
-				jobs, err := jobTrainer.ListTrainingJobs(NAMESPACE)
+				jobs, err := jobTrainer.ListTrainingJobs(NAMESPACE, trainer.JobListOptions{})
 				if err != nil {
 					Fail(fmt.Sprintf("%v", err))
 				}
@@ -151,7 +151,7 @@ var _ = Describe("Job Information Collection", func() {
 				objects := []runtime.Object{pod, job, job3, pod2, job2, pod3}
 				client, runaiclient := util.GetClientWithObject(objects)
 				jobTrainer := trainer.NewRunaiTrainerWithClients(client, runaiclient)
-				jobs, err := jobTrainer.ListTrainingJobs(NAMESPACE)
+				jobs, err := jobTrainer.ListTrainingJobs(NAMESPACE, trainer.JobListOptions{})
 				if err != nil {
 					Fail(fmt.Sprintf("%v", err))
 				}
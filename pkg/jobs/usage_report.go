@@ -0,0 +1,291 @@
+package jobs
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/run-ai/runai-cli/cmd/trainer"
+	prom "github.com/run-ai/runai-cli/pkg/prometheus"
+	"github.com/run-ai/runai-cli/pkg/types"
+	log "github.com/sirupsen/logrus"
+	v1 "k8s.io/api/core/v1"
+)
+
+const (
+	UsageByUser     = "user"
+	UsageByProject  = "project"
+	UsageByJob      = "job"
+	UsageByNodeType = "node-type"
+
+	// DefaultGPUType is the type of the cost of the gpus whose type has no cost of its own
+	DefaultGPUType = "default"
+
+	allocatedGPUsRangeQuery = `runai_allocated_gpus`
+	// allocatedGPUsOfProjectRangeQuery filters the allocated gpus by the queue of the project
+	allocatedGPUsOfProjectRangeQuery = `runai_allocated_gpus{queue_name="%s"}`
+	// idleUtilizationThreshold is the gpu utilization, in percents, under which allocated gpus are idle
+	idleUtilizationThreshold = 1
+	// maxUsagePoints is the maximal number of values of a series in a range query, below the limit of prometheus
+	maxUsagePoints    = 10000
+	unknownUsageValue = "<unknown>"
+	nodeTypeLabel     = "run.ai/type"
+	gpuTypeLabel      = "nvidia.com/gpu.product"
+
+	// the labels of runai_allocated_gpus, which pod groups whose jobs no longer exist are reported by
+	workloadNameMetricLabel = "workload_name"
+	podGroupNameMetricLabel = "pod_group_name"
+	queueNameMetricLabel    = "queue_name"
+	// the labels of the user, the node type and the gpu type, which are used when the metric has them
+	userMetricLabel     = "user"
+	nodeTypeMetricLabel = "node_type"
+	gpuTypeMetricLabel  = "gpu_type"
+)
+
+var UsageGroupings = []string{UsageByUser, UsageByProject, UsageByJob, UsageByNodeType}
+
+// UsageJobInfo is the job of a pod group, which the usage of the pod group is reported by
+type UsageJobInfo struct {
+	Name     string
+	Project  string
+	User     string
+	NodeType string
+	GPUType  string
+}
+
+// UsageReportOptions are the window, the grouping and the costs of the usage report
+type UsageReportOptions struct {
+	Since time.Duration
+	By    string
+	// Project is the project to report the usage of, or empty for all the projects
+	Project string
+	// GPUHourCosts are the costs of a gpu hour by gpu type, the cost of DefaultGPUType is used for other types
+	GPUHourCosts map[string]float64
+}
+
+// usageTotals are the gpu seconds of a row of the report
+type usageTotals struct {
+	gpuSeconds      float64
+	utilizedSeconds float64
+	idleSeconds     float64
+	cost            float64
+	// the pod groups of the row whose jobs no longer exist
+	deletedPodGroups map[string]bool
+}
+
+// NewUsageJobInfo returns the job of a pod group. The gpu type is the type of the node of the chief pod of the job.
+func NewUsageJobInfo(job trainer.TrainingJob, nodes []v1.Node) UsageJobInfo {
+	info := UsageJobInfo{Name: job.Name(), Project: job.Project(), User: job.User()}
+	chiefPod := job.ChiefPod()
+	if chiefPod == nil {
+		return info
+	}
+	info.NodeType = chiefPod.Spec.NodeSelector[nodeTypeLabel]
+	for _, node := range nodes {
+		if node.Name == chiefPod.Spec.NodeName {
+			info.GPUType = node.Labels[gpuTypeLabel]
+			if info.NodeType == "" {
+				info.NodeType = node.Labels[nodeTypeLabel]
+			}
+		}
+	}
+	return info
+}
+
+// ParseGPUHourCosts parses costs of gpu hours in the format of TYPE=COST, e.g. A100=2.5
+func ParseGPUHourCosts(costs []string) (map[string]float64, error) {
+	gpuHourCosts := map[string]float64{}
+	for _, cost := range costs {
+		parts := strings.SplitN(cost, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, fmt.Errorf("invalid gpu hour cost %s, the format is TYPE=COST", cost)
+		}
+		value, err := strconv.ParseFloat(parts[1], 64)
+		if err != nil || value < 0 {
+			return nil, fmt.Errorf("invalid gpu hour cost %s, the cost must be a non negative number", cost)
+		}
+		gpuHourCosts[parts[0]] = value
+	}
+	return gpuHourCosts, nil
+}
+
+// GetUsageStep returns the resolution of the range queries of the usage over the window, in whole minutes
+func GetUsageStep(since time.Duration) time.Duration {
+	step := time.Duration(math.Ceil(float64(since)/maxUsagePoints/float64(time.Minute))) * time.Minute
+	if step < time.Minute {
+		return time.Minute
+	}
+	return step
+}
+
+// GetUsageReport computes the gpu hours, the average utilization and the idle gpu hours of the pod groups over the
+// window from prometheus range queries, grouped by the users, the projects, the jobs or the node types of their jobs.
+// Pod groups whose jobs no longer exist are reported by the labels of their metrics, so their user, node type and gpu
+// type are known only when the metrics have them, and the rows count them as deleted jobs.
+func GetUsageReport(client prom.RangeQueryClient, jobsByPodGroup map[string]UsageJobInfo, options UsageReportOptions, now time.Time) ([]types.UsageView, error) {
+	step := GetUsageStep(options.Since)
+	start := now.Add(-options.Since)
+	allocationsQuery := allocatedGPUsRangeQuery
+	if options.Project != "" {
+		allocationsQuery = fmt.Sprintf(allocatedGPUsOfProjectRangeQuery, options.Project)
+	}
+	allocations, err := client.QueryRange(allocationsQuery, start, now, step)
+	if err != nil {
+		return nil, err
+	}
+	utilizations, err := client.QueryRange(jobPQs[gpuUtilizationPQ], start, now, step)
+	if err != nil {
+		return nil, err
+	}
+
+	utilizationsByPodGroup := map[string]map[int64]float64{}
+	for _, series := range utilizations.Result {
+		utilizationsByPodGroup[series.Metric[prometheusJobLabelID]] = getSeriesValues(series)
+	}
+
+	totals := map[string]*usageTotals{}
+	for _, series := range allocations.Result {
+		podGroupUUID := series.Metric[prometheusJobLabelID]
+		info, exists := jobsByPodGroup[podGroupUUID]
+		if !exists {
+			info = getDeletedUsageJobInfo(series.Metric)
+		}
+		if options.Project != "" && info.Project != options.Project {
+			continue
+		}
+
+		key := getUsageKey(info, options.By)
+		rowTotals, found := totals[key]
+		if !found {
+			rowTotals = &usageTotals{deletedPodGroups: map[string]bool{}}
+			totals[key] = rowTotals
+		}
+		if !exists {
+			rowTotals.deletedPodGroups[podGroupUUID] = true
+		}
+
+		podGroupUtilizations := utilizationsByPodGroup[podGroupUUID]
+		for timestamp, gpus := range getSeriesValues(series) {
+			gpuSeconds := gpus * step.Seconds()
+			utilization := podGroupUtilizations[timestamp]
+			rowTotals.gpuSeconds += gpuSeconds
+			rowTotals.utilizedSeconds += gpuSeconds * utilization / 100
+			if utilization < idleUtilizationThreshold {
+				rowTotals.idleSeconds += gpuSeconds
+			}
+			rowTotals.cost += gpuSeconds / time.Hour.Seconds() * getGPUHourCost(options.GPUHourCosts, info.GPUType)
+		}
+	}
+
+	keys := []string{}
+	for key := range totals {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if totals[keys[i]].gpuSeconds != totals[keys[j]].gpuSeconds {
+			return totals[keys[i]].gpuSeconds > totals[keys[j]].gpuSeconds
+		}
+		return keys[i] < keys[j]
+	})
+
+	views := []types.UsageView{}
+	for _, key := range keys {
+		rowTotals := totals[key]
+		view := types.UsageView{
+			GPUHours:     rowTotals.gpuSeconds / time.Hour.Seconds(),
+			IdleGPUHours: rowTotals.idleSeconds / time.Hour.Seconds(),
+			Cost:         rowTotals.cost,
+			DeletedJobs:  len(rowTotals.deletedPodGroups),
+		}
+		if rowTotals.gpuSeconds > 0 {
+			view.AvgUtilization = rowTotals.utilizedSeconds / rowTotals.gpuSeconds * 100
+		}
+		setUsageKey(&view, key, options.By)
+		views = append(views, view)
+	}
+	return views, nil
+}
+
+// getDeletedUsageJobInfo returns the job of a pod group which no longer exists by the labels of its allocated gpus.
+// The user, the node type and the gpu type are empty when the metric has no labels of them.
+func getDeletedUsageJobInfo(labels map[string]string) UsageJobInfo {
+	name := labels[workloadNameMetricLabel]
+	if name == "" {
+		name = labels[podGroupNameMetricLabel]
+	}
+	return UsageJobInfo{
+		Name:     name,
+		Project:  labels[queueNameMetricLabel],
+		User:     labels[userMetricLabel],
+		NodeType: labels[nodeTypeMetricLabel],
+		GPUType:  labels[gpuTypeMetricLabel],
+	}
+}
+
+// getSeriesValues returns the values of a series by their unix times
+func getSeriesValues(series prom.RangeMetricResult) map[int64]float64 {
+	values := map[int64]float64{}
+	for _, value := range series.Values {
+		if len(value) != 2 {
+			continue
+		}
+		timestamp, isNumber := value[0].(float64)
+		text, isString := value[1].(string)
+		if !isNumber || !isString {
+			continue
+		}
+		number, err := strconv.ParseFloat(text, 64)
+		if err != nil || math.IsNaN(number) {
+			log.Debugf("Failed to parse the metric value %s: %v", text, err)
+			continue
+		}
+		values[int64(timestamp)] = number
+	}
+	return values
+}
+
+// getUsageKey returns the value of the job which the usage is grouped by. Jobs are grouped by their projects as well,
+// as jobs of different projects may have the same names.
+func getUsageKey(info UsageJobInfo, by string) string {
+	var key string
+	switch by {
+	case UsageByProject:
+		key = info.Project
+	case UsageByJob:
+		key = info.Project + "/" + info.Name
+	case UsageByNodeType:
+		key = info.NodeType
+	default:
+		key = info.User
+	}
+	if key == "" || key == "/" {
+		return unknownUsageValue
+	}
+	return key
+}
+
+func setUsageKey(view *types.UsageView, key string, by string) {
+	switch by {
+	case UsageByProject:
+		view.Project = key
+	case UsageByJob:
+		view.Job = key
+		if parts := strings.SplitN(key, "/", 2); len(parts) == 2 {
+			view.Project, view.Job = parts[0], parts[1]
+		}
+	case UsageByNodeType:
+		view.NodeType = key
+	default:
+		view.User = key
+	}
+}
+
+func getGPUHourCost(gpuHourCosts map[string]float64, gpuType string) float64 {
+	if cost, found := gpuHourCosts[gpuType]; found && gpuType != "" {
+		return cost
+	}
+	return gpuHourCosts[DefaultGPUType]
+}
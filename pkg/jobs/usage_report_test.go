@@ -0,0 +1,157 @@
+package jobs
+
+import (
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	prom "github.com/run-ai/runai-cli/pkg/prometheus"
+	"github.com/run-ai/runai-cli/pkg/types"
+)
+
+// fakeRangeQueryClient returns the series of the allocated gpus and of the gpu utilization
+type fakeRangeQueryClient struct {
+	allocations  []prom.RangeMetricResult
+	utilizations []prom.RangeMetricResult
+	queries      []string
+}
+
+func (client *fakeRangeQueryClient) QueryRange(query string, start, end time.Time, step time.Duration) (*prom.RangeMetricData, error) {
+	client.queries = append(client.queries, query)
+	if strings.HasPrefix(query, allocatedGPUsRangeQuery) {
+		return &prom.RangeMetricData{Result: client.allocations}, nil
+	}
+	return &prom.RangeMetricData{Result: client.utilizations}, nil
+}
+
+func usageSeries(podGroupUUID string, values ...string) prom.RangeMetricResult {
+	series := prom.RangeMetricResult{Metric: map[string]string{prometheusJobLabelID: podGroupUUID}}
+	for i, value := range values {
+		series.Values = append(series.Values, []prom.MetricValue{float64(1614592800 + i*60), value})
+	}
+	return series
+}
+
+var _ = Describe("Usage Report", func() {
+	var (
+		client         *fakeRangeQueryClient
+		jobsByPodGroup map[string]UsageJobInfo
+		options        UsageReportOptions
+	)
+	BeforeEach(func() {
+		// a minute of every value, as the step of a window of an hour is a minute
+		client = &fakeRangeQueryClient{
+			allocations: []prom.RangeMetricResult{
+				usageSeries("id1", "2", "2", "2"),
+				usageSeries("id2", "1", "1"),
+				usageSeries("id3", "4"),
+			},
+			utilizations: []prom.RangeMetricResult{
+				usageSeries("id1", "50", "0", "100"),
+				usageSeries("id2", "30", "60"),
+			},
+		}
+		jobsByPodGroup = map[string]UsageJobInfo{
+			"id1": {Name: "train1", Project: "team-a", User: "alice", GPUType: "A100"},
+			"id2": {Name: "train2", Project: "team-b", User: "alice", GPUType: "V100"},
+		}
+		options = UsageReportOptions{Since: time.Hour, By: UsageByUser}
+	})
+
+	It("groups the usage by user", func() {
+		views, err := GetUsageReport(client, jobsByPodGroup, options, time.Now())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(views).To(HaveLen(2))
+		Expect(views[0].User).To(Equal("alice"))
+		Expect(views[0].GPUHours).To(BeNumerically("~", 8.0/60))
+		Expect(views[0].IdleGPUHours).To(BeNumerically("~", 2.0/60))
+		Expect(views[0].AvgUtilization).To(BeNumerically("~", (2*50+2*100+30+60)/8.0))
+		Expect(views[0].DeletedJobs).To(Equal(0))
+		Expect(views[1]).To(Equal(types.UsageView{User: unknownUsageValue, GPUHours: 4.0 / 60, IdleGPUHours: 4.0 / 60, DeletedJobs: 1}))
+	})
+
+	It("groups the usage by job of a project", func() {
+		options.By = UsageByJob
+		options.Project = "team-a"
+		views, err := GetUsageReport(client, jobsByPodGroup, options, time.Now())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(views).To(HaveLen(1))
+		Expect(views[0].Project).To(Equal("team-a"))
+		Expect(views[0].Job).To(Equal("train1"))
+		Expect(client.queries).To(ContainElement(`runai_allocated_gpus{queue_name="team-a"}`))
+	})
+
+	It("reports deleted jobs by the labels of the allocated gpus", func() {
+		deleted := usageSeries("id4", "1", "1")
+		deleted.Metric["pod_group_name"] = "pg-train3-0"
+		deleted.Metric["workload_name"] = "train3"
+		deleted.Metric["workload_type"] = "Train"
+		deleted.Metric["queue_name"] = "team-c"
+		withoutWorkload := usageSeries("id5", "1")
+		withoutWorkload.Metric["pod_group_name"] = "pg-train4-0"
+		withoutWorkload.Metric["queue_name"] = "team-c"
+		client.allocations = []prom.RangeMetricResult{deleted, withoutWorkload}
+
+		options.By = UsageByJob
+		views, err := GetUsageReport(client, jobsByPodGroup, options, time.Now())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(views).To(HaveLen(2))
+		Expect(views[0].Project).To(Equal("team-c"))
+		Expect(views[0].Job).To(Equal("train3"))
+		Expect(views[1].Project).To(Equal("team-c"))
+		Expect(views[1].Job).To(Equal("pg-train4-0"))
+
+		options.By = UsageByUser
+		views, err = GetUsageReport(client, jobsByPodGroup, options, time.Now())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(views).To(HaveLen(1))
+		Expect(views[0].User).To(Equal(unknownUsageValue))
+		Expect(views[0].DeletedJobs).To(Equal(2))
+	})
+
+	It("reports deleted jobs by the user and the gpu type of their metrics", func() {
+		deleted := usageSeries("id4", "1", "1")
+		deleted.Metric["workload_name"] = "train3"
+		deleted.Metric["queue_name"] = "team-c"
+		deleted.Metric["user"] = "bob"
+		deleted.Metric["gpu_type"] = "A100"
+		client.allocations = []prom.RangeMetricResult{deleted}
+
+		options.GPUHourCosts = map[string]float64{"A100": 3, DefaultGPUType: 1.5}
+		views, err := GetUsageReport(client, jobsByPodGroup, options, time.Now())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(views).To(HaveLen(1))
+		Expect(views[0].User).To(Equal("bob"))
+		Expect(views[0].Cost).To(BeNumerically("~", 2.0/60*3))
+		Expect(views[0].DeletedJobs).To(Equal(1))
+	})
+
+	It("computes the cost by gpu type", func() {
+		options.By = UsageByProject
+		options.GPUHourCosts = map[string]float64{"A100": 3, DefaultGPUType: 1.5}
+		views, err := GetUsageReport(client, jobsByPodGroup, options, time.Now())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(views).To(HaveLen(3))
+		Expect(views[0].Project).To(Equal("team-a"))
+		Expect(views[0].Cost).To(BeNumerically("~", 6.0/60*3))
+		Expect(views[2].Project).To(Equal("team-b"))
+		Expect(views[2].Cost).To(BeNumerically("~", 2.0/60*1.5))
+	})
+
+	It("parses the gpu hour costs", func() {
+		costs, err := ParseGPUHourCosts([]string{"A100=2.5", "default=1"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(costs).To(Equal(map[string]float64{"A100": 2.5, DefaultGPUType: 1}))
+		_, err = ParseGPUHourCosts([]string{"A100"})
+		Expect(err).To(HaveOccurred())
+		_, err = ParseGPUHourCosts([]string{"A100=-1"})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("limits the points of the range queries", func() {
+		Expect(GetUsageStep(time.Hour)).To(Equal(time.Minute))
+		Expect(GetUsageStep(30 * 24 * time.Hour)).To(Equal(5 * time.Minute))
+	})
+})
@@ -0,0 +1,144 @@
+package localcode
+
+import (
+	"bufio"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+const gitIgnoreFile = ".gitignore"
+
+// ignoreRule is a pattern of a .gitignore file
+type ignoreRule struct {
+	// base is the directory of the .gitignore file, relative to the packed directory
+	base    string
+	regexp  *regexp.Regexp
+	negate  bool
+	dirOnly bool
+	// anchored rules match the path relative to the base, and the others match the name at any level
+	anchored bool
+}
+
+// ignoreRules are the patterns of the .gitignore files of a directory tree, in the order git applies them
+type ignoreRules []ignoreRule
+
+// parseIgnoreRules parses the patterns of a .gitignore file in the base directory
+func parseIgnoreRules(base, content string) ignoreRules {
+	rules := ignoreRules{}
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), " \t\r")
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		rule := ignoreRule{base: base}
+		if strings.HasPrefix(line, "!") {
+			rule.negate = true
+			line = line[1:]
+		} else if strings.HasPrefix(line, `\`) {
+			line = line[1:]
+		}
+		if strings.HasSuffix(line, "/") {
+			rule.dirOnly = true
+			line = strings.TrimRight(line, "/")
+		}
+		if strings.Contains(line, "/") {
+			rule.anchored = true
+			line = strings.TrimPrefix(line, "/")
+		}
+		if line == "" {
+			continue
+		}
+		compiled, err := regexp.Compile("^" + globToRegexp(line) + "$")
+		if err != nil {
+			// git ignores invalid patterns as well
+			continue
+		}
+		rule.regexp = compiled
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+// loadIgnoreRules loads the patterns of the .gitignore file of a directory, if it has one
+func loadIgnoreRules(root, relativeDir string) (ignoreRules, error) {
+	content, err := ioutil.ReadFile(filepath.Join(root, filepath.FromSlash(relativeDir), gitIgnoreFile))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return parseIgnoreRules(relativeDir, string(content)), nil
+}
+
+// isIgnored returns whether the path, relative to the packed directory, is ignored. The last matching pattern
+// decides, so a negated pattern can include a path which a former pattern ignores.
+func (rules ignoreRules) isIgnored(relativePath string, isDir bool) bool {
+	ignored := false
+	for _, rule := range rules {
+		if rule.matches(relativePath, isDir) {
+			ignored = !rule.negate
+		}
+	}
+	return ignored
+}
+
+func (rule ignoreRule) matches(relativePath string, isDir bool) bool {
+	if rule.dirOnly && !isDir {
+		return false
+	}
+	if rule.base != "" {
+		if !strings.HasPrefix(relativePath, rule.base+"/") {
+			return false
+		}
+		relativePath = strings.TrimPrefix(relativePath, rule.base+"/")
+	}
+	if rule.anchored {
+		return rule.regexp.MatchString(relativePath)
+	}
+	return rule.regexp.MatchString(path.Base(relativePath))
+}
+
+// globToRegexp converts a .gitignore glob to a regular expression, where ** matches any number of directories
+func globToRegexp(glob string) string {
+	var builder strings.Builder
+	for i := 0; i < len(glob); i++ {
+		c := glob[i]
+		switch {
+		case strings.HasPrefix(glob[i:], "**/"):
+			builder.WriteString("(.*/)?")
+			i += 2
+		case strings.HasPrefix(glob[i:], "**"):
+			builder.WriteString(".*")
+			i++
+		case c == '*':
+			builder.WriteString("[^/]*")
+		case c == '?':
+			builder.WriteString("[^/]")
+		case c == '[':
+			end := strings.IndexByte(glob[i+1:], ']')
+			if end == -1 {
+				builder.WriteString(regexp.QuoteMeta(string(c)))
+				continue
+			}
+			class := glob[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			builder.WriteString("[" + class + "]")
+			i += end + 1
+		case c == '\\' && i+1 < len(glob):
+			i++
+			builder.WriteString(regexp.QuoteMeta(string(glob[i])))
+		default:
+			builder.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	return builder.String()
+}
@@ -0,0 +1,126 @@
+package localcode
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+)
+
+// Archive is a gzipped tarball of a local directory
+type Archive struct {
+	Content []byte
+	// Checksum is the sha256 of the content, which is the same for the same files
+	Checksum string
+	Files    int
+}
+
+// Pack packs the files of a directory into a gzipped tarball, skipping the .git directory and the files which are
+// ignored by the .gitignore files of the directory. The tarball does not include modification times or owners, so
+// packing unchanged files results in the same checksum.
+func Pack(dir string) (*Archive, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory", dir)
+	}
+
+	var buffer bytes.Buffer
+	gzipWriter := gzip.NewWriter(&buffer)
+	tarWriter := tar.NewWriter(gzipWriter)
+	archive := &Archive{}
+	if err = packDirectory(tarWriter, dir, "", ignoreRules{}, archive); err != nil {
+		return nil, err
+	}
+	if err = tarWriter.Close(); err != nil {
+		return nil, err
+	}
+	if err = gzipWriter.Close(); err != nil {
+		return nil, err
+	}
+
+	archive.Content = buffer.Bytes()
+	archive.Checksum = fmt.Sprintf("%x", sha256.Sum256(archive.Content))
+	return archive, nil
+}
+
+// packDirectory adds the files of a directory, relative to the packed directory, to the tarball
+func packDirectory(tarWriter *tar.Writer, root, relativeDir string, rules ignoreRules, archive *Archive) error {
+	dirRules, err := loadIgnoreRules(root, relativeDir)
+	if err != nil {
+		return err
+	}
+	rules = append(append(ignoreRules{}, rules...), dirRules...)
+
+	file, err := os.Open(filepath.Join(root, filepath.FromSlash(relativeDir)))
+	if err != nil {
+		return err
+	}
+	entries, err := file.Readdir(-1)
+	file.Close()
+	if err != nil {
+		return err
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Name() < entries[j].Name()
+	})
+
+	for _, entry := range entries {
+		relativePath := path.Join(relativeDir, entry.Name())
+		if entry.Name() == ".git" || rules.isIgnored(relativePath, entry.IsDir()) {
+			continue
+		}
+
+		switch {
+		case entry.IsDir():
+			if err = writeHeader(tarWriter, &tar.Header{Typeflag: tar.TypeDir, Name: relativePath + "/", Mode: int64(entry.Mode().Perm())}); err != nil {
+				return err
+			}
+			if err = packDirectory(tarWriter, root, relativePath, rules, archive); err != nil {
+				return err
+			}
+		case entry.Mode()&os.ModeSymlink != 0:
+			target, err := os.Readlink(filepath.Join(root, filepath.FromSlash(relativePath)))
+			if err != nil {
+				return err
+			}
+			if err = writeHeader(tarWriter, &tar.Header{Typeflag: tar.TypeSymlink, Name: relativePath, Linkname: target, Mode: int64(entry.Mode().Perm())}); err != nil {
+				return err
+			}
+		case entry.Mode().IsRegular():
+			if err = packFile(tarWriter, root, relativePath, entry); err != nil {
+				return err
+			}
+			archive.Files++
+		}
+	}
+	return nil
+}
+
+func packFile(tarWriter *tar.Writer, root, relativePath string, info os.FileInfo) error {
+	file, err := os.Open(filepath.Join(root, filepath.FromSlash(relativePath)))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	header := &tar.Header{Typeflag: tar.TypeReg, Name: relativePath, Mode: int64(info.Mode().Perm()), Size: info.Size()}
+	if err = writeHeader(tarWriter, header); err != nil {
+		return err
+	}
+	_, err = io.CopyN(tarWriter, file, info.Size())
+	return err
+}
+
+func writeHeader(tarWriter *tar.Writer, header *tar.Header) error {
+	header.Format = tar.FormatPAX
+	return tarWriter.WriteHeader(header)
+}
@@ -0,0 +1,123 @@
+package localcode
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	for name, content := range files {
+		filePath := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(filePath, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func listArchive(t *testing.T, content []byte) []string {
+	gzipReader, err := gzip.NewReader(bytes.NewReader(content))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tarReader := tar.NewReader(gzipReader)
+	names := []string{}
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			return names
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		names = append(names, header.Name)
+	}
+}
+
+func TestPack(t *testing.T) {
+	dir, err := ioutil.TempDir("", "localcode")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	writeFiles(t, dir, map[string]string{
+		".gitignore":             "*.pyc\n/data/\ncheckpoints/\n!keep.pyc\n# a comment\n",
+		".git/HEAD":              "ref: refs/heads/master",
+		"train.py":               "print('train')",
+		"train.pyc":              "",
+		"keep.pyc":               "",
+		"data/mnist.npz":         "",
+		"models/.gitignore":      "*.h5\n",
+		"models/model.py":        "",
+		"models/weights.h5":      "",
+		"models/data/labels.txt": "",
+		"models/checkpoints/1":   "",
+	})
+
+	archive, err := Pack(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{".gitignore", "keep.pyc", "models/", "models/.gitignore", "models/data/", "models/data/labels.txt", "models/model.py", "train.py"}
+	if names := listArchive(t, archive.Content); !reflect.DeepEqual(names, expected) {
+		t.Errorf("expected the files %v, got %v", expected, names)
+	}
+	if archive.Files != 6 {
+		t.Errorf("expected 6 files, got %d", archive.Files)
+	}
+
+	// packing unchanged files again results in the same checksum, and a change results in another
+	unchanged, err := Pack(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if unchanged.Checksum != archive.Checksum {
+		t.Errorf("expected the checksum of unchanged files to be %s, got %s", archive.Checksum, unchanged.Checksum)
+	}
+	writeFiles(t, dir, map[string]string{"train.py": "print('train twice')"})
+	changed, err := Pack(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if changed.Checksum == archive.Checksum {
+		t.Errorf("expected the checksum of changed files to change")
+	}
+}
+
+func TestIgnoreRules(t *testing.T) {
+	rules := parseIgnoreRules("", "logs/**/*.log\n**/cache\n/build\nsrc/*.tmp\n[Bb]in/\n")
+	tests := []struct {
+		path    string
+		isDir   bool
+		ignored bool
+	}{
+		{path: "logs/a.log", ignored: true},
+		{path: "logs/2021/01/a.log", ignored: true},
+		{path: "other/a.log"},
+		{path: "cache", isDir: true, ignored: true},
+		{path: "deep/in/cache", isDir: true, ignored: true},
+		{path: "build", ignored: true},
+		{path: "src/build"},
+		{path: "src/a.tmp", ignored: true},
+		{path: "src/nested/a.tmp"},
+		{path: "Bin", isDir: true, ignored: true},
+		{path: "bin"},
+	}
+
+	for _, test := range tests {
+		if ignored := rules.isIgnored(test.path, test.isDir); ignored != test.ignored {
+			t.Errorf("expected %s to be ignored: %v, got %v", test.path, test.ignored, ignored)
+		}
+	}
+}
@@ -22,6 +22,8 @@ var (
 type PodLogArgs struct {
 	Namespace    string
 	PodName      string
+	Container    string
+	Previous     bool
 	Follow       bool
 	SinceSeconds *int64
 	SinceTime    *metav1.Time
@@ -47,33 +49,63 @@ func NewPodLog(args *OuterRequestArgs) (*PodLog, error) {
 }
 
 func (pl *PodLog) GetPodLogEntry(accept func(io.ReadCloser)) error {
-	err := pl.ensureContainerStarted()
+	readCloser, err := pl.stream()
+	if err != nil {
+		return err
+	}
+	// warning: readCloser should execute readCloser.Close() in accept function.
+	go accept(readCloser)
+	return nil
+}
+
+// WriteLogs writes the logs of the pod to w, and returns when the logs end
+func (pl *PodLog) WriteLogs(w io.Writer) error {
+	readCloser, err := pl.stream()
 	if err != nil {
 		return err
 	}
-	readCloser, err := pl.Args.KubeClient.CoreV1().Pods(pl.Args.Namespace).GetLogs(pl.Args.PodName, &v1.PodLogOptions{
-		// Container:    p.container,
+	defer readCloser.Close()
+	_, err = io.Copy(w, readCloser)
+	return err
+}
+
+func (pl *PodLog) stream() (io.ReadCloser, error) {
+	err := pl.ensureContainerStarted()
+	if err != nil {
+		return nil, err
+	}
+	return pl.Args.KubeClient.CoreV1().Pods(pl.Args.Namespace).GetLogs(pl.Args.PodName, &v1.PodLogOptions{
+		Container:    pl.Args.Container,
+		Previous:     pl.Args.Previous,
 		Follow:       pl.Args.Follow,
 		Timestamps:   pl.Args.Timestamps,
 		SinceSeconds: pl.Args.SinceSeconds,
 		SinceTime:    pl.Args.SinceTime,
 		TailLines:    pl.Args.Tail,
 	}).Stream(context.TODO())
-
-	if err != nil {
-		return err
-	}
-	// warning: readCloser should execute readCloser.Close() in accept function.
-	go accept(readCloser)
-	return nil
 }
 
 func (pl *PodLog) ensureContainerStarted() error {
+	// the logs of the previous instance of a container exist regardless of the current instance
+	if pl.Args.Previous {
+		return nil
+	}
 	for pl.Args.RetryCnt > 0 {
 		pod, err := pl.Args.KubeClient.CoreV1().Pods(pl.Args.Namespace).Get(context.TODO(), pl.Args.PodName, metav1.GetOptions{})
 		if err != nil {
 			return err
 		}
+		if pl.Args.Container != "" {
+			// init containers and sidecars have logs before the pod is running, and after they terminate
+			if started, found := isContainerStarted(pod, pl.Args.Container); found {
+				if started {
+					return nil
+				}
+				pl.Args.RetryCnt--
+				continue
+			}
+			return fmt.Errorf("container %s is not found in instance %s", pl.Args.Container, pl.Args.PodName)
+		}
 		status, _, _, _ := servejob.DefinePodPhaseStatus(*pod)
 		log.Debugf("pod:%s,pod phase: %v\n", pl.Args.PodName, pod.Status.Phase)
 		log.Debugf("pod print status: %s\n", status)
@@ -87,9 +119,28 @@ func (pl *PodLog) ensureContainerStarted() error {
 	}
 	return fmt.Errorf("instance %s %s", pl.Args.PodName, ErrPodNotRunning.Error())
 }
+
+// isContainerStarted returns whether a container of the pod is running or has terminated, and whether the pod has
+// the container
+func isContainerStarted(pod *v1.Pod, container string) (bool, bool) {
+	for _, status := range append(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses...) {
+		if status.Name == container {
+			return status.State.Running != nil || status.State.Terminated != nil || status.LastTerminationState.Terminated != nil, true
+		}
+	}
+	for _, specContainer := range append(pod.Spec.InitContainers, pod.Spec.Containers...) {
+		if specContainer.Name == container {
+			return false, true
+		}
+	}
+	return false, false
+}
+
 func checkAndTransferArgs(out *OuterRequestArgs) (*PodLogArgs, error) {
 	podLogArgs := &PodLogArgs{
 		PodName:    out.PodName,
+		Container:  out.Container,
+		Previous:   out.Previous,
 		Namespace:  out.Namespace,
 		KubeClient: out.KubeClient,
 		Follow:     out.Follow,
@@ -0,0 +1,61 @@
+package podlogs
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestCheckAndTransferArgs(t *testing.T) {
+	podLogArgs, err := checkAndTransferArgs(&OuterRequestArgs{PodName: "train1-0", Container: "git-sync", Previous: true, Tail: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if podLogArgs.Container != "git-sync" || !podLogArgs.Previous || *podLogArgs.Tail != 10 {
+		t.Errorf("expected the container, previous and tail to be transferred, got %+v", podLogArgs)
+	}
+}
+
+func TestEnsureContainerStarted(t *testing.T) {
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "train1-0", Namespace: "runai-team-a"},
+		Spec: v1.PodSpec{
+			InitContainers: []v1.Container{{Name: "git-sync"}},
+			Containers:     []v1.Container{{Name: "train1"}},
+		},
+		Status: v1.PodStatus{
+			Phase:                 v1.PodPending,
+			InitContainerStatuses: []v1.ContainerStatus{{Name: "git-sync", State: v1.ContainerState{Running: &v1.ContainerStateRunning{}}}},
+			ContainerStatuses:     []v1.ContainerStatus{{Name: "train1", State: v1.ContainerState{Waiting: &v1.ContainerStateWaiting{}}}},
+		},
+	}
+	clientset := fake.NewSimpleClientset(pod)
+
+	tests := []struct {
+		container   string
+		previous    bool
+		expectError bool
+	}{
+		{container: "git-sync"},
+		{container: "train1", expectError: true},
+		{container: "train1", previous: true},
+		{container: "sidecar", expectError: true},
+		{expectError: true},
+	}
+
+	for _, test := range tests {
+		podLog := &PodLog{Args: &PodLogArgs{
+			Namespace:  "runai-team-a",
+			PodName:    "train1-0",
+			Container:  test.container,
+			Previous:   test.previous,
+			RetryCnt:   2,
+			KubeClient: clientset,
+		}}
+		if err := podLog.ensureContainerStarted(); (err != nil) != test.expectError {
+			t.Errorf("expected an error for container %s with previous %v: %v, got %v", test.container, test.previous, test.expectError, err)
+		}
+	}
+}
@@ -8,16 +8,19 @@ import (
 )
 
 type OuterRequestArgs struct {
-	PodName      string
-	Namespace    string
-	Follow       bool
-	Tail         int
-	RetryCount   int
-	RetryTimeout time.Duration
-	SinceSeconds time.Duration
-	SinceTime    string
-	Timestamps   bool
-	KubeClient   kubernetes.Interface
+	PodName       string
+	Container     string
+	AllContainers bool
+	Previous      bool
+	Namespace     string
+	Follow        bool
+	Tail          int
+	RetryCount    int
+	RetryTimeout  time.Duration
+	SinceSeconds  time.Duration
+	SinceTime     string
+	Timestamps    bool
+	KubeClient    kubernetes.Interface
 }
 
 func ParseSinceTime(sinceTime string) (*metav1.Time, error) {
@@ -0,0 +1,248 @@
+package logs
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/run-ai/runai-cli/pkg/podlogs"
+	log "github.com/sirupsen/logrus"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// DefaultPodsPollInterval is the interval of looking for new pods of the job when following the logs
+	DefaultPodsPollInterval = 5 * time.Second
+	colorReset              = "\033[0m"
+)
+
+// sourceColors are the ansi colors of the line prefixes: red, green, yellow, blue, magenta and cyan
+var sourceColors = []string{"\033[31m", "\033[32m", "\033[33m", "\033[34m", "\033[35m", "\033[36m"}
+
+// MultiPodLogPrinter prints the logs of several pods concurrently, prefixing every line with the name of its pod, and
+// the name of its container when printing the logs of all the containers. With timestamps, the lines are merged by
+// their timestamps, unless the logs are followed, in which case they are printed as they arrive. When following the
+// logs, pods which are listed later are streamed as well.
+type MultiPodLogPrinter struct {
+	LogArgs *podlogs.OuterRequestArgs
+	// ListPods returns the names of the pods to print the logs of
+	ListPods     func() ([]string, error)
+	Out          io.Writer
+	Color        bool
+	PollInterval time.Duration
+
+	colors map[string]string
+}
+
+// logSource is a container of a pod, or the default container of a pod when the container is empty
+type logSource struct {
+	pod       string
+	container string
+}
+
+func (source logSource) String() string {
+	if source.container == "" {
+		return source.pod
+	}
+	return source.pod + "/" + source.container
+}
+
+type logLine struct {
+	source string
+	text   string
+	// time is the timestamp of the line, or of the former line of the source when the line has none
+	time time.Time
+}
+
+func NewMultiPodLogPrinter(logArgs *podlogs.OuterRequestArgs, listPods func() ([]string, error), out io.Writer, color bool) *MultiPodLogPrinter {
+	return &MultiPodLogPrinter{
+		LogArgs:      logArgs,
+		ListPods:     listPods,
+		Out:          out,
+		Color:        color,
+		PollInterval: DefaultPodsPollInterval,
+		colors:       map[string]string{},
+	}
+}
+
+func (printer *MultiPodLogPrinter) Print() (int, error) {
+	names, err := printer.ListPods()
+	if err != nil {
+		return 1, err
+	}
+	if len(names) == 0 {
+		return 1, ErrPodNotFound
+	}
+
+	lines := make(chan logLine)
+	ended := make(chan string)
+	streamed := map[logSource]bool{}
+	active := 0
+	var lastErr error
+	startStreams := func(names []string) {
+		for _, source := range printer.getSources(names) {
+			if streamed[source] {
+				continue
+			}
+			if err := printer.streamSource(source, lines, ended); err != nil {
+				// containers which are not running yet are streamed in a later poll when following the logs
+				log.Debugf("Failed to get the logs of %s: %v", source, err)
+				lastErr = err
+				continue
+			}
+			streamed[source] = true
+			active++
+		}
+	}
+	startStreams(names)
+	if active == 0 && !printer.LogArgs.Follow {
+		return 1, lastErr
+	}
+
+	var poll <-chan time.Time
+	if printer.LogArgs.Follow {
+		ticker := time.NewTicker(printer.PollInterval)
+		defer ticker.Stop()
+		poll = ticker.C
+	}
+	listAndStream := func() {
+		if names, err := printer.ListPods(); err != nil {
+			log.Debugf("Failed to list the pods of the job: %v", err)
+		} else {
+			startStreams(names)
+		}
+	}
+
+	mergeByTime := printer.LogArgs.Timestamps && !printer.LogArgs.Follow
+	collected := []logLine{}
+	for active > 0 || (printer.LogArgs.Follow && len(streamed) == 0) {
+		select {
+		case line := <-lines:
+			if mergeByTime {
+				collected = append(collected, line)
+			} else {
+				printer.printLine(line)
+			}
+		case name := <-ended:
+			log.Debugf("The logs of %s have ended", name)
+			active--
+			if active == 0 && printer.LogArgs.Follow {
+				// the job may have created new pods since the last poll
+				listAndStream()
+			}
+		case <-poll:
+			listAndStream()
+		}
+	}
+
+	if mergeByTime {
+		sortLinesByTime(collected)
+		for _, line := range collected {
+			printer.printLine(line)
+		}
+	}
+	return 0, nil
+}
+
+// getSources returns the containers of the pods to print the logs of
+func (printer *MultiPodLogPrinter) getSources(names []string) []logSource {
+	sources := []logSource{}
+	for _, name := range names {
+		if !printer.LogArgs.AllContainers {
+			sources = append(sources, logSource{pod: name, container: printer.LogArgs.Container})
+			continue
+		}
+		pod, err := printer.LogArgs.KubeClient.CoreV1().Pods(printer.LogArgs.Namespace).Get(context.TODO(), name, metav1.GetOptions{})
+		if err != nil {
+			log.Debugf("Failed to get pod %s: %v", name, err)
+			continue
+		}
+		for _, container := range getContainerNames(pod) {
+			sources = append(sources, logSource{pod: name, container: container})
+		}
+	}
+	return sources
+}
+
+// getContainerNames returns the names of the init containers and the containers of the pod
+func getContainerNames(pod *v1.Pod) []string {
+	names := []string{}
+	for _, container := range pod.Spec.InitContainers {
+		names = append(names, container.Name)
+	}
+	for _, container := range pod.Spec.Containers {
+		names = append(names, container.Name)
+	}
+	return names
+}
+
+// streamSource streams the logs of a container to lines, and notifies ended when the logs end
+func (printer *MultiPodLogPrinter) streamSource(source logSource, lines chan<- logLine, ended chan<- string) error {
+	logArgs := *printer.LogArgs
+	logArgs.PodName = source.pod
+	logArgs.Container = source.container
+	podLog, err := podlogs.NewPodLog(&logArgs)
+	if err != nil {
+		return err
+	}
+	return podLog.GetPodLogEntry(func(reader io.ReadCloser) {
+		defer func() {
+			ended <- source.String()
+		}()
+		defer reader.Close()
+		readLines(reader, source.String(), lines)
+	})
+}
+
+func readLines(reader io.Reader, source string, lines chan<- logLine) {
+	bufferedReader := bufio.NewReader(reader)
+	lastTime := time.Time{}
+	for {
+		text, err := bufferedReader.ReadString('\n')
+		if text != "" {
+			text = strings.TrimSuffix(text, "\n")
+			if lineTime, ok := parseLineTime(text); ok {
+				lastTime = lineTime
+			}
+			lines <- logLine{source: source, text: text, time: lastTime}
+		}
+		if err != nil {
+			if err != io.EOF {
+				log.Debugf("Failed to read the logs of %s: %v", source, err)
+			}
+			return
+		}
+	}
+}
+
+// parseLineTime parses the timestamp which prefixes a line of logs which have been requested with timestamps
+func parseLineTime(text string) (time.Time, bool) {
+	timestamp := strings.SplitN(text, " ", 2)[0]
+	lineTime, err := time.Parse(time.RFC3339Nano, timestamp)
+	return lineTime, err == nil
+}
+
+// sortLinesByTime sorts the lines by their timestamps, keeping the order of the lines of each source
+func sortLinesByTime(lines []logLine) {
+	sort.SliceStable(lines, func(i, j int) bool {
+		return lines[i].time.Before(lines[j].time)
+	})
+}
+
+func (printer *MultiPodLogPrinter) printLine(line logLine) {
+	prefix := fmt.Sprintf("[%s]", line.source)
+	if printer.Color {
+		color, found := printer.colors[line.source]
+		if !found {
+			color = sourceColors[len(printer.colors)%len(sourceColors)]
+			printer.colors[line.source] = color
+		}
+		prefix = color + prefix + colorReset
+	}
+	fmt.Fprintf(printer.Out, "%s %s\n", prefix, line.text)
+}
@@ -0,0 +1,50 @@
+package logs
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestMergeLinesByTime(t *testing.T) {
+	lines := make(chan logLine)
+	go func() {
+		readLines(strings.NewReader("2021-06-01T10:00:00.5Z launcher started\n2021-06-01T10:00:03Z launcher done"), "launcher", lines)
+		readLines(strings.NewReader("2021-06-01T10:00:01Z worker started\ncontinued\n2021-06-01T10:00:02Z worker done\n"), "worker", lines)
+		close(lines)
+	}()
+	collected := []logLine{}
+	for line := range lines {
+		collected = append(collected, line)
+	}
+	sortLinesByTime(collected)
+
+	var out bytes.Buffer
+	printer := NewMultiPodLogPrinter(nil, nil, &out, false)
+	for _, line := range collected {
+		printer.printLine(line)
+	}
+
+	expected := `[launcher] 2021-06-01T10:00:00.5Z launcher started
+[worker] 2021-06-01T10:00:01Z worker started
+[worker] continued
+[worker] 2021-06-01T10:00:02Z worker done
+[launcher] 2021-06-01T10:00:03Z launcher done
+`
+	if out.String() != expected {
+		t.Errorf("expected the merged logs:\n%s\ngot:\n%s", expected, out.String())
+	}
+}
+
+func TestPrintLineColors(t *testing.T) {
+	var out bytes.Buffer
+	printer := NewMultiPodLogPrinter(nil, nil, &out, true)
+	printer.printLine(logLine{source: "worker-0", text: "a"})
+	printer.printLine(logLine{source: "worker-1", text: "b"})
+	printer.printLine(logLine{source: "worker-0", text: "c"})
+
+	expected := "\033[31m[worker-0]\033[0m a\n\033[32m[worker-1]\033[0m b\n\033[31m[worker-0]\033[0m c\n"
+	if out.String() != expected {
+		t.Errorf("expected %q, got %q", expected, out.String())
+	}
+}
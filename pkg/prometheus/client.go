@@ -55,6 +55,23 @@ type (
 		Value  []MetricValue     `json:"value"`
 	}
 
+	// RangeMetricData is the result of a range query, a matrix of the values of the series over time
+	RangeMetricData struct {
+		Result     []RangeMetricResult `json:"result"`
+		ResultType string              `json:"resultType"`
+	}
+
+	// RangeMetricResult is a series of a range query, every value is a pair of a unix time and a value
+	RangeMetricResult struct {
+		Metric map[string]string `json:"metric"`
+		Values [][]MetricValue   `json:"values"`
+	}
+
+	rangeMetric struct {
+		Status MetricStatusResult `json:"status,inline"`
+		Data   RangeMetricData    `json:"data,omitempty"`
+	}
+
 	queryResult struct {
 		name   string
 		metric *MetricData
@@ -81,6 +98,12 @@ type (
 		// GroupMultiQueriesToItems queries prometheus for multiple queries from `queryMap` and groups the results by the `labelId` values
 		GroupMultiQueriesToItems(queryMap QueryNameToQuery, labelID string) (MetricResultsByItems, error)
 	}
+
+	// RangeQueryClient is interface to query prometheus for the values of metrics over time
+	RangeQueryClient interface {
+		// QueryRange queries prometheus for the values of `query` between `start` and `end`, every `step`
+		QueryRange(query string, start, end time.Time, step time.Duration) (*RangeMetricData, error)
+	}
 )
 
 func BuildMetricsClient(c *client.Client) (*Client, error) {
@@ -165,36 +188,74 @@ func (ps *Client) queryPrometheus(query string) (*MetricData, error) {
 }
 
 func (ps *Client) queryThanos(query string) (*MetricData, error) {
-	http.DefaultTransport.(*http.Transport).TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
-	client := http.Client{}
+	log.Debugf("Query thanos for by %s", query)
+	rawMetrics, err := ps.getThanos("api/v1/query", map[string]string{
+		"query": query,
+		"time":  strconv.FormatInt(time.Now().Unix(), 10),
+	})
+	if err != nil {
+		log.Debugf("Query thanos failed due to err %v", err)
+		return nil, err
+	}
+	return handleQueryResponse(rawMetrics, query)
+}
+
+// QueryRange queries prometheus, or thanos on openshift, for the values of the query over time
+func (ps *Client) QueryRange(query string, start, end time.Time, step time.Duration) (*RangeMetricData, error) {
+	params := map[string]string{
+		"query": query,
+		"start": strconv.FormatInt(start.Unix(), 10),
+		"end":   strconv.FormatInt(end.Unix(), 10),
+		"step":  strconv.FormatInt(int64(step.Seconds()), 10),
+	}
 
-	requestUrl := fmt.Sprintf("%s%s", ps.thanosRouteService.url, "api/v1/query")
-	request, err := http.NewRequest("GET", requestUrl, nil)
+	var rawMetrics []byte
+	var err error
+	if ps.isOpenshift {
+		rawMetrics, err = ps.getThanos("api/v1/query_range", params)
+	} else {
+		log.Debugf("Query prometheus range for %s in ns %s", query, ps.prometheusService.Namespace)
+		rawMetrics, err = ps.client.CoreV1().Services(ps.prometheusService.Namespace).ProxyGet(prometheusSchema, ps.prometheusService.Name, "9090", "api/v1/query_range", params).DoRaw(context.TODO())
+	}
 	if err != nil {
+		log.Debugf("Query prometheus range failed due to err %v", err)
 		return nil, err
 	}
 
-	q := url.Values{}
-	q.Add("query", query)
-	q.Add("time", strconv.FormatInt(time.Now().Unix(), 10))
-	request.URL.RawQuery = q.Encode()
+	metricResponse := &rangeMetric{}
+	if err = json.Unmarshal(rawMetrics, metricResponse); err != nil {
+		return nil, fmt.Errorf("failed to unmarshall response: %v", err)
+	}
+	if metricResponse.Status != SuccessStatus {
+		return nil, fmt.Errorf("failed to query prometheus, status: %s", metricResponse.Status)
+	}
+	return &metricResponse.Data, nil
+}
 
-	request.Header.Set("Authorization", ps.thanosRouteService.authorizationToken)
+// getThanos gets the path of the thanos route with the params as its query. The certificate of the route is not
+// verified, on a transport of its own rather than on the default transport of the process.
+func (ps *Client) getThanos(path string, params map[string]string) ([]byte, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+	client := http.Client{Transport: transport}
 
-	response, err := client.Do(request)
+	request, err := http.NewRequest("GET", ps.thanosRouteService.url+path, nil)
 	if err != nil {
 		return nil, err
 	}
+	q := url.Values{}
+	for key, value := range params {
+		q.Add(key, value)
+	}
+	request.URL.RawQuery = q.Encode()
+	request.Header.Set("Authorization", ps.thanosRouteService.authorizationToken)
 
-	log.Debugf("Query thanos for by %s in ns %s", query, ps.prometheusService.Namespace)
-
-	rawMetrics, err := ioutil.ReadAll(response.Body)
+	response, err := client.Do(request)
 	if err != nil {
-		log.Debugf("Query thanos failed due to err %v", err)
-		log.Debugf("Query thanos failed due to result %s", string(rawMetrics))
 		return nil, err
 	}
-	return handleQueryResponse(rawMetrics, query)
+	defer response.Body.Close()
+	return ioutil.ReadAll(response.Body)
 }
 
 func handleQueryResponse(rawMetric []byte, query string) (*MetricData, error) {
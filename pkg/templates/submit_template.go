@@ -34,6 +34,14 @@ type SubmitTemplate struct {
 	Memory                     *TemplateField   `yaml:"memory,omitempty"`
 	MemoryLimit                *TemplateField   `yaml:"memory-limit,omitempty"`
 	NodeType                   *TemplateField   `yaml:"node-type,omitempty"`
+	Tolerations                []string         `yaml:"tolerations,omitempty"`
+	NodeSelectors              []string         `yaml:"node-selectors,omitempty"`
+	NodeAffinity               []string         `yaml:"node-affinity,omitempty"`
+	PreferredNodeAffinity      []string         `yaml:"preferred-node-affinity,omitempty"`
+	EnvFromSecrets             []string         `yaml:"env-from-secrets,omitempty"`
+	SecretEnvironmentVariables []string         `yaml:"secret-environment,omitempty"`
+	SecretMounts               []string         `yaml:"mount-secrets,omitempty"`
+	ConfigMapMounts            []string         `yaml:"mount-configmaps,omitempty"`
 	Ports                      []string         `yaml:"ports,omitempty"`
 	PersistentVolumes          []string         `yaml:"pvcs,omitempty"`
 	WorkingDir                 *TemplateField   `yaml:"working-dir,omitempty"`
@@ -61,6 +69,10 @@ type GitSyncTemplate struct {
 	Revision   *TemplateField `yaml:"rev,omitempty"`
 	Username   *TemplateField `yaml:"username,omitempty"`
 	Password   *TemplateField `yaml:"password,omitempty"`
+	Token      *TemplateField `yaml:"token,omitempty"`
+	SSHKey     *TemplateField `yaml:"ssh-key,omitempty"`
+	KnownHosts *TemplateField `yaml:"known-hosts,omitempty"`
+	Secret     *TemplateField `yaml:"secret,omitempty"`
 	Image      *TemplateField `yaml:"image,omitempty"`
 	Directory  *TemplateField `yaml:"target,omitempty"`
 }
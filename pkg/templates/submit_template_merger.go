@@ -94,6 +94,18 @@ func mergeGitSyncTemplate(base, patch *GitSyncTemplate) *GitSyncTemplate {
 	if patch.Password != nil {
 		base.Password = patch.Password
 	}
+	if patch.Token != nil {
+		base.Token = patch.Token
+	}
+	if patch.SSHKey != nil {
+		base.SSHKey = patch.SSHKey
+	}
+	if patch.KnownHosts != nil {
+		base.KnownHosts = patch.KnownHosts
+	}
+	if patch.Secret != nil {
+		base.Secret = patch.Secret
+	}
 	if patch.Image != nil {
 		base.Image = patch.Image
 	}
@@ -0,0 +1,8 @@
+package types
+
+// ClusterView is a row of the clusters list
+type ClusterView struct {
+	Name    string `title:"CLUSTER" format:"clustername" json:"name"`
+	Current bool   `title:"CURRENT" json:"current"`
+	Project string `title:"CURRENT PROJECT" json:"project"`
+}
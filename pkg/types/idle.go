@@ -0,0 +1,18 @@
+package types
+
+import (
+	"time"
+)
+
+// IdleJobView is a job whose allocated GPUs have been idle for longer than a threshold
+type IdleJobView struct {
+	Name          string        `title:"NAME" json:"name"`
+	Project       string        `title:"PROJECT" json:"project"`
+	User          string        `title:"USER" json:"user"`
+	Type          string        `title:"TYPE" json:"type"`
+	Node          string        `title:"NODE" json:"node"`
+	AllocatedGPUs float64       `title:"GPUs ALLOCATED" json:"allocatedGPUs"`
+	IdleFor       time.Duration `title:"IDLE FOR" format:"duration" json:"idleFor"`
+	// LastActive is the last time the GPUs were utilized, or nil if they were not utilized within the queried history
+	LastActive *time.Time `title:"LAST ACTIVE" format:"lastactive" def:"-" json:"lastActive,omitempty"`
+}
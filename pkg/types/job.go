@@ -6,44 +6,65 @@ import (
 
 // JobGeneralInfo general information
 type JobGeneralInfo struct {
-	Name     string        `title:"NAME"`
-	Project  string        `title:"PROJECT"`
-	User     string        `title:"USER"`
-	Type     string        `title:"TYPE"`
-	Status   string        `title:"STATUS"`
-	Duration time.Duration `title:"DURATION" format:"time"`
-	Node     string        `title:"NODE"`
+	Name     string        `title:"NAME" json:"name"`
+	Project  string        `title:"PROJECT" json:"project"`
+	User     string        `title:"USER" json:"user"`
+	Type     string        `title:"TYPE" json:"type"`
+	Status   string        `title:"STATUS" json:"status"`
+	Duration time.Duration `title:"DURATION" format:"time" json:"duration"`
+	Node     string        `title:"NODE" json:"node"`
 }
 
 // ResourceUsage resource usage
 type ResourceUsage struct {
-	Usage       float64 `title:"Usage"`
-	Utilization float64 `title:"Utiliz."`
+	Usage       float64 `title:"Usage" json:"usage"`
+	Utilization float64 `title:"Utiliz." json:"utilization"`
 }
 
 // MemoryMetrics resource metrics
 type MemoryMetrics struct {
-	Allocated float64        `title:"Allocated" format:"memory"`
-	Usage     *ResourceUsage `title:"Usage" format:"memoryusage"`
+	Allocated float64        `title:"Allocated" format:"memory" json:"allocated"`
+	Usage     *ResourceUsage `title:"Usage" format:"memoryusage" json:"usage,omitempty"`
 }
 
 // CPUMetrics resource metrics
 type CPUMetrics struct {
-	Allocated float64        `title:"Allocated" format:"cpu"`
-	Usage     *ResourceUsage `title:"Usage" format:"cpuusage"`
+	Allocated float64        `title:"Allocated" format:"cpu" json:"allocated"`
+	Usage     *ResourceUsage `title:"Usage" format:"cpuusage" json:"usage,omitempty"`
 }
 
 // GPUMetrics resource metrics
 type GPUMetrics struct {
-	Allocated   float64 `title:"Allocated"`
-	Utilization float64 `title:"Util" format:"%"`
+	Allocated   float64 `title:"Allocated" json:"allocated"`
+	Utilization float64 `title:"Util" format:"%" json:"utilization"`
 }
 
 // JobView is general status of a RunAI/MPI Job
 type JobView struct {
-	Info   *JobGeneralInfo `group:"GENERAL,flatten"`
-	GPUs   *GPUMetrics     `group:"GPU" def:"<none>"`
-	GPUMem *MemoryMetrics  `group:"GPU MEMORY" def:"<none>"`
-	CPUs   *CPUMetrics     `group:"CPU"`
-	Mem    *MemoryMetrics  `group:"CPU MEMORY"`
+	Info   *JobGeneralInfo `group:"GENERAL,flatten" json:"info"`
+	GPUs   *GPUMetrics     `group:"GPU" def:"<none>" json:"gpus,omitempty"`
+	GPUMem *MemoryMetrics  `group:"GPU MEMORY" def:"<none>" json:"gpuMemory,omitempty"`
+	CPUs   *CPUMetrics     `group:"CPU" json:"cpus,omitempty"`
+	Mem    *MemoryMetrics  `group:"CPU MEMORY" json:"memory,omitempty"`
+}
+
+// JobListView is a row of the jobs list
+type JobListView struct {
+	Name          string        `title:"NAME" json:"name"`
+	Status        string        `title:"STATUS" json:"status"`
+	Age           time.Duration `title:"AGE" format:"age" json:"age"`
+	Duration      time.Duration `title:"DURATION" format:"age" json:"duration"`
+	Node          string        `title:"NODE" json:"node"`
+	Image         string        `title:"IMAGE" json:"image"`
+	Type          string        `title:"TYPE" json:"type"`
+	Project       string        `title:"PROJECT" json:"project"`
+	User          string        `title:"USER" json:"user"`
+	PriorityClass string        `title:"PRIORITY CLASS" json:"priorityClass"`
+	AllocatedGPUs float64       `title:"GPUs Allocated (Requested)" format:"allocatedgpus" json:"allocatedGPUs"`
+	RequestedGPUs string        `title:"REQUESTED GPUs" json:"requestedGPUs"`
+	RunningPods   int           `title:"PODs Running (Pending)" format:"pods" json:"runningPods"`
+	PendingPods   int           `title:"PENDING PODs" json:"pendingPods"`
+	ServiceURLs   []string      `title:"SERVICE URL(S)" format:"list" json:"serviceURLs"`
+	// Invalid jobs have a config map but no kubernetes objects
+	Invalid bool `title:"INVALID" json:"invalid,omitempty"`
 }
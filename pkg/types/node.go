@@ -2,50 +2,48 @@ package types
 
 type NodeStatus string
 
-
-
 type NodeCPUResource struct {
-	Capacity    int     `title:"CAPACITY" def:"0"`
-	Allocatable float64 `title:"ALLOCATABLE"`
-	Allocated   float64 `title:"ALLOCATED"`
-	Utilization float64 `title:"UTILIZATION" format:"%"`
-	Usage       float64 `title:"USAGE"`
+	Capacity    int     `title:"CAPACITY" def:"0" json:"capacity"`
+	Allocatable float64 `title:"ALLOCATABLE" json:"allocatable"`
+	Allocated   float64 `title:"ALLOCATED" json:"allocated"`
+	Utilization float64 `title:"UTILIZATION" format:"%" json:"utilization"`
+	Usage       float64 `title:"USAGE" json:"usage"`
 }
 
 type NodeGPUResource struct {
-	GpuType     string  `title:"TYPE" def:"-"`
-	Capacity    int     `title:"CAPACITY" def:"0"`
-	Allocatable float64 `title:"ALLOCATABLE" def:"0"`
-	Allocated   float64 `title:"ALLOCATED"`
-	InUse       int     `title:"IN USE"`
-	Free        int     `title:"FREE"`
-	Utilization float64 `title:"UTILIZATION" format:"%"`
-	Usage       float64 `title:"USAGE"`
-	Unhealthy   int     `title:"UNHEALTHY"`
+	GpuType     string  `title:"TYPE" def:"-" json:"type"`
+	Capacity    int     `title:"CAPACITY" def:"0" json:"capacity"`
+	Allocatable float64 `title:"ALLOCATABLE" def:"0" json:"allocatable"`
+	Allocated   float64 `title:"ALLOCATED" json:"allocated"`
+	InUse       int     `title:"IN USE" json:"inUse"`
+	Free        int     `title:"FREE" json:"free"`
+	Utilization float64 `title:"UTILIZATION" format:"%" json:"utilization"`
+	Usage       float64 `title:"USAGE" json:"usage"`
+	Unhealthy   int     `title:"UNHEALTHY" json:"unhealthy"`
 }
 
 type NodeMemoryResource struct {
-	Capacity            float64 `title:"CAPACITY" format:"memory" def:"0"`
-	Allocatable         float64 `title:"ALLOCATABLE" format:"memory"`
-	Allocated           float64 `title:"ALLOCATED" format:"memory"`
-	Utilization         float64 `title:"UTILIZATION" format:"%"`
-	Usage               float64 `title:"USAGE" format:"memory"`
-	UsageAndUtilization string  `title:"USAGE"`
+	Capacity            float64 `title:"CAPACITY" format:"memory" def:"0" json:"capacity"`
+	Allocatable         float64 `title:"ALLOCATABLE" format:"memory" json:"allocatable"`
+	Allocated           float64 `title:"ALLOCATED" format:"memory" json:"allocated"`
+	Utilization         float64 `title:"UTILIZATION" format:"%" json:"utilization"`
+	Usage               float64 `title:"USAGE" format:"memory" json:"usage"`
+	UsageAndUtilization string  `title:"USAGE" json:"-"`
 }
 
 type NodeGeneralInfo struct {
-	Name      string     `title:"NAME"`
-	Status    string 	 `title:"STATUS"`
-	IPAddress string     `title:"IP Address"`
-	Role      string     `title:"ROLE" def:"<none>"`
+	Name      string `title:"NAME" json:"name"`
+	Status    string `title:"STATUS" json:"status"`
+	IPAddress string `title:"IP Address" json:"ipAddress"`
+	Role      string `title:"ROLE" def:"<none>" json:"role"`
 }
 
 type NodeView struct {
-	Info   NodeGeneralInfo     `group:"GENERAL,flatten"`
-	CPUs   *NodeCPUResource    `group:"CPU"`
-	Mem    *NodeMemoryResource `group:"MEMORY"`
-	GPUs   *NodeGPUResource    `group:"GPU" def:"<none>"`
-	GPUMem *NodeMemoryResource `group:"GPU MEMORY" def:"<none>"`
+	Info   NodeGeneralInfo     `group:"GENERAL,flatten" json:"info"`
+	CPUs   *NodeCPUResource    `group:"CPU" json:"cpus,omitempty"`
+	Mem    *NodeMemoryResource `group:"MEMORY" json:"memory,omitempty"`
+	GPUs   *NodeGPUResource    `group:"GPU" def:"<none>" json:"gpus,omitempty"`
+	GPUMem *NodeMemoryResource `group:"GPU MEMORY" def:"<none>" json:"gpuMemory,omitempty"`
 }
 
 type ClusterNodesView struct {
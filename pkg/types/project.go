@@ -0,0 +1,14 @@
+package types
+
+import "time"
+
+// ProjectView is a row of the projects list
+type ProjectView struct {
+	Name                    string        `title:"PROJECT" format:"projectname" json:"name"`
+	Default                 bool          `title:"DEFAULT" json:"default"`
+	Department              string        `title:"DEPARTMENT" def:"-" json:"department"`
+	DeservedGPUs            float64       `title:"DESERVED GPUs" format:"deservedgpus" def:"-" json:"deservedGPUs"`
+	InteractiveTimeLimit    time.Duration `title:"INT LIMIT" format:"timelimit" def:"-" json:"interactiveJobTimeLimit"`
+	InteractiveNodeAffinity []string      `title:"INT AFFINITY" format:"affinity" json:"interactiveNodeAffinity"`
+	TrainNodeAffinity       []string      `title:"TRAIN AFFINITY" format:"affinity" json:"trainNodeAffinity"`
+}
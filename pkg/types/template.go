@@ -0,0 +1,8 @@
+package types
+
+// TemplateView is a row of the templates list
+type TemplateView struct {
+	Name        string `title:"NAME" format:"templatename" json:"name"`
+	Admin       bool   `title:"ADMIN" json:"admin"`
+	Description string `title:"DESCRIPTION" json:"description"`
+}
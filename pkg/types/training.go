@@ -49,4 +49,25 @@ type Instance struct {
 	Node string `json:"node"`
 	// the instance is chief or not
 	IsChief bool `json:"chief" yaml:"chief"`
+	// the role of the instance in a distributed job, e.g. the launcher or a worker of an mpi job
+	Role string `json:"role,omitempty" yaml:"role,omitempty"`
+	// the ready containers of the instance out of all its containers, e.g. 1/2
+	Ready string `json:"ready"`
+	// the restarts of the containers of the instance
+	Restarts int32 `json:"restarts"`
+	// the reason and the exit code of the last termination of a container of the instance
+	LastTerminationReason   string `json:"lastTerminationReason,omitempty" yaml:"lastTerminationReason,omitempty"`
+	LastTerminationExitCode *int32 `json:"lastTerminationExitCode,omitempty" yaml:"lastTerminationExitCode,omitempty"`
+	// the resources which the containers of the instance request and are limited to
+	Requests InstanceResources `json:"requests"`
+	Limits   InstanceResources `json:"limits"`
+	// the fraction of a gpu and the index of the gpu which are allocated to the instance
+	GPUFraction string `json:"gpuFraction,omitempty" yaml:"gpuFraction,omitempty"`
+	GPUIndex    string `json:"gpuIndex,omitempty" yaml:"gpuIndex,omitempty"`
+}
+
+type InstanceResources struct {
+	CPU    string `json:"cpu,omitempty" yaml:"cpu,omitempty"`
+	Memory string `json:"memory,omitempty" yaml:"memory,omitempty"`
+	GPU    string `json:"gpu,omitempty" yaml:"gpu,omitempty"`
 }
@@ -0,0 +1,15 @@
+package types
+
+// UsageView is a row of the usage report, the usage of the GPUs by a user, a project, a job or a node type
+type UsageView struct {
+	User           string  `title:"USER" def:"-" json:"user,omitempty"`
+	Project        string  `title:"PROJECT" def:"-" json:"project,omitempty"`
+	Job            string  `title:"JOB" def:"-" json:"job,omitempty"`
+	NodeType       string  `title:"NODE TYPE" def:"-" json:"nodeType,omitempty"`
+	GPUHours       float64 `title:"GPU HOURS" format:"hours" json:"gpuHours"`
+	AvgUtilization float64 `title:"AVG UTILIZATION" format:"%" json:"averageUtilization"`
+	IdleGPUHours   float64 `title:"IDLE GPU HOURS" format:"hours" json:"idleGpuHours"`
+	Cost           float64 `title:"COST" format:"cost" json:"cost,omitempty"`
+	// DeletedJobs are the jobs of the row which no longer exist, whose user, node type and gpu type may be unknown
+	DeletedJobs int `title:"DELETED JOBS" json:"deletedJobs,omitempty"`
+}
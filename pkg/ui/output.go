@@ -0,0 +1,234 @@
+package ui
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"gopkg.in/yaml.v2"
+	"k8s.io/client-go/util/jsonpath"
+)
+
+const (
+	JsonOutput = "json"
+	YamlOutput = "yaml"
+	WideOutput = "wide"
+	NameOutput = "name"
+
+	JsonPathOutputPrefix      = "jsonpath="
+	CustomColumnsOutputPrefix = "custom-columns="
+
+	noneValue = "<none>"
+)
+
+// OutputOpt is the output format of list-like commands, as set by the --output and --no-headers flags
+type OutputOpt struct {
+	Format    string
+	NoHeaders bool
+}
+
+// ListOpt describes the items of a list for the structured output formats
+type ListOpt struct {
+	// the kind of the items, used by the name output, e.g. job/my-job
+	Kind string
+	// a jsonpath template of the item name, e.g. {.name}
+	NamePath string
+}
+
+// Validate checks that the output format is one of the supported formats
+func (opt OutputOpt) Validate() error {
+	switch {
+	case opt.Format == "", opt.Format == WideOutput, opt.Format == JsonOutput, opt.Format == YamlOutput, opt.Format == NameOutput:
+		return nil
+	case strings.HasPrefix(opt.Format, JsonPathOutputPrefix):
+		_, err := newJsonPath(strings.TrimPrefix(opt.Format, JsonPathOutputPrefix))
+		return err
+	case strings.HasPrefix(opt.Format, CustomColumnsOutputPrefix):
+		_, err := parseCustomColumns(strings.TrimPrefix(opt.Format, CustomColumnsOutputPrefix))
+		return err
+	}
+	return fmt.Errorf("unsupported output format: %s, supported formats are json, yaml, wide, name, %sTEMPLATE and %sHEADER:FIELD_PATH,...", opt.Format, JsonPathOutputPrefix, CustomColumnsOutputPrefix)
+}
+
+// IsTable returns true when the items should be printed as the command table, i.e. with the default or the wide output
+func (opt OutputOpt) IsTable() bool {
+	return opt.Format == "" || opt.IsWide()
+}
+
+// IsWide returns true when the table should include the additional columns of the wide output
+func (opt OutputOpt) IsWide() bool {
+	return opt.Format == WideOutput
+}
+
+// PrintList prints the items, a slice of views, in one of the structured output formats.
+// The items are converted to their json representation first, so all the formats use the same field names.
+func PrintList(w io.Writer, items interface{}, listOpt ListOpt, opt OutputOpt) error {
+	objects, err := toJsonObjects(items)
+	if err != nil {
+		return err
+	}
+	list := map[string]interface{}{"items": objects}
+
+	switch {
+	case opt.Format == JsonOutput:
+		content, err := json.MarshalIndent(list, "", "    ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(w, string(content))
+	case opt.Format == YamlOutput:
+		content, err := yaml.Marshal(list)
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(w, string(content))
+	case opt.Format == NameOutput:
+		namePath, err := newJsonPath(listOpt.NamePath)
+		if err != nil {
+			return err
+		}
+		for _, object := range objects {
+			name, err := executeJsonPath(namePath, object)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(w, "%s/%s\n", listOpt.Kind, name)
+		}
+	case strings.HasPrefix(opt.Format, JsonPathOutputPrefix):
+		template, err := newJsonPath(strings.TrimPrefix(opt.Format, JsonPathOutputPrefix))
+		if err != nil {
+			return err
+		}
+		if err = template.Execute(w, list); err != nil {
+			return err
+		}
+		fmt.Fprintln(w)
+	case strings.HasPrefix(opt.Format, CustomColumnsOutputPrefix):
+		columns, err := parseCustomColumns(strings.TrimPrefix(opt.Format, CustomColumnsOutputPrefix))
+		if err != nil {
+			return err
+		}
+		return printCustomColumns(w, columns, objects, opt.NoHeaders)
+	default:
+		return fmt.Errorf("unsupported output format: %s", opt.Format)
+	}
+	return nil
+}
+
+type customColumn struct {
+	header string
+	path   *jsonpath.JSONPath
+}
+
+// parseCustomColumns parses a custom columns spec, e.g. NAME:.name,GPUS:.gpus.allocated
+func parseCustomColumns(spec string) ([]customColumn, error) {
+	columns := []customColumn{}
+	for _, columnSpec := range strings.Split(spec, ",") {
+		parts := strings.SplitN(columnSpec, ":", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return nil, fmt.Errorf("invalid custom column: %s, expected HEADER:FIELD_PATH", columnSpec)
+		}
+		path, err := newJsonPath(parts[1])
+		if err != nil {
+			return nil, err
+		}
+		columns = append(columns, customColumn{header: parts[0], path: path})
+	}
+	return columns, nil
+}
+
+func printCustomColumns(out io.Writer, columns []customColumn, objects []interface{}, noHeaders bool) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	if !noHeaders {
+		headers := []string{}
+		for _, column := range columns {
+			headers = append(headers, column.header)
+		}
+		Line(w, headers...)
+	}
+
+	for _, object := range objects {
+		values := []string{}
+		for _, column := range columns {
+			value, err := executeJsonPath(column.path, object)
+			if err != nil {
+				return err
+			}
+			if value == "" {
+				value = noneValue
+			}
+			values = append(values, value)
+		}
+		Line(w, values...)
+	}
+	return w.Flush()
+}
+
+// newJsonPath parses a jsonpath template. Like kubectl, a bare path such as .name is treated as {.name}
+func newJsonPath(template string) (*jsonpath.JSONPath, error) {
+	if !strings.HasPrefix(template, "{") {
+		template = fmt.Sprintf("{%s}", template)
+	}
+	path := jsonpath.New("output").AllowMissingKeys(true)
+	if err := path.Parse(template); err != nil {
+		return nil, fmt.Errorf("invalid jsonpath template %s: %v", template, err)
+	}
+	return path, nil
+}
+
+func executeJsonPath(path *jsonpath.JSONPath, object interface{}) (string, error) {
+	results, err := path.FindResults(object)
+	if err != nil {
+		return "", err
+	}
+
+	values := []string{}
+	for _, result := range results {
+		for _, value := range result {
+			values = append(values, fmt.Sprintf("%v", value.Interface()))
+		}
+	}
+	return strings.Join(values, ","), nil
+}
+
+func toJsonObjects(items interface{}) ([]interface{}, error) {
+	content, err := json.Marshal(items)
+	if err != nil {
+		return nil, err
+	}
+
+	objects := []interface{}{}
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	// keep integers, e.g. durations, as integers instead of converting all the numbers to float64
+	decoder.UseNumber()
+	if err = decoder.Decode(&objects); err != nil {
+		return nil, err
+	}
+	for i, object := range objects {
+		objects[i] = fromJsonNumbers(object)
+	}
+	return objects, nil
+}
+
+func fromJsonNumbers(value interface{}) interface{} {
+	switch typedValue := value.(type) {
+	case json.Number:
+		if number, err := typedValue.Int64(); err == nil {
+			return number
+		}
+		number, _ := typedValue.Float64()
+		return number
+	case map[string]interface{}:
+		for key, item := range typedValue {
+			typedValue[key] = fromJsonNumbers(item)
+		}
+	case []interface{}:
+		for i, item := range typedValue {
+			typedValue[i] = fromJsonNumbers(item)
+		}
+	}
+	return value
+}
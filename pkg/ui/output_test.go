@@ -0,0 +1,102 @@
+package ui
+
+import (
+	"bytes"
+	"testing"
+	"text/tabwriter"
+)
+
+type outputTestInfo struct {
+	Name string `title:"NAME" json:"name"`
+	GPUs int    `title:"GPUS" json:"gpus"`
+}
+
+type outputTestView struct {
+	Info   outputTestInfo `group:"GENERAL,flatten" json:"info"`
+	Status string         `title:"STATUS" json:"status"`
+}
+
+var outputTestRows = []outputTestView{
+	{Info: outputTestInfo{Name: "job1", GPUs: 1}, Status: "Running"},
+	{Info: outputTestInfo{Name: "job2", GPUs: 2}},
+}
+
+func TestOutputOptValidate(t *testing.T) {
+	valid := []string{"", "wide", "json", "yaml", "name", "jsonpath={.items[*].info.name}", "custom-columns=NAME:.info.name"}
+	for _, format := range valid {
+		if err := (OutputOpt{Format: format}).Validate(); err != nil {
+			t.Errorf("expected output format %s to be valid, got: %v", format, err)
+		}
+	}
+
+	invalid := []string{"table", "jsonpath={.items[", "custom-columns=NAME", "custom-columns=:.name"}
+	for _, format := range invalid {
+		if err := (OutputOpt{Format: format}).Validate(); err == nil {
+			t.Errorf("expected output format %s to be invalid", format)
+		}
+	}
+}
+
+func TestPrintList(t *testing.T) {
+	listOpt := ListOpt{Kind: "job", NamePath: "{.info.name}"}
+	tests := []struct {
+		name     string
+		opt      OutputOpt
+		expected string
+	}{
+		{
+			name:     "name",
+			opt:      OutputOpt{Format: NameOutput},
+			expected: "job/job1\njob/job2\n",
+		},
+		{
+			name:     "jsonpath",
+			opt:      OutputOpt{Format: "jsonpath={range .items[*]}{.info.name}={.info.gpus} {end}"},
+			expected: "job1=1 job2=2 \n",
+		},
+		{
+			name:     "custom columns",
+			opt:      OutputOpt{Format: "custom-columns=JOB:.info.name,STATUS:.status"},
+			expected: "JOB   STATUS\njob1  Running\njob2  <none>\n",
+		},
+		{
+			name:     "custom columns without headers",
+			opt:      OutputOpt{Format: "custom-columns=JOB:.info.name", NoHeaders: true},
+			expected: "job1\njob2\n",
+		},
+		{
+			name:     "yaml",
+			opt:      OutputOpt{Format: YamlOutput},
+			expected: "items:\n- info:\n    gpus: 1\n    name: job1\n  status: Running\n- info:\n    gpus: 2\n    name: job2\n  status: \"\"\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := new(bytes.Buffer)
+			if err := PrintList(b, outputTestRows, listOpt, tt.opt); err != nil {
+				t.Fatalf("failed to print the list: %v", err)
+			}
+			if b.String() != tt.expected {
+				t.Errorf("expected:\n%s\ngot:\n%s", tt.expected, b.String())
+			}
+		})
+	}
+}
+
+func TestTableWithoutHeaders(t *testing.T) {
+	b := new(bytes.Buffer)
+	w := tabwriter.NewWriter(b, 0, 0, 2, ' ', 0)
+
+	rows := []outputTestInfo{{Name: "job1", GPUs: 1}, {Name: "job2", GPUs: 2}}
+	err := CreateTable(outputTestInfo{}, TableOpt{NoHeaders: true}).Render(w, rows).Error()
+	if err != nil {
+		t.Fatalf("failed to build the table: %v", err)
+	}
+	_ = w.Flush()
+
+	expected := "job1  1\njob2  2\n"
+	if b.String() != expected {
+		t.Errorf("expected:\n%q\ngot:\n%q", expected, b.String())
+	}
+}
@@ -43,6 +43,8 @@ type (
 		DisplayOpt
 		// map format name into a function
 		Formatts FormattersByName
+		// render only the rows, without the groups and titles
+		NoHeaders bool
 	}
 
 	// Column data for a table
@@ -107,6 +109,9 @@ func (td *tableData) addGroup(field reflect.StructField, path []string, groupTag
 }
 
 func (td *tableData) Render(w io.Writer, rows interface{}) Table {
+	if td.opt.NoHeaders {
+		return td.RenderRows(w, rows)
+	}
 	return td.RenderHeader(w).RenderRows(w, rows)
 }
 
@@ -0,0 +1,124 @@
+package helm
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	yaml "gopkg.in/yaml.v2"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/engine"
+	"helm.sh/helm/v3/pkg/releaseutil"
+)
+
+const (
+	fileRepositoryPrefix = "file://"
+	notesFileSuffix      = "NOTES.txt"
+)
+
+// RenderChart renders the chart at chartPath in-process, the same way 'helm template' does,
+// and returns the rendered manifests as a single multi-document yaml, sorted in install order.
+func RenderChart(name, namespace string, values interface{}, chartPath string) (string, error) {
+	chrt, err := loadChart(chartPath)
+	if err != nil {
+		return "", err
+	}
+
+	valuesYaml, err := yaml.Marshal(values)
+	if err != nil {
+		return "", err
+	}
+	log.Debugf("values: %s", string(valuesYaml))
+
+	chartValues, err := chartutil.ReadValues(valuesYaml)
+	if err != nil {
+		return "", err
+	}
+
+	if err = chartutil.ProcessDependencies(chrt, chartValues); err != nil {
+		return "", err
+	}
+
+	options := chartutil.ReleaseOptions{
+		Name:      name,
+		Namespace: namespace,
+		Revision:  1,
+		IsInstall: true,
+	}
+	renderValues, err := chartutil.ToRenderValues(chrt, chartValues, options, chartutil.DefaultCapabilities)
+	if err != nil {
+		return "", err
+	}
+
+	files, err := engine.Render(chrt, renderValues)
+	if err != nil {
+		return "", fmt.Errorf("failed to render chart %s: %v", GetChartName(chartPath), err)
+	}
+
+	for fileName := range files {
+		if strings.HasSuffix(fileName, notesFileSuffix) {
+			delete(files, fileName)
+		}
+	}
+
+	_, manifests, err := releaseutil.SortManifests(files, chartutil.DefaultVersionSet, releaseutil.InstallOrder)
+	if err != nil {
+		return "", err
+	}
+
+	var rendered strings.Builder
+	for _, manifest := range manifests {
+		fmt.Fprintf(&rendered, "---\n# Source: %s\n%s\n", manifest.Name, manifest.Content)
+	}
+	return rendered.String(), nil
+}
+
+// GetChartVersion returns the version written in the Chart.yaml of the given chart directory
+func GetChartVersion(chartPath string) (string, error) {
+	metadata, err := chartutil.LoadChartfile(filepath.Join(chartPath, chartutil.ChartfileName))
+	if err != nil {
+		return "", err
+	}
+	return metadata.Version, nil
+}
+
+func GetChartName(chart string) string {
+	return filepath.Base(chart)
+}
+
+// loadChart loads the chart and, when running from a source tree where 'helm dep update' was not run,
+// resolves its file:// dependencies (e.g. runai-common) from the neighbouring chart folders.
+func loadChart(chartPath string) (*chart.Chart, error) {
+	chrt, err := loader.Load(chartPath)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, dependency := range chrt.Metadata.Dependencies {
+		if isDependencyLoaded(chrt, dependency.Name) || !strings.HasPrefix(dependency.Repository, fileRepositoryPrefix) {
+			continue
+		}
+
+		dependencyPath := filepath.Join(chartPath, strings.TrimPrefix(dependency.Repository, fileRepositoryPrefix))
+		log.Debugf("Loading dependency %s of chart %s from %s", dependency.Name, chrt.Name(), dependencyPath)
+		dependencyChart, err := loader.Load(dependencyPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load dependency %s of chart %s: %v", dependency.Name, chrt.Name(), err)
+		}
+		chrt.AddDependency(dependencyChart)
+	}
+
+	return chrt, nil
+}
+
+func isDependencyLoaded(chrt *chart.Chart, name string) bool {
+	for _, dependency := range chrt.Dependencies() {
+		if dependency.Name() == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,63 @@
+package helm
+
+import (
+	"strings"
+	"testing"
+
+	yaml "gopkg.in/yaml.v2"
+	"gotest.tools/assert"
+)
+
+const runaiChartPath = "../../../charts/runai"
+
+// jobValues returns the values of a job as the submit command sets them, git sync is always set
+func jobValues(values map[string]interface{}) map[string]interface{} {
+	values["gitSync"] = map[string]interface{}{"sync": false}
+	return values
+}
+
+func TestRenderChart(t *testing.T) {
+	values := jobValues(map[string]interface{}{
+		"isRunaiJob":  true,
+		"image":       "gcr.io/run-ai-demo/quickstart",
+		"user":        "john",
+		"labels":      map[string]string{"team": "vision"},
+		"serviceType": "nodeport",
+		"ports":       []string{"8888"},
+	})
+
+	manifests, err := RenderChart("train1", "runai-team-a", values, runaiChartPath)
+	assert.NilError(t, err)
+
+	kinds := []string{}
+	for _, document := range strings.Split(manifests, "\n---\n") {
+		object := map[string]interface{}{}
+		assert.NilError(t, yaml.Unmarshal([]byte(document), &object))
+		if len(object) == 0 {
+			continue
+		}
+		kinds = append(kinds, object["kind"].(string))
+		metadata := object["metadata"].(map[interface{}]interface{})
+		assert.Equal(t, metadata["name"], "train1")
+		if object["kind"] == "RunaiJob" {
+			labels := metadata["labels"].(map[interface{}]interface{})
+			assert.Equal(t, labels["team"], "vision")
+		}
+	}
+	// the objects are sorted in install order, services before workloads
+	assert.DeepEqual(t, kinds, []string{"Service", "RunaiJob"})
+	assert.Equal(t, strings.Contains(manifests, notesFileSuffix), false)
+}
+
+func TestRenderChartWithoutService(t *testing.T) {
+	manifests, err := RenderChart("train1", "runai-team-a", jobValues(map[string]interface{}{"image": "ubuntu"}), runaiChartPath)
+	assert.NilError(t, err)
+	assert.Equal(t, strings.Contains(manifests, "kind: Service"), false)
+	assert.Equal(t, strings.Contains(manifests, "kind: \"Job\""), true)
+}
+
+func TestRenderChartFailure(t *testing.T) {
+	// exposing a service requires ports
+	_, err := RenderChart("train1", "runai-team-a", jobValues(map[string]interface{}{"image": "ubuntu", "serviceType": "nodeport"}), runaiChartPath)
+	assert.ErrorContains(t, err, "Ports must be provided")
+}
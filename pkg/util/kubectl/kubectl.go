@@ -396,12 +396,6 @@ func Logs(podName string, namespace string) (string, error) {
 	return kubectl(args)
 }
 
-func PortForward(ports []string, serviceName string, namespace string) error {
-	args := []string{"port-forward", fmt.Sprintf("service/%s", serviceName), "--pod-running-timeout=1m0s", "-n", namespace}
-	args = append(args, ports...)
-	return kubectlAttched(args)
-}
-
 func kubectlAttched(args []string) error {
 	binary, err := exec.LookPath(kubectlCmd[0])
 	if err != nil {
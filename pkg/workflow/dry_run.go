@@ -0,0 +1,43 @@
+package workflow
+
+import (
+	"fmt"
+
+	"github.com/run-ai/runai-cli/pkg/client"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+type DryRunStrategy string
+
+const (
+	DryRunNone   DryRunStrategy = ""
+	DryRunClient DryRunStrategy = "client"
+	DryRunServer DryRunStrategy = "server"
+)
+
+func ParseDryRunStrategy(value string) (DryRunStrategy, error) {
+	switch strategy := DryRunStrategy(value); strategy {
+	case DryRunNone, DryRunClient, DryRunServer:
+		return strategy, nil
+	default:
+		return DryRunNone, fmt.Errorf("invalid dry-run value %s. One of: %s|%s", value, DryRunClient, DryRunServer)
+	}
+}
+
+// DryRunJob renders the job objects without submitting the job. With the server strategy the objects are also
+// sent to the API server in dry run mode, so quota, admission webhook and RBAC errors are reported without
+// creating anything, and the objects are returned as the server would have persisted them.
+func DryRunJob(name, namespace string, values interface{}, chart string, kubeClient *client.Client, strategy DryRunStrategy) ([]*unstructured.Unstructured, error) {
+	job, err := generateJobManifests(name, namespace, values, chart)
+	if err != nil {
+		return nil, err
+	}
+
+	if strategy != DryRunServer {
+		return job.objects, nil
+	}
+
+	applier := newObjectsApplier(kubeClient, namespace)
+	applier.dryRun = true
+	return applier.apply(job.objects)
+}
@@ -0,0 +1,163 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/run-ai/runai-cli/pkg/client"
+	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/wait"
+	yamlutil "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/restmapper"
+)
+
+const (
+	manifestsDecoderBufferSize = 4096
+	deletePollInterval         = 500 * time.Millisecond
+	deleteTimeout              = 30 * time.Second
+)
+
+// decodeObjects splits the rendered chart manifests into kubernetes objects
+func decodeObjects(manifests string) ([]*unstructured.Unstructured, error) {
+	decoder := yamlutil.NewYAMLOrJSONDecoder(strings.NewReader(manifests), manifestsDecoderBufferSize)
+	objects := []*unstructured.Unstructured{}
+	for {
+		object := &unstructured.Unstructured{}
+		err := decoder.Decode(&object.Object)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(object.Object) == 0 {
+			continue
+		}
+		objects = append(objects, object)
+	}
+	return objects, nil
+}
+
+// describeObject returns the object in the format kubectl prints it, e.g. job.batch/my-job
+func describeObject(object *unstructured.Unstructured) string {
+	gvk := object.GroupVersionKind()
+	resource := strings.ToLower(gvk.Kind)
+	if gvk.Group != "" {
+		resource = fmt.Sprintf("%s.%s", resource, gvk.Group)
+	}
+	return fmt.Sprintf("%s/%s", resource, object.GetName())
+}
+
+// getAppInfo returns the objects of the job, one per line, as kept under the "app" key of the job config map
+func getAppInfo(objects []*unstructured.Unstructured) string {
+	lines := []string{}
+	for _, object := range objects {
+		lines = append(lines, describeObject(object))
+	}
+	return strings.Join(lines, "\n")
+}
+
+type objectsApplier struct {
+	dynamicClient dynamic.Interface
+	mapper        meta.RESTMapper
+	namespace     string
+	dryRun        bool
+}
+
+func newObjectsApplier(kubeClient *client.Client, namespace string) *objectsApplier {
+	discoveryClient := memory.NewMemCacheClient(kubeClient.GetClientset().Discovery())
+	return &objectsApplier{
+		dynamicClient: kubeClient.GetDynamicClient(),
+		mapper:        restmapper.NewDeferredDiscoveryRESTMapper(discoveryClient),
+		namespace:     namespace,
+	}
+}
+
+func (oa *objectsApplier) resourceFor(object *unstructured.Unstructured) (dynamic.ResourceInterface, error) {
+	gvk := object.GroupVersionKind()
+	mapping, err := oa.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	if mapping.Scope.Name() == meta.RESTScopeNameRoot {
+		return oa.dynamicClient.Resource(mapping.Resource), nil
+	}
+	object.SetNamespace(oa.namespace)
+	return oa.dynamicClient.Resource(mapping.Resource).Namespace(oa.namespace), nil
+}
+
+// delete removes the objects if they exist and waits until they are gone
+func (oa *objectsApplier) delete(objects []*unstructured.Unstructured) error {
+	propagationPolicy := metav1.DeletePropagationBackground
+	for _, object := range objects {
+		resource, err := oa.resourceFor(object)
+		if err != nil {
+			return err
+		}
+
+		err = resource.Delete(context.TODO(), object.GetName(), metav1.DeleteOptions{PropagationPolicy: &propagationPolicy})
+		if errors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		log.Debugf("Deleted %s", describeObject(object))
+
+		err = wait.PollImmediate(deletePollInterval, deleteTimeout, func() (bool, error) {
+			_, err := resource.Get(context.TODO(), object.GetName(), metav1.GetOptions{})
+			if errors.IsNotFound(err) {
+				return true, nil
+			}
+			return false, err
+		})
+		if err != nil {
+			return fmt.Errorf("failed waiting for %s to be deleted: %v", describeObject(object), err)
+		}
+	}
+	return nil
+}
+
+// apply creates the objects, or updates them when they already exist, and returns them as persisted by the server
+func (oa *objectsApplier) apply(objects []*unstructured.Unstructured) ([]*unstructured.Unstructured, error) {
+	createOptions := metav1.CreateOptions{}
+	updateOptions := metav1.UpdateOptions{}
+	if oa.dryRun {
+		createOptions.DryRun = []string{metav1.DryRunAll}
+		updateOptions.DryRun = []string{metav1.DryRunAll}
+	}
+
+	appliedObjects := []*unstructured.Unstructured{}
+	for _, object := range objects {
+		resource, err := oa.resourceFor(object)
+		if err != nil {
+			return nil, err
+		}
+
+		appliedObject, err := resource.Create(context.TODO(), object, createOptions)
+		if errors.IsAlreadyExists(err) {
+			var existing *unstructured.Unstructured
+			existing, err = resource.Get(context.TODO(), object.GetName(), metav1.GetOptions{})
+			if err == nil {
+				object.SetResourceVersion(existing.GetResourceVersion())
+				appliedObject, err = resource.Update(context.TODO(), object, updateOptions)
+			}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to create %s: %v", describeObject(object), err)
+		}
+		log.Debugf("Applied %s", describeObject(object))
+		appliedObjects = append(appliedObjects, appliedObject)
+	}
+	return appliedObjects, nil
+}
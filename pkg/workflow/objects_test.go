@@ -0,0 +1,163 @@
+package workflow
+
+import (
+	"context"
+	"testing"
+
+	"gotest.tools/assert"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+const testManifests = `---
+# Source: runaijob/templates/runai-service.yaml
+apiVersion: v1
+kind: Service
+metadata:
+  name: train1
+spec:
+  type: 'NodePort'
+---
+# Source: runaijob/templates/runai-job.yaml
+apiVersion: run.ai/v1
+kind: "RunaiJob"
+metadata:
+  name: train1
+  labels:
+    team: vision
+---
+`
+
+var (
+	serviceResource  = schema.GroupVersionResource{Version: "v1", Resource: "services"}
+	runaiJobResource = schema.GroupVersionResource{Group: "run.ai", Version: "v1", Resource: "runaijobs"}
+)
+
+func newTestObjectsApplier() *objectsApplier {
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.AddSpecific(schema.GroupVersionKind{Version: "v1", Kind: "Service"}, serviceResource, schema.GroupVersionResource{Version: "v1", Resource: "service"}, meta.RESTScopeNamespace)
+	mapper.AddSpecific(schema.GroupVersionKind{Group: "run.ai", Version: "v1", Kind: "RunaiJob"}, runaiJobResource, runaiJobResource.GroupVersion().WithResource("runaijob"), meta.RESTScopeNamespace)
+	return &objectsApplier{
+		dynamicClient: dynamicfake.NewSimpleDynamicClient(runtime.NewScheme()),
+		mapper:        mapper,
+		namespace:     "runai-team-a",
+	}
+}
+
+// dryRunRecorder records the dry run options of the objects created through it, which the fake client ignores
+type dryRunRecorder struct {
+	dynamic.Interface
+	dryRuns [][]string
+}
+
+func (r *dryRunRecorder) Resource(resource schema.GroupVersionResource) dynamic.NamespaceableResourceInterface {
+	return &dryRunRecorderResource{NamespaceableResourceInterface: r.Interface.Resource(resource), recorder: r}
+}
+
+type dryRunRecorderResource struct {
+	dynamic.NamespaceableResourceInterface
+	recorder *dryRunRecorder
+}
+
+func (r *dryRunRecorderResource) Namespace(namespace string) dynamic.ResourceInterface {
+	return &dryRunRecorderNamespacedResource{ResourceInterface: r.NamespaceableResourceInterface.Namespace(namespace), recorder: r.recorder}
+}
+
+type dryRunRecorderNamespacedResource struct {
+	dynamic.ResourceInterface
+	recorder *dryRunRecorder
+}
+
+func (r *dryRunRecorderNamespacedResource) Create(ctx context.Context, object *unstructured.Unstructured, options metav1.CreateOptions, subresources ...string) (*unstructured.Unstructured, error) {
+	r.recorder.dryRuns = append(r.recorder.dryRuns, options.DryRun)
+	return r.ResourceInterface.Create(ctx, object, options, subresources...)
+}
+
+func TestDescribeObjects(t *testing.T) {
+	objects, err := decodeObjects(testManifests + `apiVersion: batch/v1
+kind: Job
+metadata:
+  name: train2
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: infer1
+---
+apiVersion: extensions/v1beta1
+kind: Ingress
+metadata:
+  name: train1
+`)
+	assert.NilError(t, err)
+
+	// the same names 'kubectl create --dry-run=client' printed, which were kept in the job config map
+	expected := "service/train1\nrunaijob.run.ai/train1\njob.batch/train2\ndeployment.apps/infer1\ningress.extensions/train1"
+	assert.Equal(t, getAppInfo(objects), expected)
+}
+
+func TestObjectsApplierApply(t *testing.T) {
+	applier := newTestObjectsApplier()
+	objects, err := decodeObjects(testManifests)
+	assert.NilError(t, err)
+
+	applied, err := applier.apply(objects)
+	assert.NilError(t, err)
+	assert.Equal(t, len(applied), 2)
+
+	runaiJob, err := applier.dynamicClient.Resource(runaiJobResource).Namespace("runai-team-a").Get(context.TODO(), "train1", metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, runaiJob.GetLabels()["team"], "vision")
+
+	// applying existing objects updates them
+	objects[1].SetLabels(map[string]string{"team": "nlp"})
+	_, err = applier.apply(objects)
+	assert.NilError(t, err)
+	runaiJob, err = applier.dynamicClient.Resource(runaiJobResource).Namespace("runai-team-a").Get(context.TODO(), "train1", metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, runaiJob.GetLabels()["team"], "nlp")
+}
+
+func TestObjectsApplierDelete(t *testing.T) {
+	applier := newTestObjectsApplier()
+	objects, err := decodeObjects(testManifests)
+	assert.NilError(t, err)
+	_, err = applier.apply(objects[:1])
+	assert.NilError(t, err)
+
+	// objects which don't exist are skipped
+	assert.NilError(t, applier.delete(objects))
+
+	_, err = applier.dynamicClient.Resource(serviceResource).Namespace("runai-team-a").Get(context.TODO(), "train1", metav1.GetOptions{})
+	assert.Equal(t, errors.IsNotFound(err), true)
+}
+
+func TestObjectsApplierDryRun(t *testing.T) {
+	applier := newTestObjectsApplier()
+	recorder := &dryRunRecorder{Interface: applier.dynamicClient}
+	applier.dynamicClient = recorder
+	objects, err := decodeObjects(testManifests)
+	assert.NilError(t, err)
+
+	_, err = applier.apply(objects)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, recorder.dryRuns, [][]string{nil, nil})
+
+	// a server dry run sends the objects to the server, which validates them without persisting them
+	recorder.dryRuns = nil
+	applier.dryRun = true
+	objects, err = decodeObjects(testManifests)
+	assert.NilError(t, err)
+	for _, object := range objects {
+		object.SetName("train2")
+	}
+	_, err = applier.apply(objects)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, recorder.dryRuns, [][]string{{metav1.DryRunAll}, {metav1.DryRunAll}})
+}
@@ -3,17 +3,18 @@ package workflow
 import (
 	"context"
 	"fmt"
-	"io/ioutil"
-	"os"
 	"strconv"
 
 	"k8s.io/apimachinery/pkg/api/errors"
 
+	"github.com/run-ai/runai-cli/pkg/client"
 	"github.com/run-ai/runai-cli/pkg/util/helm"
 	"github.com/run-ai/runai-cli/pkg/util/kubectl"
 	log "github.com/sirupsen/logrus"
+	yaml "gopkg.in/yaml.v2"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/client-go/kubernetes"
 )
 
@@ -23,45 +24,37 @@ const (
 	configMapGenerationRetries = 5
 )
 
-type JobFiles struct {
-	valueFileName   string
-	template        string
-	appInfoFileName string
+type jobManifests struct {
+	values    string
+	manifests string
+	objects   []*unstructured.Unstructured
 }
 
 /**
 *	Submit training job
 **/
 
-func generateJobFiles(name string, namespace string, values interface{}, chart string) (*JobFiles, error) {
-	valueFileName, err := helm.GenerateValueFile(values)
+func generateJobManifests(name string, namespace string, values interface{}, chart string) (*jobManifests, error) {
+	valuesYaml, err := yaml.Marshal(values)
 	if err != nil {
 		return nil, err
 	}
 
-	// 2. Generate Template file
-	template, err := helm.GenerateHelmTemplate(name, namespace, valueFileName, chart)
+	manifests, err := helm.RenderChart(name, namespace, values, chart)
 	if err != nil {
-		cleanupSingleFile(valueFileName)
 		return nil, err
 	}
 
-	// 3. Generate AppInfo file
-	appInfoFileName, err := kubectl.SaveAppInfo(template, namespace)
+	objects, err := decodeObjects(manifests)
 	if err != nil {
-		cleanupSingleFile(template)
-		cleanupSingleFile(valueFileName)
 		return nil, err
 	}
 
-	jobFiles := &JobFiles{
-		valueFileName:   valueFileName,
-		template:        template,
-		appInfoFileName: appInfoFileName,
-	}
-
-	return jobFiles, nil
-
+	return &jobManifests{
+		values:    string(valuesYaml),
+		manifests: manifests,
+		objects:   objects,
+	}, nil
 }
 
 func getConfigMapLabelSelector(configMapName string) string {
@@ -145,52 +138,28 @@ func createEmptyConfigMap(name, baseName, namespace string, index int, clientset
 	return acceptedConfigMap, nil
 }
 
-func populateConfigMap(configMap *corev1.ConfigMap, chartName, chartVersion, valuesFileName, appInfoFileName, namespace string, clientset kubernetes.Interface) error {
+func populateConfigMap(configMap *corev1.ConfigMap, chartName, chartVersion, values, appInfo, namespace string, clientset kubernetes.Interface) error {
 	data := make(map[string]string)
 	data[chartName] = chartVersion
-	valuesFileContent, err := ioutil.ReadFile(valuesFileName)
-	if err != nil {
-		return err
-	}
-	data["values"] = string(valuesFileContent)
-	appFileContent, err := ioutil.ReadFile(appInfoFileName)
-	if err != nil {
-		return err
-	}
-
-	data["app"] = string(appFileContent)
+	data["values"] = values
+	data["app"] = appInfo
 
 	configMap.Data = data
-	_, err = clientset.CoreV1().ConfigMaps(namespace).Update(context.TODO(), configMap, metav1.UpdateOptions{})
+	_, err := clientset.CoreV1().ConfigMaps(namespace).Update(context.TODO(), configMap, metav1.UpdateOptions{})
 	return err
 }
 
-func cleanupSingleFile(file string) {
-	if _, err := os.Stat(file); err == nil {
-		err = os.Remove(file)
-		if err != nil {
-			log.Warnf("Failed to delete %s due to %v", file, err)
-		}
-	}
-}
-
-func cleanupJobFiles(files *JobFiles) {
-	cleanupSingleFile(files.valueFileName)
-	cleanupSingleFile(files.template)
-	cleanupSingleFile(files.appInfoFileName)
-}
-
-func submitJobInternal(name, namespace string, generateSuffix bool, values interface{}, chart string, clientset kubernetes.Interface) (string, error) {
+func submitJobInternal(name, namespace string, generateSuffix bool, values interface{}, chart string, kubeClient *client.Client) (string, error) {
+	clientset := kubeClient.GetClientset()
 	configMap, err := submitConfigMap(name, namespace, generateSuffix, clientset)
 	if err != nil {
 		return "", err
 	}
 	jobName := configMap.Name
-	jobFiles, err := generateJobFiles(jobName, namespace, values, chart)
+	job, err := generateJobManifests(jobName, namespace, values, chart)
 	if err != nil {
 		return jobName, err
 	}
-	defer cleanupJobFiles(jobFiles)
 
 	chartName := helm.GetChartName(chart)
 	chartVersion, err := helm.GetChartVersion(chart)
@@ -198,35 +167,77 @@ func submitJobInternal(name, namespace string, generateSuffix bool, values inter
 		return jobName, err
 	}
 
-	err = populateConfigMap(configMap, chartName, chartVersion, jobFiles.valueFileName, jobFiles.appInfoFileName, namespace, clientset)
+	err = populateConfigMap(configMap, chartName, chartVersion, job.values, getAppInfo(job.objects), namespace, clientset)
 	if err != nil {
 		return jobName, err
 	}
 
-	_, err = kubectl.UninstallAppsWithAppInfoFile(jobFiles.appInfoFileName, namespace)
+	applier := newObjectsApplier(kubeClient, namespace)
+	err = applier.delete(job.objects)
 	if err != nil {
-		log.Debugf("Failed to UninstallAppsWithAppInfoFile due to %v", err)
+		log.Debugf("Failed to delete existing job objects due to %v", err)
 	}
-	_, err = kubectl.InstallApps(jobFiles.template, namespace)
+	_, err = applier.apply(job.objects)
 	if err != nil {
 		return jobName, err
 	}
 	return jobName, nil
 }
 
-func SubmitJob(name, namespace string, generateSuffix bool, values interface{}, chart string, clientset kubernetes.Interface, dryRun bool) (string, error) {
-	if dryRun {
-		jobFiles, err := generateJobFiles(name, namespace, values, chart)
-		if err != nil {
-			return "", err
+func SubmitJob(name, namespace string, generateSuffix bool, values interface{}, chart string, kubeClient *client.Client) (string, error) {
+	jobName, err := submitJobInternal(name, namespace, generateSuffix, values, chart, kubeClient)
+	if err != nil {
+		return "", err
+	}
+	return jobName, nil
+}
+
+// JobConfig is the configuration a job was submitted with, as kept in the job config map
+type JobConfig struct {
+	// Values are the values the job chart was rendered with, in yaml
+	Values string
+	// ChartName is the name of the job chart, e.g. runai or mpijob
+	ChartName string
+	// BaseName is the name which the job name was generated from, e.g. job for job-3
+	BaseName string
+}
+
+// GetJobConfig returns the configuration the job was submitted with
+func GetJobConfig(name, namespace string, clientset kubernetes.Interface) (*JobConfig, error) {
+	configMap, err := clientset.CoreV1().ConfigMaps(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		return nil, fmt.Errorf("could not find the configuration of job %s, only jobs submitted by the cli can be used", name)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	values, found := configMap.Data["values"]
+	if !found {
+		return nil, fmt.Errorf("the configuration of job %s does not contain the values it was submitted with", name)
+	}
+
+	config := &JobConfig{
+		Values:   values,
+		BaseName: configMap.Labels[BaseNameLabelSelectorName],
+	}
+	if config.BaseName == "" {
+		config.BaseName = name
+	}
+	// besides the values and the app info, the config map keeps the version of the job chart by its name
+	for key := range configMap.Data {
+		if key != "values" && key != "app" {
+			config.ChartName = key
 		}
-		fmt.Println("Template YAML file can be found at:")
-		fmt.Println(jobFiles.template)
-		return "", nil
 	}
-	jobName, err := submitJobInternal(name, namespace, generateSuffix, values, chart, clientset)
+	return config, nil
+}
+
+// GetJobValues returns the values the job was submitted with, as kept in the job config map
+func GetJobValues(name, namespace string, clientset kubernetes.Interface) (string, error) {
+	config, err := GetJobConfig(name, namespace, clientset)
 	if err != nil {
 		return "", err
 	}
-	return jobName, nil
+	return config.Values, nil
 }